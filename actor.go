@@ -205,6 +205,16 @@ func NewRestartedActor(restartedHandler func(ctx RestartContext) error, actor ..
 	}
 }
 
+// SupervisorActor 扩展了 Actor 接口，允许 Actor 自行提供监督其子 Actor 的监督策略。
+//
+// 适用于封装了子 Actor 生命周期管理的可复用 Actor（如退避重启监督者），使其无需调用方额外配置监督策略。
+// 优先级低于 WithActorSupervisionStrategy 显式指定的策略，高于系统默认策略。
+type SupervisorActor interface {
+	Actor
+	// SupervisionStrategy 返回监督子 Actor 时使用的监督策略，返回 nil 时使用系统默认策略。
+	SupervisionStrategy() SupervisionStrategy
+}
+
 // ActorFN 是基于函数适配的 Actor 实现方式。
 //
 // 可通过直接传递函数实现 Actor 行为，简化简单业务场景下的类型定义。
@@ -79,6 +79,11 @@ type ActorContext interface {
 	//   - Scheduler：面向当前 ActorContext 生命周期、隔离的调度器实例。
 	Scheduler() Scheduler
 
+	// Clock 返回 ActorSystem 的时钟（参见 WithActorSystemClock）。
+	//
+	// Actor 内需要读取当前时间或计算时长时应使用该时钟而非 time.Now，使相关行为在虚拟时钟下可被精确复现。
+	Clock() Clock
+
 	// Message 返回当前 ActorContext 正在处理的消息实例。
 	//
 	// 特殊消息说明：
@@ -553,6 +558,39 @@ type ActorLiaison interface {
 	//   - 若目标 Actor 不存在或已终止，Future 会立即失败，调用方应处理此类异常情况。
 	Ask(recipient ActorRef, message Message, timeout ...time.Duration) Future[Message]
 
+	// AskAll 向 recipients 中的每个接收者发送同一请求，并在全部应答完成（成功、失败或超时）后汇总结果。
+	//
+	// 参数说明：
+	//   - recipients: 接收者集合，为空时返回的 Future 立即以空切片完成。
+	//   - message: 请求内容，逐一发送给每个接收者。
+	//   - timeout: （可选参数）每个接收者的请求超时，若不指定则采用系统默认 ask 超时时间。
+	//
+	// 返回值：
+	//   - Future[[]AskReply]: 按 recipients 顺序排列的应答，每个接收者的错误记录在对应 AskReply.Error 中，Future 本身不会失败。
+	//
+	// 注意事项：
+	//   - 每个接收者的请求独立计时并复用 Ask 的 Future 机制，不会为每个请求额外创建 goroutine。
+	AskAll(recipients ActorRefs, message Message, timeout ...time.Duration) Future[[]AskReply]
+
+	// AskFirst 向 recipients 中的每个接收者发送同一请求，以最先成功的应答完成。
+	//
+	// 返回值：
+	//   - Future[AskReply]: 最先成功的应答；全部失败时以合并的错误（errors.Join）失败，recipients 为空时以 ErrorFutureInvalid 失败。
+	//
+	// 注意事项：
+	//   - 其余请求不会被取消，其应答在到达或超时后被丢弃。
+	AskFirst(recipients ActorRefs, message Message, timeout ...time.Duration) Future[AskReply]
+
+	// AskQuorum 向 recipients 中的每个接收者发送同一请求，在收到 quorum 个成功应答后完成。
+	//
+	// 返回值：
+	//   - Future[[]AskReply]: 按到达顺序排列的 quorum 个成功应答；
+	//     失败数使法定数量无法达成时以 ErrorFutureQuorumNotReached 失败，quorum 不在 [1, len(recipients)] 范围内时以 ErrorFutureInvalid 失败。
+	//
+	// 注意事项：
+	//   - 达成法定数量后，其余请求不会被取消，其应答在到达或超时后被丢弃。
+	AskQuorum(recipients ActorRefs, quorum int, message Message, timeout ...time.Duration) Future[[]AskReply]
+
 	// Entrust 方法用于在当前 ActorContext 中安全地异步托管一个可自定义的业务任务（EntrustTask），并以 Future[Message] 形式返回其异步结果。
 	//
 	// 功能与行为说明：
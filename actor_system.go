@@ -3,6 +3,7 @@ package vivid
 import (
 	"context"
 	"crypto/tls"
+	"net"
 	"time"
 
 	"github.com/kercylan98/vivid/internal/utils"
@@ -45,6 +46,7 @@ type ActorSystem interface {
 	//   - 停止流程包括向所有活跃 Actor 派发终止信号（如 Poison Pill/FSM 终止），并确保子 Actor 优先于父 Actor 停止，递归释放所有托管的上下文与资源。
 	//   - 用于应用生命周期管理，可保障关闭前所有未处理消息与状态持久化等任务优雅完成，防止资源泄漏及并发冲突。
 	//   - 支持可选的超时参数，用于控制停止过程的时间限制。若超时，系统会立即终止并返回错误。
+	//   - 终止 Actor 前会按顺序执行协调关闭（CoordinatedShutdown）的各阶段任务，超时参数仅作用于最终终止 Actor 的等待。
 	//
 	// 注意事项：
 	//   - 多次调用 Stop() 并无额外副作用，仅首个调用会触发实际终止流程，其余调用会在等待终止完成后直接返回。
@@ -65,6 +67,13 @@ type ActorSystem interface {
 
 	// ActorOf 该方法的效果与 ActorContext.ActorOf 相同，但是它是并发安全的。
 	ActorOf(actor Actor, options ...ActorOption) (ActorRef, error)
+
+	// CoordinatedShutdown 返回协调关闭，用于注册 Stop 时按阶段执行的任务。
+	CoordinatedShutdown() CoordinatedShutdown
+
+	// FailureHistory 返回本节点上 ref 对应 Actor 的故障记录（由旧到新），用于运维诊断与自省。
+	// ref 指向远程节点或本机不存在该 Actor 时返回 ErrorNotFound。
+	FailureHistory(ref ActorRef) ([]FailureRecord, error)
 }
 
 // PrimaryActorSystem 定义了“主”ActorSystem 的扩展接口，代表系统的具体实现，提供创建子 Actor 的能力。
@@ -153,12 +162,24 @@ type ActorSystemOptions struct {
 	// StopTimeout 指定 ActorSystem 停止操作的超时时间。
 	StopTimeout time.Duration
 
+	// ShutdownPhaseTimeouts 指定协调关闭各阶段的超时时间，未指定的阶段使用 DefaultShutdownPhaseTimeout。
+	ShutdownPhaseTimeouts map[ShutdownPhase]time.Duration
+
 	// EnableMetrics 指定是否启用指标收集。
 	// 启用后，系统会自动创建 Metrics Actor 来收集和统计系统运行指标。
 	EnableMetrics bool
 
 	// SupervisionStrategy 指定 ActorSystem 默认的监督策略。
 	SupervisionStrategy SupervisionStrategy
+
+	// Clock 指定 ActorSystem 的时钟，覆盖 Scheduler、Future 超时与指数退避；为 nil 时使用真实时间。
+	Clock Clock
+
+	// Dispatcher 指定本地邮箱的调度器；为 nil 时每个邮箱使用独立的 goroutine 处理消息。
+	Dispatcher Dispatcher
+
+	// FailureHistorySize 指定每个 Actor 保留的故障记录条数，≤0 时使用 DefaultFailureHistorySize。
+	FailureHistorySize int
 }
 
 // WithActorSystemSupervisionStrategy 返回一个 ActorSystemOption，用于指定 ActorSystem 的监督策略。
@@ -424,6 +445,16 @@ type ActorSystemRemotingOptions struct {
 	// TLSConfig 可选；非空时 Remoting 服务端使用 TLS 监听，跨 DC/公网部署时建议启用以保证传输加密与身份校验（如 mTLS）。
 	TLSConfig *tls.Config
 
+	// FrameFilter 可选；非空时对每个收发的远程消息帧调用，返回 false 的帧被静默丢弃，用于在测试中模拟网络分区。
+	FrameFilter RemotingFrameFilter
+
+	// Transport 可选；非空时 Remoting 通过它建立到对端的出站连接，为 nil 时直接使用 TCP 拨号。
+	Transport RemotingTransport
+
+	// Listener 可选；非空时 Remoting 服务端首次启动直接使用该已绑定的监听器而不再按 bindAddr 监听，
+	// 用于先绑定 "127.0.0.1:0" 再读取实际地址作为 bindAddr 的场景，避免端口探测与绑定之间的竞争。
+	Listener net.Listener
+
 	// ClusterOptions 用于配置集群通信相关的选项。
 	ClusterOptions *ClusterOptions
 }
@@ -570,6 +601,27 @@ func WithActorSystemRemotingTLSConfig(cfg *tls.Config) ActorSystemRemotingOption
 	}
 }
 
+// RemotingFrameFilter 远程消息帧过滤器，remoteAddr 为对端的广告地址，outbound 为 true 表示发出的帧、false 表示收到的帧。
+// 返回 false 时该帧被静默丢弃，如同在网络中丢失。
+type RemotingFrameFilter func(remoteAddr string, outbound bool) bool
+
+// WithActorSystemRemotingFrameFilter 返回一个 ActorSystemRemotingOption，用于配置远程消息帧过滤器。
+// 主要用于测试：按对端地址丢弃帧以模拟节点间的网络分区，参见 vividtest.ClusterHarness。
+func WithActorSystemRemotingFrameFilter(filter RemotingFrameFilter) ActorSystemRemotingOption {
+	return func(opts *ActorSystemRemotingOptions) {
+		opts.FrameFilter = filter
+	}
+}
+
+// WithActorSystemRemotingListener 返回一个 ActorSystemRemotingOption，用于指定 Remoting 服务端首次启动时使用的已绑定监听器。
+// 主要用于测试：绑定 "127.0.0.1:0" 后以 listener.Addr() 作为 Remoting 地址，参见 vividtest.ClusterHarness。
+// 配置了 TLSConfig 时监听器会被包装为 TLS 监听器；ActorSystem 停止时监听器随之关闭。
+func WithActorSystemRemotingListener(listener net.Listener) ActorSystemRemotingOption {
+	return func(opts *ActorSystemRemotingOptions) {
+		opts.Listener = listener
+	}
+}
+
 // WithActorSystemRemotingClusterOptions 返回一个 ActorSystemRemotingOption，用于配置集群通信相关的选项。
 //
 // 参数：
@@ -0,0 +1,8 @@
+package vivid
+
+// AskReply 是散播-汇聚式请求（AskAll、AskFirst、AskQuorum）中单个接收者的应答。
+type AskReply struct {
+	Recipient ActorRef // 应答所对应的接收者
+	Message   Message  // 应答消息，Error 非 nil 时为 nil
+	Error     error    // 该接收者的请求错误，如超时（ErrorFutureTimeout）或目标终止
+}
@@ -0,0 +1,55 @@
+package vivid
+
+import "time"
+
+// Clock 时钟抽象，ActorSystem 内部的定时调度（Scheduler）、Future 超时与指数退避均通过它获取时间与定时器。
+//
+// 未指定时使用真实时间；测试中可替换为手动推进的虚拟时钟（参见 vividtest.VirtualClock），使与时间相关的行为可被精确复现。
+type Clock interface {
+	// Now 返回当前时间。
+	Now() time.Time
+
+	// AfterFunc 在 d 之后于独立的 goroutine 中调用 f，返回可用于取消的定时器。
+	AfterFunc(d time.Duration, f func()) ClockTimer
+
+	// Sleep 阻塞当前 goroutine 至少 d 的时长。
+	Sleep(d time.Duration)
+}
+
+// ClockTimer 由 Clock.AfterFunc 创建的定时器。
+type ClockTimer interface {
+	// Stop 阻止定时器触发，若定时器已触发或已停止则返回 false。
+	Stop() bool
+}
+
+// Dispatcher 邮箱调度器，决定邮箱在何处执行消息处理。
+//
+// 未指定时每个邮箱在有消息待处理时启动独立的 goroutine；测试中可替换为单线程的确定性调度器（参见 vividtest.DeterministicDispatcher），
+// 使全部消息按入队顺序逐条投递。
+type Dispatcher interface {
+	// Dispatch 安排执行一次邮箱处理任务，同一邮箱在任务返回前不会再次提交任务。
+	Dispatch(task func())
+
+	// Throughput 返回单次任务最多处理的消息数，≤0 表示处理至邮箱为空。
+	Throughput() int
+}
+
+// WithActorSystemClock 返回一个 ActorSystemOption，用于指定 ActorSystem 的时钟。
+//
+// 参数：
+//   - clock: 期望设置的时钟，为 nil 时使用真实时间。
+func WithActorSystemClock(clock Clock) ActorSystemOption {
+	return func(opts *ActorSystemOptions) {
+		opts.Clock = clock
+	}
+}
+
+// WithActorSystemDispatcher 返回一个 ActorSystemOption，用于指定 ActorSystem 全部本地邮箱的调度器。
+//
+// 参数：
+//   - dispatcher: 期望设置的调度器，为 nil 时每个邮箱使用独立的 goroutine 处理消息。
+func WithActorSystemDispatcher(dispatcher Dispatcher) ActorSystemOption {
+	return func(opts *ActorSystemOptions) {
+		opts.Dispatcher = dispatcher
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kercylan98/vivid/internal/utils"
 )
 
 // QuorumStrategy 法定人数策略：全局多数、多数 DC 参与或每 DC 至少一票。
@@ -30,6 +31,134 @@ const (
 	VersionConcurrentPreferRemote
 )
 
+// SplitBrainStrategy 脑裂处理策略：在成员不可达状态稳定后，决定分区中哪一侧保留、哪一侧下线。
+//
+// 内置策略为 BuiltinSplitBrainStrategy 的各常量，也可实现该接口提供自定义裁决；策略须在全集群一致配置，
+// 且分区两侧对同一组成员应给出互补的结果，否则可能两侧同时保留或同时下线。
+type SplitBrainStrategy interface {
+	// KeepReachableSide 判断本节点所在一侧是否保留。
+	// reachable 为本侧可达成员（含本节点），unreachable 为不可达成员，均按地址升序；options 为本节点的集群配置。
+	KeepReachableSide(options *ClusterOptions, reachable, unreachable []SplitBrainMember) bool
+
+	// String 返回策略的可读名称，用于日志。
+	String() string
+}
+
+// SplitBrainMember 参与脑裂裁决的成员。
+type SplitBrainMember struct {
+	NodeID    string            // 节点唯一标识
+	Address   string            // 节点 Remoting 地址 host:port
+	Timestamp int64             // 成员状态时间戳（UnixNano），值越小表示越早启动
+	Labels    map[string]string // 拓扑等标签
+}
+
+// BuiltinSplitBrainStrategy 内置脑裂处理策略。
+type BuiltinSplitBrainStrategy int
+
+const (
+	// SplitBrainStrategyNone 不启用脑裂处理（默认），不可达成员仍由故障检测超时剔除。
+	SplitBrainStrategyNone BuiltinSplitBrainStrategy = iota
+	// SplitBrainStrategyKeepMajority 保留多数派：可达成员数多的一侧保留；数量相等时保留包含最小地址成员的一侧。
+	SplitBrainStrategyKeepMajority
+	// SplitBrainStrategyKeepOldest 保留最老成员：包含最早启动成员（Timestamp 最小）的一侧保留。
+	SplitBrainStrategyKeepOldest
+	// SplitBrainStrategyKeepReferee 保留裁判：包含 SplitBrainReferee 地址成员的一侧保留，裁判不可达时本侧下线。
+	SplitBrainStrategyKeepReferee
+	// SplitBrainStrategyStaticQuorum 静态法定人数：可达成员数不小于 SplitBrainStaticQuorumSize 的一侧保留。
+	SplitBrainStrategyStaticQuorum
+)
+
+// KeepReachableSide 实现 SplitBrainStrategy 接口，按内置策略判断本侧是否保留。
+func (s BuiltinSplitBrainStrategy) KeepReachableSide(options *ClusterOptions, reachable, unreachable []SplitBrainMember) bool {
+	switch s {
+	case SplitBrainStrategyKeepMajority:
+		if len(reachable) != len(unreachable) {
+			return len(reachable) > len(unreachable)
+		}
+		return lowestSplitBrainAddress(reachable) < lowestSplitBrainAddress(unreachable)
+	case SplitBrainStrategyKeepOldest:
+		oldest, inReachable := "", false
+		var oldestTimestamp int64
+		for i, side := range [][]SplitBrainMember{reachable, unreachable} {
+			for _, m := range side {
+				if oldest == "" || m.Timestamp < oldestTimestamp || (m.Timestamp == oldestTimestamp && m.Address < oldest) {
+					oldest, oldestTimestamp, inReachable = m.Address, m.Timestamp, i == 0
+				}
+			}
+		}
+		return inReachable
+	case SplitBrainStrategyKeepReferee:
+		referee, ok := utils.NormalizeAddress(options.SplitBrainReferee)
+		if !ok {
+			return false
+		}
+		for _, m := range reachable {
+			if addr, ok := utils.NormalizeAddress(m.Address); ok && addr == referee {
+				return true
+			}
+		}
+		return false
+	case SplitBrainStrategyStaticQuorum:
+		return options.SplitBrainStaticQuorumSize > 0 && len(reachable) >= options.SplitBrainStaticQuorumSize
+	default:
+		return true
+	}
+}
+
+// String 返回策略的可读名称。
+func (s BuiltinSplitBrainStrategy) String() string {
+	switch s {
+	case SplitBrainStrategyNone:
+		return "none"
+	case SplitBrainStrategyKeepMajority:
+		return "keep-majority"
+	case SplitBrainStrategyKeepOldest:
+		return "keep-oldest"
+	case SplitBrainStrategyKeepReferee:
+		return "keep-referee"
+	case SplitBrainStrategyStaticQuorum:
+		return "static-quorum"
+	default:
+		return "unknown"
+	}
+}
+
+// lowestSplitBrainAddress 返回成员中最小的地址，无成员时返回空。
+func lowestSplitBrainAddress(members []SplitBrainMember) string {
+	if len(members) == 0 {
+		return ""
+	}
+	lowest := members[0].Address
+	for _, m := range members[1:] {
+		if m.Address < lowest {
+			lowest = m.Address
+		}
+	}
+	return lowest
+}
+
+// FailureDetectorStrategy 成员故障检测算法。
+type FailureDetectorStrategy int
+
+const (
+	// FailureDetectorTimeout 固定超时（默认）：LastSeen 超过 FailureDetectionTimeout 即判定 Suspect/移除。
+	FailureDetectorTimeout FailureDetectorStrategy = iota
+	// FailureDetectorPhiAccrual Phi 累积检测：按每个成员心跳到达间隔的历史分布计算 φ 值，φ 超过 PhiAccrualThreshold 时判定 Suspect。
+	FailureDetectorPhiAccrual
+)
+
+// String 返回检测算法的可读名称。
+func (s FailureDetectorStrategy) String() string {
+	switch s {
+	case FailureDetectorTimeout:
+		return "timeout"
+	case FailureDetectorPhiAccrual:
+		return "phi-accrual"
+	default:
+		return "unknown"
+	}
+}
+
 // SeedsResolver 用于动态解析种子地址；实现方可从 DNS、K8s 等获取。nil 时使用 ClusterOptions 的静态 Seeds/SeedsByDC。
 type SeedsResolver interface {
 	GetSeeds() []string
@@ -37,17 +166,25 @@ type SeedsResolver interface {
 }
 
 type ClusterMemberInfo struct {
-	Address    string // 节点 Remoting 地址 host:port
-	Version    string // 节点在视图中的因果版本号（来自 ClusterView.VersionVector.Get(nodeID)）
-	Datacenter string // 数据中心标识，未配置时为空
-	Rack       string // 机架标识，未配置时为空
-	Region     string // 区域标识，未配置时为空
-	Zone       string // 可用区标识，未配置时为空
+	NodeID     string            // 节点唯一标识
+	Address    string            // 节点 Remoting 地址 host:port
+	Status     string            // 成员状态：joining、weakly-up、up、suspect、unreachable、down、leaving、exiting、removed
+	Generation int               // 重启分代，节点每次重启后递增
+	Labels     map[string]string // 拓扑等标签（副本）
+	Version    string            // 节点在视图中的因果版本号（来自 ClusterView.VersionVector.Get(nodeID)）
+	Datacenter string            // 数据中心标识，未配置时为空
+	Rack       string            // 机架标识，未配置时为空
+	Region     string            // 区域标识，未配置时为空
+	Zone       string            // 可用区标识，未配置时为空
+	AppVersion string            // 应用版本，未配置时为空
+	Available  bool              // 是否可用于路由（up 或 weakly-up）；选主与法定人数仅统计 up
 }
 
 type ClusterView struct {
-	LeaderAddr string // 领导者地址
-	InQuorum   bool   // 当前节点是否处于多数派
+	LeaderAddr   string // 领导者地址
+	InQuorum     bool   // 当前节点是否处于多数派
+	HealthyCount int    // 视图中健康（Up）成员数
+	QuorumSize   int    // 法定人数
 }
 
 // ClusterContext 供业务在运行时访问集群能力：成员列表、多数派状态、优雅退出、集群单例引用等。
@@ -61,6 +198,108 @@ type ClusterContext interface {
 	SingletonRef(name string) (ActorRef, error)
 	// GetView 返回当前集群视图；未启用集群时返回 ErrorClusterDisabled。
 	GetView() (*ClusterView, error)
+	// Down 将 address 对应成员强制下线，管理令牌由本节点 AdminSecret 自动计算；视图中不存在该地址时返回 ErrorNotFound。
+	Down(address string) error
+	// ForceBroadcast 立即触发一轮视图广播，管理令牌由本节点 AdminSecret 自动计算。
+	ForceBroadcast() error
+	// Join 令本节点向 seeds 发起加入并合并其视图；所有种子均失败时返回最后一个错误，节点离开中时返回 ErrorIllegalArgument。
+	Join(seeds []string) error
+	// LeaveAddress 令 address 对应节点优雅退出集群（为本节点时等价于 Leave）；远程节点配置了 AdminSecret 时须与本节点一致。
+	LeaveAddress(address string) error
+	// RegisterService 将 ref 注册到本节点的集群客户端接待者，集群外部的 ClusterClient 可按 ref 的路径向其发送消息；ref 终止后自动注销。
+	RegisterService(ref ActorRef) error
+	// UnregisterService 从本节点的集群客户端接待者注销 ref。
+	UnregisterService(ref ActorRef) error
+	// Register 将本节点的 ref 以 key 注册到集群服务注册表，注册随成员间同步在全集群可见；ref 终止或本节点离开集群后自动移除。
+	Register(key ServiceKeyID, ref ActorRef) error
+	// Deregister 从集群服务注册表移除 ref 在 key 下的注册。
+	Deregister(key ServiceKeyID, ref ActorRef) error
+	// Find 返回本节点已知的 key 在全集群的注册列表（最终一致）。
+	Find(key ServiceKeyID) ([]ActorRef, error)
+	// Subscribe 订阅 key 的注册列表：subscriber 立即收到一次当前列表，之后列表每次变化时收到 ves.ClusterServiceListingEvent；subscriber 终止后自动退订。
+	Subscribe(key ServiceKeyID, subscriber ActorRef) error
+	// Unsubscribe 取消 subscriber 对 key 的订阅。
+	Unsubscribe(key ServiceKeyID, subscriber ActorRef) error
+}
+
+// ServiceKeyID 是集群服务键的非泛型视图，由 ServiceKey 实现，供 ClusterContext 的注册表方法使用。
+type ServiceKeyID interface {
+	ID() string
+}
+
+// ServiceKey 标识集群服务注册表中的一类服务；T 为该服务接收的消息类型，注册表按 ID 匹配。
+// 经 ServiceKey 的 Register、Find、Subscribe 访问注册表时，查找结果转换为 ServiceRef[T]，向其发送的消息在编译期即限定为 T。
+type ServiceKey[T any] struct {
+	id string
+}
+
+// NewServiceKey 创建标识为 id 的服务键，相同 id 的键指向同一组注册。
+func NewServiceKey[T any](id string) ServiceKey[T] {
+	return ServiceKey[T]{id: id}
+}
+
+// ID 返回服务键标识。
+func (k ServiceKey[T]) ID() string {
+	return k.id
+}
+
+// Register 将本节点的 ref 以 k 注册到 cluster 的服务注册表，ref 应为接收 T 类型消息的 Actor；cluster 为 nil 时返回 ErrorClusterDisabled。
+func (k ServiceKey[T]) Register(cluster ClusterContext, ref ActorRef) error {
+	if cluster == nil {
+		return ErrorClusterDisabled
+	}
+	return cluster.Register(k, ref)
+}
+
+// Find 返回 k 在全集群的注册列表（最终一致），转换为只接受 T 类型消息的 ServiceRef；cluster 为 nil 时返回 ErrorClusterDisabled。
+func (k ServiceKey[T]) Find(cluster ClusterContext) ([]ServiceRef[T], error) {
+	if cluster == nil {
+		return nil, ErrorClusterDisabled
+	}
+	refs, err := cluster.Find(k)
+	if err != nil {
+		return nil, err
+	}
+	return k.Refs(refs), nil
+}
+
+// Subscribe 令 subscriber 订阅 k 的注册列表，收到的 ves.ClusterServiceListingEvent 可经 Refs 转换为 ServiceRef；cluster 为 nil 时返回 ErrorClusterDisabled。
+func (k ServiceKey[T]) Subscribe(cluster ClusterContext, subscriber ActorRef) error {
+	if cluster == nil {
+		return ErrorClusterDisabled
+	}
+	return cluster.Subscribe(k, subscriber)
+}
+
+// Refs 将 k 的注册列表（如 ves.ClusterServiceListingEvent 的 Refs）转换为 ServiceRef，nil 元素被跳过。
+func (k ServiceKey[T]) Refs(refs []ActorRef) []ServiceRef[T] {
+	out := make([]ServiceRef[T], 0, len(refs))
+	for _, ref := range refs {
+		if ref != nil {
+			out = append(out, ServiceRef[T]{ref: ref})
+		}
+	}
+	return out
+}
+
+// ServiceRef 是注册在 ServiceKey[T] 下的服务引用，Tell 与 Ask 只接受 T 类型的消息。
+type ServiceRef[T any] struct {
+	ref ActorRef
+}
+
+// Ref 返回底层的 ActorRef。
+func (r ServiceRef[T]) Ref() ActorRef {
+	return r.ref
+}
+
+// Tell 经 liaison（通常为 ActorContext 或 ActorSystem）向服务发送 message。
+func (r ServiceRef[T]) Tell(liaison ActorLiaison, message T) {
+	liaison.Tell(r.ref, message)
+}
+
+// Ask 经 liaison 向服务发送请求 message，并返回等待应答的 Future。
+func (r ServiceRef[T]) Ask(liaison ActorLiaison, message T, timeout ...time.Duration) Future[Message] {
+	return liaison.Ask(r.ref, message, timeout...)
 }
 
 // ClusterOptions 封装集群节点（NodeActor）的启动期配置，所有字段均在创建时确定，设计为不可变、不在运行时修改。
@@ -96,6 +335,12 @@ type ClusterOptions struct {
 	Region string
 	// Zone 本节点所在可用区标识，会写入 NodeState.Labels。
 	Zone string
+	// AppVersion 本节点应用版本（如 "1.4.2"），会写入 NodeState.Labels 并随 Gossip 扩散，用于滚动升级；与协议版本范围无关。
+	AppVersion string
+	// TargetAppVersion 滚动升级的目标应用版本；非空时全体在役成员的应用版本均达到该版本后发布 ves.ClusterAppVersionReachedEvent，为空时不发布。
+	TargetAppVersion string
+	// PreferNewestAppVersion 为 true 时选主（及随 Leader 放置的集群单例）仅在应用版本最新的 Up 成员中进行；需全集群一致配置。
+	PreferNewestAppVersion bool
 	// SeedsResolver 可选；非空时 GetSeeds 与 GetSeedsByDC 由此提供，用于动态发现（如 DNS、K8s）；nil 时使用静态 Seeds/SeedsByDC。
 	SeedsResolver SeedsResolver
 	// AdminSecret 可选；非空时管理消息（强制下线、触发广播）须携带匹配的 AdminToken，否则拒绝。
@@ -146,6 +391,34 @@ type ClusterOptions struct {
 	JoinAskTimeout time.Duration
 	// GetViewAskTimeout Quorum 恢复等 GetView 请求的超时时长。未设置或 ≤0 时使用系统 DefaultAskTimeout；建议 1s–30s。
 	GetViewAskTimeout time.Duration
+	// SplitBrainStrategy 脑裂处理策略；为 nil 或 SplitBrainStrategyNone 时不启用。启用后故障检测仅将超时成员置为 Suspect，是否剔除由脑裂处理器裁决。
+	SplitBrainStrategy SplitBrainStrategy
+	// SplitBrainStableAfter 不可达成员集合需保持不变的时长，超过后脑裂处理器才作出裁决。≤0 时使用默认 20s。
+	SplitBrainStableAfter time.Duration
+	// SplitBrainDownAllWhenUnstable 不稳定保护：若不可达成员集合持续变化超过「SplitBrainStableAfter + 本时长」仍未稳定，则所有节点下线。≤0 表示不启用。
+	SplitBrainDownAllWhenUnstable time.Duration
+	// SplitBrainReferee 裁判节点地址（host:port），仅 SplitBrainStrategyKeepReferee 使用。
+	SplitBrainReferee string
+	// SplitBrainStaticQuorumSize 静态法定人数，仅 SplitBrainStrategyStaticQuorum 使用；≤0 时该策略总是判定本侧下线。
+	SplitBrainStaticQuorumSize int
+	// FailureDetectorStrategy 故障检测算法；默认 FailureDetectorTimeout。为 FailureDetectorPhiAccrual 时以 φ 阈值代替固定超时判定 Suspect（FailureDetectionTimeout ≤0 仍表示关闭自动检测），Suspect 确认与脑裂处理规则不变。
+	FailureDetectorStrategy FailureDetectorStrategy
+	// PhiAccrualThreshold Phi 累积检测的判定阈值，φ 达到此值即视为 Suspect；≤0 时使用默认 8。值越大误判越少、检测越慢。
+	PhiAccrualThreshold float64
+	// PhiAccrualMinStdDeviation 心跳间隔的最小标准差，避免间隔过于规律时微小抖动即触发误判；≤0 时使用默认 100ms。
+	PhiAccrualMinStdDeviation time.Duration
+	// PhiAccrualMaxSampleSize 每个成员保留的心跳间隔样本数上限；≤0 时使用默认 200。
+	PhiAccrualMaxSampleSize int
+	// IndirectProbeCount 间接探测（SWIM）协助节点数 K；>0 时成员超时后先请求 K 个其他成员代为探测，仅当间接探测也失败时才置为 Suspect。≤0 表示不启用。
+	IndirectProbeCount int
+	// IndirectProbeTimeout 等待间接探测回复的时长；≤0 时使用默认 1s。超时未收到任何回复即视为探测失败。
+	IndirectProbeTimeout time.Duration
+	// WeaklyUpAfter >0 时启用收敛门控的加入流程：新成员以 Joining 进入视图，由 Leader 在视图收敛（无 Suspect/Unreachable 成员）时晋升为 Up；
+	// 若加入后超过该时长仍未收敛，则先晋升为 WeaklyUp 以便路由使用，收敛后再晋升为 Up。≤0 表示不启用（加入即 Up）。
+	WeaklyUpAfter time.Duration
+	// DeltaGossip 是否启用增量 Gossip。启用后按版本向量仅向目标发送其上次确认版本之后变更的成员；目标版本未知或无法增量时回退为全量视图。
+	// 需集群内所有节点均支持增量消息，滚动升级期间应保持关闭。默认 false。
+	DeltaGossip bool
 	// SingletonTemplates 集群单例模板：key 为单例逻辑名，value 为创建实例的 ActorProvider。仅当非空时创建 ClusterSingletonManager。
 	SingletonTemplates map[string]ActorProvider
 }
@@ -265,6 +538,27 @@ func WithClusterZone(zone string) ClusterOption {
 	}
 }
 
+// WithClusterAppVersion 返回一个 ClusterOption，用于设置本节点应用版本，版本按点分段比较（数字段按数值，其余按字典序）。
+func WithClusterAppVersion(version string) ClusterOption {
+	return func(o *ClusterOptions) {
+		o.AppVersion = version
+	}
+}
+
+// WithClusterTargetAppVersion 返回一个 ClusterOption，用于设置滚动升级的目标应用版本，全体在役成员达到该版本时发布 ves.ClusterAppVersionReachedEvent。
+func WithClusterTargetAppVersion(version string) ClusterOption {
+	return func(o *ClusterOptions) {
+		o.TargetAppVersion = version
+	}
+}
+
+// WithClusterPreferNewestAppVersion 返回一个 ClusterOption，用于设置选主与集群单例是否优先应用版本最新的成员。
+func WithClusterPreferNewestAppVersion(prefer bool) ClusterOption {
+	return func(o *ClusterOptions) {
+		o.PreferNewestAppVersion = prefer
+	}
+}
+
 // WithClusterSeedsResolver 返回一个 ClusterOption，用于设置动态种子解析器；nil 时使用静态 Seeds/SeedsByDC。
 func WithClusterSeedsResolver(r SeedsResolver) ClusterOption {
 	return func(o *ClusterOptions) {
@@ -435,6 +729,78 @@ func WithClusterSingleton(name string, provider ActorProvider) ClusterOption {
 	}
 }
 
+// WithClusterSplitBrainResolver 返回一个 ClusterOption，用于启用脑裂处理器并设置策略与稳定时长。
+// stableAfter 为不可达成员集合保持不变的时长，≤0 时使用默认 20s；strategy 为 nil 或 SplitBrainStrategyNone 时关闭脑裂处理。
+func WithClusterSplitBrainResolver(strategy SplitBrainStrategy, stableAfter time.Duration) ClusterOption {
+	return func(o *ClusterOptions) {
+		o.SplitBrainStrategy = strategy
+		o.SplitBrainStableAfter = stableAfter
+	}
+}
+
+// WithClusterSplitBrainDownAllWhenUnstable 返回一个 ClusterOption，用于设置不稳定保护时长。
+// 不可达成员集合在「稳定时长 + d」内持续变化时，所有节点下线；≤0 表示不启用。
+func WithClusterSplitBrainDownAllWhenUnstable(d time.Duration) ClusterOption {
+	return func(o *ClusterOptions) {
+		o.SplitBrainDownAllWhenUnstable = d
+	}
+}
+
+// WithClusterSplitBrainReferee 返回一个 ClusterOption，用于设置 SplitBrainStrategyKeepReferee 的裁判节点地址（host:port）。
+func WithClusterSplitBrainReferee(address string) ClusterOption {
+	return func(o *ClusterOptions) {
+		o.SplitBrainReferee = address
+	}
+}
+
+// WithClusterSplitBrainStaticQuorumSize 返回一个 ClusterOption，用于设置 SplitBrainStrategyStaticQuorum 的静态法定人数。
+func WithClusterSplitBrainStaticQuorumSize(size int) ClusterOption {
+	return func(o *ClusterOptions) {
+		o.SplitBrainStaticQuorumSize = size
+	}
+}
+
+// WithClusterPhiAccrualFailureDetector 返回一个 ClusterOption，用于启用 Phi 累积故障检测并设置判定阈值与最小标准差。
+// threshold ≤0 时使用默认 8；minStdDeviation ≤0 时使用默认 100ms。
+func WithClusterPhiAccrualFailureDetector(threshold float64, minStdDeviation time.Duration) ClusterOption {
+	return func(o *ClusterOptions) {
+		o.FailureDetectorStrategy = FailureDetectorPhiAccrual
+		o.PhiAccrualThreshold = threshold
+		o.PhiAccrualMinStdDeviation = minStdDeviation
+	}
+}
+
+// WithClusterPhiAccrualMaxSampleSize 返回一个 ClusterOption，用于设置 Phi 累积检测每个成员保留的心跳间隔样本数上限。
+func WithClusterPhiAccrualMaxSampleSize(n int) ClusterOption {
+	return func(o *ClusterOptions) {
+		o.PhiAccrualMaxSampleSize = n
+	}
+}
+
+// WithClusterIndirectProbe 返回一个 ClusterOption，用于启用间接探测：成员超时后先请求 k 个其他成员代为探测，避免单条链路故障导致误判。
+// k ≤0 表示不启用；timeout ≤0 时使用默认 1s。
+func WithClusterIndirectProbe(k int, timeout time.Duration) ClusterOption {
+	return func(o *ClusterOptions) {
+		o.IndirectProbeCount = k
+		o.IndirectProbeTimeout = timeout
+	}
+}
+
+// WithClusterWeaklyUp 返回一个 ClusterOption，用于启用 WeaklyUp：部分成员不可达导致视图无法收敛时，新成员加入超过 after 后即可用于路由。
+// after ≤0 表示不启用。
+func WithClusterWeaklyUp(after time.Duration) ClusterOption {
+	return func(o *ClusterOptions) {
+		o.WeaklyUpAfter = after
+	}
+}
+
+// WithClusterDeltaGossip 返回一个 ClusterOption，用于启用或关闭增量 Gossip；大规模集群下可显著降低 Gossip 流量。
+func WithClusterDeltaGossip(enabled bool) ClusterOption {
+	return func(o *ClusterOptions) {
+		o.DeltaGossip = enabled
+	}
+}
+
 // clusterSpawner 由 internal/cluster 在 init 中通过 RegisterClusterSpawner 注册，SpawnNodeActor 调用时用于实际创建 NodeActor。
 var clusterSpawner func(system ActorSystem, opts *ClusterOptions) (ActorRef, error)
 
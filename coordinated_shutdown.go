@@ -0,0 +1,66 @@
+package vivid
+
+import (
+	"context"
+	"time"
+)
+
+// ShutdownPhase 协调关闭（CoordinatedShutdown）的阶段名称。
+// ActorSystem.Stop 按 ShutdownPhases 返回的顺序依次执行各阶段，前一阶段全部任务结束或超时后才进入下一阶段。
+type ShutdownPhase string
+
+const (
+	// ShutdownPhaseBeforeServiceUnbind 对外服务解绑前的阶段，适合停止接收新请求、摘除负载均衡等。
+	ShutdownPhaseBeforeServiceUnbind ShutdownPhase = "before-service-unbind"
+	// ShutdownPhaseClusterLeave 离开集群阶段，启用集群时系统内置任务会执行 ClusterContext.Leave。
+	ShutdownPhaseClusterLeave ShutdownPhase = "cluster-leave"
+	// ShutdownPhaseClusterShutdown 已离开集群、Actor 仍在运行的阶段，适合释放与集群相关的外部资源。
+	ShutdownPhaseClusterShutdown ShutdownPhase = "cluster-shutdown"
+	// ShutdownPhaseActorSystemTerminate 终止 ActorSystem 阶段，该阶段任务执行完毕后系统才终止全部 Actor。
+	ShutdownPhaseActorSystemTerminate ShutdownPhase = "actor-system-terminate"
+)
+
+const (
+	// DefaultShutdownPhaseTimeout 每个关闭阶段的默认超时时间。
+	DefaultShutdownPhaseTimeout = 10 * time.Second
+)
+
+// ShutdownPhases 按执行顺序返回全部关闭阶段。
+func ShutdownPhases() []ShutdownPhase {
+	return []ShutdownPhase{
+		ShutdownPhaseBeforeServiceUnbind,
+		ShutdownPhaseClusterLeave,
+		ShutdownPhaseClusterShutdown,
+		ShutdownPhaseActorSystemTerminate,
+	}
+}
+
+// ShutdownTask 关闭阶段中执行的任务；ctx 在所属阶段超时后取消，任务应尽快返回。
+type ShutdownTask = func(ctx context.Context) error
+
+// CoordinatedShutdown 协调关闭，管理 ActorSystem.Stop 时按阶段执行的任务。
+//
+// 同一阶段内的任务并发执行，阶段超时后不再等待未完成的任务并进入下一阶段；
+// 任务返回的错误与阶段超时仅记录日志，不会中断关闭流程。
+type CoordinatedShutdown interface {
+	// AddTask 向 phase 阶段注册名为 name 的任务。
+	// phase 不是已知阶段或 task 为 nil 时返回 ErrorIllegalArgument；关闭已开始后返回 ErrorActorSystemStopped。
+	AddTask(phase ShutdownPhase, name string, task ShutdownTask) error
+}
+
+// WithActorSystemShutdownPhaseTimeout 返回一个 ActorSystemOption，用于指定协调关闭某一阶段的超时时间。
+//
+// 参数：
+//   - phase: 关闭阶段。
+//   - timeout: 该阶段的超时时间，仅当 timeout > 0 时生效；未指定的阶段使用 DefaultShutdownPhaseTimeout。
+func WithActorSystemShutdownPhaseTimeout(phase ShutdownPhase, timeout time.Duration) ActorSystemOption {
+	return func(opts *ActorSystemOptions) {
+		if timeout <= 0 {
+			return
+		}
+		if opts.ShutdownPhaseTimeouts == nil {
+			opts.ShutdownPhaseTimeouts = make(map[ShutdownPhase]time.Duration)
+		}
+		opts.ShutdownPhaseTimeouts[phase] = timeout
+	}
+}
@@ -33,6 +33,7 @@ var (
 	ErrorFutureInvalid             = RegisterError(110003, "future invalid", ErrorIllegalArgument)               // 创建时 timeout 非法
 	ErrorInvalidMessageLength      = RegisterError(110004, "invalid message length", ErrorIllegalArgument)       // 消息长度非法
 	ErrorReadMessageBufferFailed   = RegisterError(110005, "read message buffer failed")   // 读消息缓冲失败
+	ErrorFutureQuorumNotReached    = RegisterError(110006, "future quorum not reached")    // AskQuorum 成功应答数无法达到法定数量
 )
 
 // 参数、调度与状态相关错误。
@@ -77,6 +78,25 @@ var (
 	ErrorClusterProtocolVersionMismatch = RegisterError(150006, "cluster protocol version mismatch") // 集群协议版本不兼容
 	ErrorClusterJoinNotAllowed          = RegisterError(150007, "cluster join not allowed")          // 地址或 DC 不在白名单
 	ErrorClusterAdminAuthFailed         = RegisterError(150008, "cluster admin auth failed")         // 管理操作 Token 无效
+	ErrorClusterSeedsResolveFailed      = RegisterError(150009, "cluster seeds resolve failed")      // 种子发现（DNS/文件/HTTP）解析失败
+	ErrorClusterClientUnavailable       = RegisterError(150010, "cluster client unavailable")        // 集群客户端所有联系点均不可达
+	ErrorClusterServiceNotRegistered    = RegisterError(150011, "cluster service not registered", ErrorNotFound) // 接待者所在节点未注册该服务
+)
+
+// 可靠投递相关错误。
+var (
+	ErrorReliableDeliveryBufferFull = RegisterError(160000, "reliable delivery unconfirmed buffer full") // 未确认消息数达到上限
+)
+
+// 流控相关错误。
+var (
+	ErrorFlowBufferFull = RegisterError(170000, "flow producer buffer full") // 无可用需求且缓冲区已满
+)
+
+// 流相关错误。
+var (
+	ErrorStreamAborted     = RegisterError(180000, "stream aborted")      // 流结束前根 Actor 被终止
+	ErrorStreamStageFailed = RegisterError(180001, "stream stage failed") // 阶段算子 panic
 )
 
 var _ error = (*Error)(nil)
@@ -79,6 +79,7 @@ type Context struct {
 	watchers      map[string]vivid.ActorRef          // 正在监听该 Actor 终止事件的 ActorRef，其中 key 为 ActorRef 的完整路径
 	stash         []vivid.Envelop                    // 暂存区
 	scheduler     *Scheduler                         // 调度器
+	failures      failureHistory                     // 有界故障记录，由监督者写入
 }
 
 func (c *Context) Cluster() vivid.ClusterContext {
@@ -160,6 +161,10 @@ func (c *Context) Scheduler() vivid.Scheduler {
 	return c.scheduler
 }
 
+func (c *Context) Clock() vivid.Clock {
+	return c.system.clock
+}
+
 func (c *Context) ActorOf(actor vivid.Actor, options ...vivid.ActorOption) (vivid.ActorRef, error) {
 	var status = atomic.LoadInt32(&c.state)
 	if status == killed {
@@ -222,7 +227,7 @@ func (c *Context) Ask(recipient vivid.ActorRef, message vivid.Message, timeout .
 	return c.ask(false, recipient, message, timeout...)
 }
 
-func (c *Context) ask(system bool, recipient vivid.ActorRef, message vivid.Message, timeout ...time.Duration) vivid.Future[vivid.Message] {
+func (c *Context) ask(system bool, recipient vivid.ActorRef, message vivid.Message, timeout ...time.Duration) *future.Future[vivid.Message] {
 	var askTimeout = c.options.DefaultAskTimeout
 	if len(timeout) > 0 {
 		askTimeout = timeout[0]
@@ -230,7 +235,7 @@ func (c *Context) ask(system bool, recipient vivid.ActorRef, message vivid.Messa
 
 	// Context 本身被构建后，其 ref 一定是有效的，此处错误可忽略。
 	agentRef, _ := NewAgentRef(c.ref)
-	futureIns := future.NewFuture[vivid.Message](c, askTimeout, func() {
+	futureIns := future.NewFuture[vivid.Message](c, c.system.clock, askTimeout, func() {
 		c.system.removeFuture(agentRef)
 	})
 	c.system.appendFuture(agentRef, futureIns)
@@ -247,7 +252,7 @@ func (c *Context) Entrust(timeout time.Duration, task vivid.EntrustTask) vivid.F
 		return future.NewFutureFail[vivid.Message](vivid.ErrorFutureInvalid.WithMessage("no task to be executed"))
 	}
 
-	futureIns := future.NewFuture[vivid.Message](c, timeout, nil)
+	futureIns := future.NewFuture[vivid.Message](c, c.system.clock, timeout, nil)
 	go func() {
 		defer func() {
 			if r := recover(); r != nil {
@@ -303,6 +308,10 @@ func (c *Context) HandleEnvelop(envelop vivid.Envelop) {
 	currentState := atomic.LoadInt32(&c.state)
 	killingOrKilled := (currentState == killed) || (!envelop.System() && currentState != running) // 是否处于停止中或死亡状态
 	if killingOrKilled && !c.zombie {                                                             // 是否处于僵尸状态
+		// 死信本身无法处理时直接丢弃，避免已停止的系统 Actor 向自身循环投递死信
+		if _, ok := envelop.Message().(ves.DeathLetterEvent); ok {
+			return
+		}
 		c.system.TellSelf(ves.DeathLetterEvent{
 			Envelope: envelop,
 			Time:     time.Now(),
@@ -645,7 +654,7 @@ func (c *Context) Children() vivid.ActorRefs {
 func (c *Context) failed(fault vivid.Message) {
 	// 记录第一现场，且挂起当前 Actor 的消息处理并且向父级 Actor 发送监督上下文以触发父级 Actor 的监督策略
 	c.mailbox.Pause()
-	supervisionContext := newSupervisionContext(c.ref, fault)
+	supervisionContext := newSupervisionContext(c.ref, fault, c.system.clock)
 	c.Logger().Error("supervision: actor failed", log.String("id", supervisionContext.ID()), log.String("path", c.ref.GetPath()), log.String("fault_type", fmt.Sprintf("%T", fault)), log.Any("fault", fault), log.Any("stack", errors.New(string(supervisionContext.FaultStack()))))
 
 	c.tell(true, c.parent, supervisionContext)
@@ -654,6 +663,7 @@ func (c *Context) failed(fault vivid.Message) {
 		ActorRef: c.ref,
 		Type:     reflect.TypeOf(c.actor),
 		Fault:    fault,
+		Time:     supervisionContext.failedAt,
 	})
 	// 通知事件流：邮箱暂停
 	c.EventStream().Publish(c, ves.ActorMailboxPausedEvent{
@@ -678,13 +688,20 @@ func (c *Context) onSupervise(supervisionContext *supervisionContext) {
 	)
 
 	// 获取影响的目标和决策
-	supervisionStrategy := c.options.SupervisionStrategy
-	if supervisionStrategy == nil {
-		supervisionStrategy = c.system.options.SupervisionStrategy
+	targets, decision, reason = c.supervisionStrategy().Supervise(supervisionContext)
+	supervisionContext.recordFailure(c, decision, reason)
+	for _, report := range supervisionContext.budgetExhausted {
+		c.Logger().Warn("supervision: restart budget exhausted", log.String("id", supervisionContext.ID()), log.String("child", report.child.GetPath()), log.Int("max_retries", report.maxRetries), log.Duration("within", report.within), log.String("decision", report.decision.String()))
+		c.EventStream().Publish(c, ves.ActorRestartBudgetExhaustedEvent{
+			ActorRef:       report.child,
+			Supervisor:     c.ref,
+			MaxRetries:     report.maxRetries,
+			WithinDuration: report.within,
+			Decision:       report.decision,
+			Fault:          supervisionContext.fault,
+		})
 	}
 
-	targets, decision, reason = supervisionStrategy.Supervise(supervisionContext)
-
 	// 暂停所有目标的邮箱消息处理
 	mailboxPauseMessage := messages.CommandPauseMailbox.Build()
 	for _, target := range targets {
@@ -693,6 +710,18 @@ func (c *Context) onSupervise(supervisionContext *supervisionContext) {
 	supervisionContext.applyDecision(c, targets, decision, reason)
 }
 
+// supervisionStrategy 返回当前 Actor 监督子 Actor 使用的策略：优先配置项，其次 vivid.SupervisorActor 提供的策略，最后为系统默认策略。
+func (c *Context) supervisionStrategy() vivid.SupervisionStrategy {
+	supervisionStrategy := c.options.SupervisionStrategy
+	if supervisorActor, ok := c.actor.(vivid.SupervisorActor); ok && supervisionStrategy == nil {
+		supervisionStrategy = supervisorActor.SupervisionStrategy()
+	}
+	if supervisionStrategy == nil {
+		supervisionStrategy = c.system.options.SupervisionStrategy
+	}
+	return supervisionStrategy
+}
+
 // 目前该消息暂无任何字段，将其固化避免额外的内存分配
 var watchMessage = new(messages.WatchMessage)
 
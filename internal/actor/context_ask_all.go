@@ -0,0 +1,113 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/future"
+)
+
+func (c *Context) AskAll(recipients vivid.ActorRefs, message vivid.Message, timeout ...time.Duration) vivid.Future[[]vivid.AskReply] {
+	futureIns := future.NewFuture[[]vivid.AskReply](c, c.system.clock, 0, nil)
+	if len(recipients) == 0 {
+		futureIns.EnqueueMessage([]vivid.AskReply{})
+		return futureIns
+	}
+
+	var (
+		mu      sync.Mutex
+		replies = make([]vivid.AskReply, len(recipients))
+		pending = len(recipients)
+	)
+	c.scatter(recipients, message, timeout, func(index int, reply vivid.AskReply) {
+		mu.Lock()
+		replies[index] = reply
+		pending--
+		done := pending == 0
+		mu.Unlock()
+		if done {
+			futureIns.EnqueueMessage(replies)
+		}
+	})
+	return futureIns
+}
+
+func (c *Context) AskFirst(recipients vivid.ActorRefs, message vivid.Message, timeout ...time.Duration) vivid.Future[vivid.AskReply] {
+	futureIns := future.NewFuture[vivid.AskReply](c, c.system.clock, 0, nil)
+	if len(recipients) == 0 {
+		futureIns.Close(vivid.ErrorFutureInvalid.WithMessage("no recipients"))
+		return futureIns
+	}
+
+	var (
+		mu   sync.Mutex
+		errs = make([]error, 0, len(recipients))
+	)
+	c.scatter(recipients, message, timeout, func(index int, reply vivid.AskReply) {
+		if reply.Error == nil {
+			futureIns.EnqueueMessage(reply)
+			return
+		}
+		mu.Lock()
+		errs = append(errs, reply.Error)
+		failed := len(errs) == len(recipients)
+		mu.Unlock()
+		if failed {
+			futureIns.Close(errors.Join(errs...))
+		}
+	})
+	return futureIns
+}
+
+func (c *Context) AskQuorum(recipients vivid.ActorRefs, quorum int, message vivid.Message, timeout ...time.Duration) vivid.Future[[]vivid.AskReply] {
+	futureIns := future.NewFuture[[]vivid.AskReply](c, c.system.clock, 0, nil)
+	if quorum <= 0 || quorum > len(recipients) {
+		futureIns.Close(vivid.ErrorFutureInvalid.WithMessage(fmt.Sprintf("quorum %d out of range [1, %d]", quorum, len(recipients))))
+		return futureIns
+	}
+
+	var (
+		mu       sync.Mutex
+		replies  = make([]vivid.AskReply, 0, quorum)
+		errs     []error
+		finished bool
+	)
+	c.scatter(recipients, message, timeout, func(index int, reply vivid.AskReply) {
+		mu.Lock()
+		if finished {
+			mu.Unlock()
+			return
+		}
+		if reply.Error == nil {
+			replies = append(replies, reply)
+		} else {
+			errs = append(errs, reply.Error)
+		}
+		reached := len(replies) == quorum
+		// 剩余请求全部成功也无法达到法定数量时提前失败
+		unreachable := len(recipients)-len(errs) < quorum
+		finished = reached || unreachable
+		mu.Unlock()
+
+		switch {
+		case reached:
+			futureIns.EnqueueMessage(replies)
+		case unreachable:
+			futureIns.Close(vivid.ErrorFutureQuorumNotReached.With(errors.Join(errs...)))
+		}
+	})
+	return futureIns
+}
+
+// scatter 向 recipients 逐一发起 Ask，并在每个应答 Future 完成（成功、失败或超时）时以其在 recipients 中的下标调用 onReply。
+// onReply 在完成应答 Future 的 goroutine 中同步执行，须保持轻量且并发安全。
+func (c *Context) scatter(recipients vivid.ActorRefs, message vivid.Message, timeout []time.Duration, onReply func(index int, reply vivid.AskReply)) {
+	for i, recipient := range recipients {
+		c.ask(false, recipient, message, timeout...).OnDone(func(message vivid.Message, err error) {
+			onReply(i, vivid.AskReply{Recipient: recipient, Message: message, Error: err})
+		})
+	}
+}
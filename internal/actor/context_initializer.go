@@ -69,7 +69,7 @@ func (i *contextInitializer) prelaunch() error {
 }
 
 func (i *contextInitializer) initMailbox() error {
-	i.ctx.mailbox = mailbox.NewUnboundedMailbox(256, i.ctx)
+	i.ctx.mailbox = mailbox.NewUnboundedMailbox(256, i.ctx, i.ctx.system.options.Dispatcher)
 	return nil
 }
 
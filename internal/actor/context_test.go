@@ -13,6 +13,7 @@ import (
 	"github.com/kercylan98/vivid/internal/sugar"
 	"github.com/kercylan98/vivid/pkg/log"
 	"github.com/kercylan98/vivid/pkg/ves"
+	"github.com/kercylan98/vivid/pkg/vividtest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -507,6 +508,230 @@ func TestContext_Restart(t *testing.T) {
 			assert.Fail(t, "timeout")
 		}
 	})
+
+	t.Run("restart budget exhausted", func(t *testing.T) {
+		system := actor.NewTestSystem(t)
+		defer func() {
+			assert.NoError(t, system.Stop())
+		}()
+
+		var failures atomic.Int32
+		var exhausted = make(chan ves.ActorRestartBudgetExhaustedEvent, 1)
+		var killed = make(chan struct{})
+		var launched bool
+		ref, err := system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+			switch m := ctx.Message().(type) {
+			case *vivid.OnLaunch:
+				if launched {
+					return
+				}
+				launched = true
+				ctx.EventStream().Subscribe(ctx, ves.ActorRestartBudgetExhaustedEvent{})
+				child, err := ctx.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+					if ctx.Message() == "fail" {
+						failures.Add(1)
+						ctx.Failed("always fail")
+					}
+				}))
+				assert.NoError(t, err)
+				ctx.Watch(child)
+				// 邮箱中的消息在重启后由新实例继续处理，每条消息触发一次故障
+				for i := 0; i < 3; i++ {
+					ctx.Tell(child, "fail")
+				}
+			case ves.ActorRestartBudgetExhaustedEvent:
+				exhausted <- m
+			case *vivid.OnKilled:
+				if !m.Ref.Equals(ctx.Ref()) {
+					close(killed)
+				}
+			}
+		}), vivid.WithActorSupervisionStrategy(vivid.OneForOneStrategy(vivid.SupervisionStrategyDecisionMakerFN(func(ctx vivid.SupervisionContext) (decision vivid.SupervisionDecision, reason string) {
+			return vivid.SupervisionDecisionRestart, "test restart"
+		}), vivid.WithOneForOneStrategyMaxRetries(2), vivid.WithOneForOneStrategyWithinDuration(time.Minute))))
+		assert.NoError(t, err)
+		assert.NotNil(t, ref)
+
+		select {
+		case event := <-exhausted:
+			assert.Equal(t, 2, event.MaxRetries)
+			assert.Equal(t, time.Minute, event.WithinDuration)
+			assert.Equal(t, vivid.SupervisionDecisionStop, event.Decision)
+			assert.True(t, event.Supervisor.Equals(ref))
+		case <-time.After(time.Second):
+			assert.Fail(t, "timeout")
+		}
+		select {
+		case <-killed:
+		case <-time.After(time.Second):
+			assert.Fail(t, "timeout")
+		}
+		assert.Equal(t, int32(3), failures.Load())
+	})
+
+	t.Run("restart budget uses system clock", func(t *testing.T) {
+		clock := vividtest.NewVirtualClock(time.Now())
+		system := actor.NewTestSystem(t, vivid.WithActorSystemClock(clock))
+		defer func() {
+			assert.NoError(t, system.Stop())
+		}()
+
+		parent, err := system.ActorOf(newRestartBudgetParent(t), vivid.WithActorSupervisionStrategy(vivid.OneForOneStrategy(vivid.SupervisionStrategyDecisionMakerFN(func(ctx vivid.SupervisionContext) (decision vivid.SupervisionDecision, reason string) {
+			return vivid.SupervisionDecisionRestart, "test restart"
+		}), vivid.WithOneForOneStrategyMaxRetries(1), vivid.WithOneForOneStrategyWithinDuration(time.Minute))))
+		assert.NoError(t, err)
+		child := spawnRestartBudgetChild(t, system, parent)
+
+		system.Tell(child, "first")
+		awaitLastDecision(t, system, child, 1, vivid.SupervisionDecisionRestart)
+		// 虚拟时钟越过统计窗口后，上一次重启不再计入
+		clock.Advance(2 * time.Minute)
+		system.Tell(child, "second")
+		awaitLastDecision(t, system, child, 2, vivid.SupervisionDecisionRestart)
+	})
+
+	t.Run("restart budget forgets terminated child", func(t *testing.T) {
+		system := actor.NewTestSystem(t)
+		defer func() {
+			assert.NoError(t, system.Stop())
+		}()
+
+		parent, err := system.ActorOf(newRestartBudgetParent(t), vivid.WithActorSupervisionStrategy(vivid.OneForOneStrategy(vivid.SupervisionStrategyDecisionMakerFN(func(ctx vivid.SupervisionContext) (decision vivid.SupervisionDecision, reason string) {
+			return vivid.SupervisionDecisionRestart, "test restart"
+		}), vivid.WithOneForOneStrategyMaxRetries(1))))
+		assert.NoError(t, err)
+
+		child := spawnRestartBudgetChild(t, system, parent)
+		system.Tell(child, "first")
+		awaitLastDecision(t, system, child, 1, vivid.SupervisionDecisionRestart)
+		system.Kill(child, false)
+		assert.Eventually(t, func() bool {
+			_, err := system.FailureHistory(child)
+			return errors.Is(err, vivid.ErrorNotFound)
+		}, time.Second, 10*time.Millisecond)
+
+		// 同名子 Actor 重新创建后不沿用已终止实例的重启记录
+		child = spawnRestartBudgetChild(t, system, parent)
+		system.Tell(child, "second")
+		awaitLastDecision(t, system, child, 1, vivid.SupervisionDecisionRestart)
+	})
+
+	t.Run("failure history", func(t *testing.T) {
+		system := actor.NewTestSystem(t, vivid.WithActorSystemFailureHistorySize(2))
+		defer func() {
+			assert.NoError(t, system.Stop())
+		}()
+
+		var counts = make(chan int, 3)
+		var child vivid.ActorRef
+		var spawned = make(chan struct{})
+		ref, err := system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+			if _, ok := ctx.Message().(*vivid.OnLaunch); ok && child == nil {
+				var err error
+				child, err = ctx.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+					if _, ok := ctx.Message().(string); ok {
+						panic(ctx.Message())
+					}
+				}))
+				assert.NoError(t, err)
+				close(spawned)
+			}
+		}), vivid.WithActorSupervisionStrategy(vivid.OneForOneStrategy(vivid.SupervisionStrategyDecisionMakerFN(func(ctx vivid.SupervisionContext) (decision vivid.SupervisionDecision, reason string) {
+			count := ctx.FailureCount(time.Minute)
+			counts <- count
+			if count >= 3 {
+				return vivid.SupervisionDecisionStop, "too many failures"
+			}
+			return vivid.SupervisionDecisionRestart, "test restart"
+		}))))
+		assert.NoError(t, err)
+		<-spawned
+
+		var history []vivid.FailureRecord
+		for i, fault := range []string{"first", "second"} {
+			system.Tell(child, fault)
+			assert.Equal(t, i+1, <-counts)
+			// 决策在决策器返回后才写入记录
+			assert.Eventually(t, func() bool {
+				history, err = system.FailureHistory(child)
+				return err == nil && len(history) == i+1 && history[i].Decision == vivid.SupervisionDecisionRestart
+			}, time.Second, 10*time.Millisecond)
+		}
+		if assert.Len(t, history, 2) {
+			assert.Equal(t, "first", history[0].Fault)
+			assert.Equal(t, "second", history[1].Fault)
+			assert.Equal(t, vivid.SupervisionDecisionRestart, history[1].Decision)
+			assert.Equal(t, "test restart", history[1].Reason)
+			assert.True(t, history[1].Supervisor.Equals(ref))
+			assert.False(t, history[1].Time.Before(history[0].Time))
+		}
+
+		system.Tell(child, "third")
+		assert.Equal(t, 3, <-counts)
+		assert.Eventually(t, func() bool {
+			_, err := system.FailureHistory(child)
+			return errors.Is(err, vivid.ErrorNotFound)
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("failure history uses system clock", func(t *testing.T) {
+		start := time.Unix(0, 0)
+		clock := vividtest.NewVirtualClock(start)
+		system := actor.NewTestSystem(t, vivid.WithActorSystemClock(clock), vivid.WithActorSystemSupervisionStrategy(vivid.OneForOneStrategy(vivid.SupervisionStrategyDecisionMakerFN(func(ctx vivid.SupervisionContext) (decision vivid.SupervisionDecision, reason string) {
+			return vivid.SupervisionDecisionRestart, "test restart"
+		}))))
+		defer func() {
+			assert.NoError(t, system.Stop())
+		}()
+
+		child, err := system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+			if _, ok := ctx.Message().(string); ok {
+				panic(ctx.Message())
+			}
+		}))
+		assert.NoError(t, err)
+
+		system.Tell(child, "fault")
+		awaitLastDecision(t, system, child, 1, vivid.SupervisionDecisionRestart)
+		history, err := system.FailureHistory(child)
+		if assert.NoError(t, err) && assert.Len(t, history, 1) {
+			assert.True(t, history[0].Time.Equal(start))
+		}
+	})
+}
+
+// newRestartBudgetParent 收到 "spawn" 时创建名为 worker 的子 Actor 并回复其引用，子 Actor 收到任意字符串时故障。
+func newRestartBudgetParent(t *testing.T) vivid.Actor {
+	return vivid.ActorFN(func(ctx vivid.ActorContext) {
+		if ctx.Message() != "spawn" {
+			return
+		}
+		child, err := ctx.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+			if _, ok := ctx.Message().(string); ok {
+				panic(ctx.Message())
+			}
+		}), vivid.WithActorName("worker"))
+		assert.NoError(t, err)
+		ctx.Reply(child)
+	})
+}
+
+func spawnRestartBudgetChild(t *testing.T, system *actor.TestSystem, parent vivid.ActorRef) vivid.ActorRef {
+	t.Helper()
+	reply, err := system.Ask(parent, "spawn").Result()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return reply.(vivid.ActorRef)
+}
+
+// awaitLastDecision 等待 child 的故障记录达到 count 条且最后一条的决策为 decision。
+func awaitLastDecision(t *testing.T, system *actor.TestSystem, child vivid.ActorRef, count int, decision vivid.SupervisionDecision) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		history, err := system.FailureHistory(child)
+		return err == nil && len(history) == count && history[count-1].Decision == decision
+	}, time.Second, 10*time.Millisecond)
 }
 
 func TestContext_Failed(t *testing.T) {
@@ -1191,6 +1416,66 @@ func TestContext_Ask(t *testing.T) {
 	wg.Wait()
 }
 
+func TestContext_AskAll(t *testing.T) {
+	system := actor.NewTestSystem(t)
+	defer func() {
+		assert.NoError(t, system.Stop())
+	}()
+
+	// 回复 n 倍请求值的 Actor，n 为 0 时不回复
+	spawn := func(n int) vivid.ActorRef {
+		ref, err := system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+			if v, ok := ctx.Message().(int); ok && n > 0 {
+				ctx.Reply(v * n)
+			}
+		}))
+		assert.NoError(t, err)
+		return ref
+	}
+	one, two, silent := spawn(1), spawn(2), spawn(0)
+	timeout := 50 * time.Millisecond
+
+	t.Run("all", func(t *testing.T) {
+		replies, err := system.AskAll(vivid.ActorRefs{one, silent, two}, 3, timeout).Result()
+		assert.NoError(t, err)
+		assert.Len(t, replies, 3)
+		assert.Equal(t, 3, replies[0].Message)
+		assert.True(t, replies[0].Recipient.Equals(one))
+		assert.ErrorIs(t, replies[1].Error, vivid.ErrorFutureTimeout)
+		assert.Equal(t, 6, replies[2].Message)
+
+		replies, err = system.AskAll(nil, 3).Result()
+		assert.NoError(t, err)
+		assert.Empty(t, replies)
+	})
+
+	t.Run("first", func(t *testing.T) {
+		reply, err := system.AskFirst(vivid.ActorRefs{silent, two}, 3, timeout).Result()
+		assert.NoError(t, err)
+		assert.Equal(t, 6, reply.Message)
+		assert.True(t, reply.Recipient.Equals(two))
+
+		_, err = system.AskFirst(vivid.ActorRefs{silent, silent}, 3, timeout).Result()
+		assert.ErrorIs(t, err, vivid.ErrorFutureTimeout)
+
+		_, err = system.AskFirst(nil, 3).Result()
+		assert.ErrorIs(t, err, vivid.ErrorFutureInvalid)
+	})
+
+	t.Run("quorum", func(t *testing.T) {
+		replies, err := system.AskQuorum(vivid.ActorRefs{one, silent, two}, 2, 3, time.Second).Result()
+		assert.NoError(t, err)
+		assert.Len(t, replies, 2)
+
+		_, err = system.AskQuorum(vivid.ActorRefs{one, silent, silent}, 2, 3, timeout).Result()
+		assert.ErrorIs(t, err, vivid.ErrorFutureQuorumNotReached)
+		assert.ErrorIs(t, err, vivid.ErrorFutureTimeout)
+
+		_, err = system.AskQuorum(vivid.ActorRefs{one}, 2, 3).Result()
+		assert.ErrorIs(t, err, vivid.ErrorFutureInvalid)
+	})
+}
+
 func BenchmarkContext_Ask(b *testing.B) {
 	system := actor.NewSystem()
 	if err := system.Start(); err != nil {
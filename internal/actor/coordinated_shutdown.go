@@ -0,0 +1,97 @@
+package actor
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/pkg/log"
+)
+
+var _ vivid.CoordinatedShutdown = (*coordinatedShutdown)(nil)
+
+type shutdownTask struct {
+	name string
+	task vivid.ShutdownTask
+}
+
+// coordinatedShutdown 按阶段顺序执行关闭任务，由 System.stop 调用 run。
+type coordinatedShutdown struct {
+	lock     sync.Mutex
+	tasks    map[vivid.ShutdownPhase][]shutdownTask
+	timeouts map[vivid.ShutdownPhase]time.Duration
+	running  bool
+}
+
+func newCoordinatedShutdown(timeouts map[vivid.ShutdownPhase]time.Duration) *coordinatedShutdown {
+	return &coordinatedShutdown{
+		tasks:    make(map[vivid.ShutdownPhase][]shutdownTask),
+		timeouts: timeouts,
+	}
+}
+
+func (c *coordinatedShutdown) AddTask(phase vivid.ShutdownPhase, name string, task vivid.ShutdownTask) error {
+	if task == nil {
+		return vivid.ErrorIllegalArgument.WithMessage("shutdown task is nil")
+	}
+	if !slices.Contains(vivid.ShutdownPhases(), phase) {
+		return vivid.ErrorIllegalArgument.WithMessage("unknown shutdown phase: " + string(phase))
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.running {
+		return vivid.ErrorActorSystemStopped
+	}
+	c.tasks[phase] = append(c.tasks[phase], shutdownTask{name: name, task: task})
+	return nil
+}
+
+// run 依次执行全部阶段，执行后不再接受新任务。
+func (c *coordinatedShutdown) run(logger log.Logger) {
+	c.lock.Lock()
+	c.running = true
+	c.lock.Unlock()
+
+	for _, phase := range vivid.ShutdownPhases() {
+		c.runPhase(logger, phase)
+	}
+}
+
+// runPhase 并发执行 phase 的全部任务，最多等待该阶段的超时时间。
+func (c *coordinatedShutdown) runPhase(logger log.Logger, phase vivid.ShutdownPhase) {
+	tasks := c.tasks[phase]
+	if len(tasks) == 0 {
+		return
+	}
+	timeout := c.timeouts[phase]
+	if timeout <= 0 {
+		timeout = vivid.DefaultShutdownPhaseTimeout
+	}
+	logger.Debug("coordinated shutdown phase started", log.String("phase", string(phase)), log.Int("tasks", len(tasks)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	for _, t := range tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := t.task(ctx); err != nil {
+				logger.Warn("coordinated shutdown task failed",
+					log.String("phase", string(phase)), log.String("task", t.name), log.Any("err", err))
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logger.Error("coordinated shutdown phase timeout", log.String("phase", string(phase)), log.Duration("timeout", timeout))
+	}
+}
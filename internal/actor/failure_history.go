@@ -0,0 +1,38 @@
+package actor
+
+import (
+	"sync"
+
+	"github.com/kercylan98/vivid"
+)
+
+// failureHistory Actor 的有界故障记录，由监督者写入，可被其他 goroutine 通过 ActorSystem.FailureHistory 读取，因此内部加锁。
+type failureHistory struct {
+	lock    sync.RWMutex
+	records []vivid.FailureRecord
+}
+
+// append 追加一条记录，超出 size 时丢弃最早的记录。
+func (h *failureHistory) append(record vivid.FailureRecord, size int) {
+	if size <= 0 {
+		size = vivid.DefaultFailureHistorySize
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if overflow := len(h.records) + 1 - size; overflow > 0 {
+		h.records = append(h.records[:0], h.records[overflow:]...)
+	}
+	h.records = append(h.records, record)
+}
+
+// snapshot 返回记录的副本（由旧到新）。
+func (h *failureHistory) snapshot() []vivid.FailureRecord {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	if len(h.records) == 0 {
+		return nil
+	}
+	records := make([]vivid.FailureRecord, len(h.records))
+	copy(records, h.records)
+	return records
+}
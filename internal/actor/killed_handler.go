@@ -10,6 +10,11 @@ import (
 	"github.com/kercylan98/vivid/pkg/ves"
 )
 
+// childTerminationObserver 与 vivid 包内未导出的同名接口一致，由需要感知子 Actor 终止的内置监督策略实现。
+type childTerminationObserver interface {
+	ChildTerminated(child vivid.ActorRef)
+}
+
 func newKilledHandler(ctx *Context, message *vivid.OnKilled, behavior vivid.Behavior) *killedHandler {
 	return &killedHandler{
 		ctx:            ctx,
@@ -32,6 +37,9 @@ type killedHandler struct {
 func (h *killedHandler) handleChildDeath() {
 	if !h.message.Ref.Equals(h.ctx.ref) {
 		delete(h.ctx.children, h.message.Ref.GetPath())
+		if observer, ok := h.ctx.supervisionStrategy().(childTerminationObserver); ok {
+			observer.ChildTerminated(h.message.Ref)
+		}
 		h.ctx.executeBehaviorWithRecovery(h.behavior)
 		h.ctx.Logger().Debug("child death", log.Int("children_count", len(h.ctx.children)), log.String("ref", h.ctx.ref.GetPath()), log.String("child", h.message.Ref.GetPath()))
 	}
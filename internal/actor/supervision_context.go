@@ -3,6 +3,7 @@ package actor
 import (
 	"fmt"
 	"runtime/debug"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kercylan98/vivid"
@@ -18,15 +19,17 @@ var (
 //
 // ref 为发生故障的 Actor 的引用。
 // fault 为发生故障的消息。
+// clock 为发生故障的 ActorSystem 的时钟，用于记录故障发生时间。
 //
 // 返回:
 //   - *supervisionContext: 新的监督上下文。
-func newSupervisionContext(ref vivid.ActorRef, fault vivid.Message) *supervisionContext {
+func newSupervisionContext(ref vivid.ActorRef, fault vivid.Message, clock vivid.Clock) *supervisionContext {
 	return &supervisionContext{
 		id:         uuid.New().String(),
 		child:      ref.ToActorRefs(),
 		fault:      fault,
 		faultStack: debug.Stack(),
+		failedAt:   clock.Now(),
 	}
 }
 
@@ -69,19 +72,39 @@ func (m *RestartMessage) recoverExec(logger log.Logger, name string, alarm bool,
 // supervise 确认由 supervisor 监督本次故障的后续处理
 func supervise(supervisor *Context, supervisionContext *supervisionContext) {
 	supervisionContext.supervisorLogger = supervisor.Logger()
+	supervisionContext.clock = supervisor.system.clock
 	supervisionContext.supervisorChildren = supervisor.Children()
+	if child := supervisionContext.child.First(); child != nil {
+		supervisionContext.childContext = supervisor.system.findActorContext(child)
+	}
+	if supervisionContext.childContext != nil {
+		supervisionContext.history = supervisionContext.childContext.failures.snapshot()
+	}
 }
 
 type supervisionContext struct {
-	id                    string              // 当前监督上下文的唯一标识
-	supervisorLogger      log.Logger          // 监督者 Actor 的日志记录器
-	supervisorChildren    vivid.ActorRefs     // 监督者 Actor 的子 ActorRefs
-	child                 vivid.ActorRefs     // 发生故障的 Actor 的子 ActorRefs
-	fault                 vivid.Message       // 发生故障的消息
-	faultStack            []byte              // 发生故障的堆栈
-	decisionReason        string              // 当前监督者的决策原因
-	targets               vivid.ActorRefs     // 当前监督上下文的目标 ActorRefs
-	subSupervisionContext *supervisionContext // 下级故障监督上下文
+	id                    string                // 当前监督上下文的唯一标识
+	supervisorLogger      log.Logger            // 监督者 Actor 的日志记录器
+	supervisorChildren    vivid.ActorRefs       // 监督者 Actor 的子 ActorRefs
+	child                 vivid.ActorRefs       // 发生故障的 Actor 的子 ActorRefs
+	fault                 vivid.Message         // 发生故障的消息
+	faultStack            []byte                // 发生故障的堆栈
+	decisionReason        string                // 当前监督者的决策原因
+	targets               vivid.ActorRefs       // 当前监督上下文的目标 ActorRefs
+	subSupervisionContext *supervisionContext   // 下级故障监督上下文
+	budgetExhausted       []restartBudgetReport // 监督策略报告的重启次数超限记录
+	failedAt              time.Time             // 故障发生时间
+	clock                 vivid.Clock           // 监督者所在 ActorSystem 的时钟
+	childContext          *Context              // 发生故障的本地 Actor 上下文，不存在时为 nil
+	history               []vivid.FailureRecord // 发生故障的 Actor 此前的故障记录
+}
+
+// restartBudgetReport 监督策略通过 ReportRestartBudgetExhausted 报告的重启次数超限信息。
+type restartBudgetReport struct {
+	child      vivid.ActorRef
+	maxRetries int
+	within     time.Duration
+	decision   vivid.SupervisionDecision
 }
 
 // broadcastAllTargets 广播消息到当前监督上下文及所有下级监督上下文的目标 Actor
@@ -138,7 +161,7 @@ func (c *supervisionContext) applyDecision(ctx *Context, targets vivid.ActorRefs
 		// 升级后视为自身的故障，但是携带了下级故障信息
 		// 挂起当前 Actor 的消息处理并且向父级 Actor 发送监督上下文以触发父级 Actor 的监督策略
 		ctx.mailbox.Pause()
-		subSupervisionContext := newSupervisionContext(ctx.ref, c.fault)
+		subSupervisionContext := newSupervisionContext(ctx.ref, c.fault, ctx.system.clock)
 		subSupervisionContext.subSupervisionContext = c
 		ctx.tell(true, ctx.parent, subSupervisionContext)
 	}
@@ -164,6 +187,48 @@ func (c *supervisionContext) Fault() vivid.Message {
 	return c.fault
 }
 
+// Clock 返回监督者所在 ActorSystem 的时钟，供内置监督策略统计重启次数。
+func (c *supervisionContext) Clock() vivid.Clock {
+	return c.clock
+}
+
+func (c *supervisionContext) ReportRestartBudgetExhausted(child vivid.ActorRef, maxRetries int, within time.Duration, decision vivid.SupervisionDecision) {
+	c.budgetExhausted = append(c.budgetExhausted, restartBudgetReport{
+		child:      child,
+		maxRetries: maxRetries,
+		within:     within,
+		decision:   decision,
+	})
+}
+
+func (c *supervisionContext) FailureHistory() []vivid.FailureRecord {
+	return c.history
+}
+
+func (c *supervisionContext) FailureCount(within time.Duration) int {
+	count := 1
+	for _, record := range c.history {
+		if within <= 0 || c.failedAt.Sub(record.Time) <= within {
+			count++
+		}
+	}
+	return count
+}
+
+// recordFailure 将本次故障及监督决策写入发生故障的 Actor 的故障记录。
+func (c *supervisionContext) recordFailure(supervisor *Context, decision vivid.SupervisionDecision, reason string) {
+	if c.childContext == nil {
+		return
+	}
+	c.childContext.failures.append(vivid.FailureRecord{
+		Time:       c.failedAt,
+		Fault:      c.fault,
+		Supervisor: supervisor.ref,
+		Decision:   decision,
+		Reason:     reason,
+	}, supervisor.system.options.FailureHistorySize)
+}
+
 func (c *supervisionContext) FaultStack() []byte {
 	if c.subSupervisionContext == nil {
 		return c.faultStack
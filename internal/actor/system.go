@@ -8,6 +8,7 @@ import (
 
 	"github.com/kercylan98/vivid"
 	"github.com/kercylan98/vivid/internal/chain"
+	"github.com/kercylan98/vivid/internal/clock"
 	"github.com/kercylan98/vivid/internal/cluster"
 	"github.com/kercylan98/vivid/internal/future"
 	"github.com/kercylan98/vivid/internal/mailbox"
@@ -33,13 +34,16 @@ var (
 
 func NewSystem(options ...vivid.ActorSystemOption) *System {
 	opts := vivid.NewActorSystemOptions(options...)
+	systemClock := clock.OrReal(opts.Clock)
 
 	system := &System{
 		options:           opts,
 		actorContexts:     sync.Map{},
 		futureAgents:      make(map[vivid.ActorPath]map[vivid.ActorPath]*AgentRef),
 		guardClosedSignal: make(chan struct{}),
-		scheduler:         scheduler.NewScheduler(opts.Context),
+		clock:             systemClock,
+		scheduler:         scheduler.NewScheduler(opts.Context, opts.Clock),
+		shutdown:          newCoordinatedShutdown(opts.ShutdownPhaseTimeouts),
 	}
 
 	system.options.Context, system.cancel = context.WithCancel(system.options.Context)
@@ -59,10 +63,12 @@ type System struct {
 	remotingServer    *remoting.ServerActor                             // 远程服务器
 	eventStream       vivid.EventStream                                 // 事件流
 	metrics           metrics.Metrics                                   // 指标收集器
+	clock             vivid.Clock                                       // 时钟，覆盖调度器、Future 超时与退避
 	scheduler         *scheduler.Scheduler                              // 调度器
 	status            int32                                             // 系统状态
 	statusLock        sync.Mutex                                        // 系统状态锁
 	clusterContext    *cluster.Context                                  // 集群上下文
+	shutdown          *coordinatedShutdown                              // 协调关闭
 	cancel            context.CancelFunc                                // 上下文停止函数
 }
 
@@ -70,6 +76,10 @@ func (s *System) Cluster() vivid.ClusterContext {
 	return s.clusterContext
 }
 
+func (s *System) CoordinatedShutdown() vivid.CoordinatedShutdown {
+	return s.shutdown
+}
+
 func (s *System) HandleRemotingEnvelop(system bool, senderAddr, senderPath, receiverAddr, receiverPath string, messageInstance any) error {
 	var sender, receiver *Ref
 	var err error
@@ -144,11 +154,9 @@ func (s *System) Start() error {
 
 	s.Logger().Debug("actor system started")
 
-	// 守护系统上下文
+	// 守护系统上下文，上下文取消时同样执行协调关闭；stop 内部自行加锁校验状态，此处不可持有 statusLock
 	go func() {
 		<-s.options.Context.Done()
-		s.statusLock.Lock()
-		defer s.statusLock.Unlock()
 		_ = s.stop(false) // 无意义错误
 	}()
 	return nil
@@ -182,10 +190,8 @@ func (s *System) stop(checkLog bool, timeout ...time.Duration) error {
 		return stateError
 	}
 
-	// 优先离开集群（未启用集群时 clusterContext 为 nil）
-	if s.clusterContext != nil {
-		s.clusterContext.Leave()
-	}
+	// 按阶段执行协调关闭任务（含启用集群时内置的离开集群任务），之后再终止全部 Actor
+	s.shutdown.run(s.Logger())
 
 	var stopTimeout = sugar.Max(sugar.FirstOrDefault(timeout, s.options.StopTimeout), 0)
 	s.Logger().Debug("actor system stopping", log.Duration("timeout", stopTimeout))
@@ -281,6 +287,25 @@ func (s *System) FindActor(actorRef string) (vivid.ActorRef, error) {
 	return nil, vivid.ErrorNotFound
 }
 
+// findActorContext 查找本节点上 ref 对应的 Actor 上下文，不存在或为远程引用时返回 nil。
+func (s *System) findActorContext(ref vivid.ActorRef) *Context {
+	if ref == nil || ref.GetAddress() != s.Ref().GetAddress() {
+		return nil
+	}
+	ctx, _ := s.actorContexts.Load(ref.GetPath())
+	v, _ := ctx.(*Context)
+	return v
+}
+
+// FailureHistory 返回本节点上 ref 对应 Actor 的故障记录（由旧到新）。
+func (s *System) FailureHistory(ref vivid.ActorRef) ([]vivid.FailureRecord, error) {
+	ctx := s.findActorContext(ref)
+	if ctx == nil {
+		return nil, vivid.ErrorNotFound
+	}
+	return ctx.failures.snapshot(), nil
+}
+
 // ParseRef 将引用字符串解析为 ActorRef，不要求目标存在于本节点或远程。
 func (s *System) ParseRef(actorRef string) (vivid.ActorRef, error) {
 	return ParseRef(actorRef)
@@ -1,6 +1,8 @@
 package actor
 
 import (
+	"context"
+
 	"github.com/kercylan98/vivid"
 	"github.com/kercylan98/vivid/internal/chain"
 	"github.com/kercylan98/vivid/internal/cluster"
@@ -48,6 +50,7 @@ func (c *_systemChains) initializeRemoting(system *System) chain.Chain {
 
 		system.remotingServer = remoting.NewServerActor(
 			system.options.Context,
+			system.clock,
 			system.options.RemotingBindAddress,
 			system.options.RemotingAdvertiseAddress,
 			system.options.RemotingCodec,
@@ -72,13 +75,24 @@ func (c *_systemChains) initializeCluster(system *System) chain.Chain {
 			if err != nil {
 				return err
 			}
-			var singletonNames []string
-			if clusterOpts.SingletonTemplates != nil {
-				for n := range clusterOpts.SingletonTemplates {
-					singletonNames = append(singletonNames, n)
+			system.clusterContext = cluster.NewContext(system, clusterRef, *clusterOpts)
+			clusterContext := system.clusterContext
+			if err = system.shutdown.AddTask(vivid.ShutdownPhaseClusterLeave, "cluster-leave", func(ctx context.Context) error {
+				// Leave 本身不支持取消，阶段超时后放弃等待
+				done := make(chan struct{})
+				go func() {
+					clusterContext.Leave()
+					close(done)
+				}()
+				select {
+				case <-done:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
 				}
+			}); err != nil {
+				return err
 			}
-			system.clusterContext = cluster.NewContext(system, clusterRef, singletonNames)
 
 			proxyManager := cluster.NewSingletonProxyManager()
 			proxyManagerRef, err := system.ActorOf(proxyManager, vivid.WithActorName(cluster.SingletonProxyActorName))
@@ -87,6 +101,18 @@ func (c *_systemChains) initializeCluster(system *System) chain.Chain {
 			}
 			system.clusterContext.SetProxyManagerRef(proxyManagerRef)
 
+			receptionistRef, err := system.ActorOf(cluster.NewReceptionist(), vivid.WithActorName(cluster.ReceptionistActorName))
+			if err != nil {
+				return err
+			}
+			system.clusterContext.SetReceptionistRef(receptionistRef)
+
+			registryRef, err := system.ActorOf(cluster.NewServiceRegistry(), vivid.WithActorName(cluster.ServiceRegistryActorName))
+			if err != nil {
+				return err
+			}
+			system.clusterContext.SetServiceRegistryRef(registryRef)
+
 			if len(clusterOpts.SingletonTemplates) > 0 {
 				manager := cluster.NewSingletonManager(clusterOpts.SingletonTemplates)
 				_, err = system.ActorOf(manager, vivid.WithActorName(cluster.SingletonsActorName))
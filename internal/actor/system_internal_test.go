@@ -1,10 +1,13 @@
 package actor
 
 import (
+	"sync/atomic"
 	"testing"
 
 	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/mailbox"
 	"github.com/kercylan98/vivid/pkg/log"
+	"github.com/kercylan98/vivid/pkg/ves"
 )
 
 func NewTestSystem(t *testing.T, options ...vivid.ActorSystemOption) *TestSystem {
@@ -27,3 +30,48 @@ type TestSystem struct {
 	*System
 	*testing.T
 }
+
+// recordingMailbox 仅记录入列的信封，不做调度
+type recordingMailbox struct {
+	envelops []vivid.Envelop
+}
+
+func (m *recordingMailbox) Enqueue(envelop vivid.Envelop) {
+	m.envelops = append(m.envelops, envelop)
+}
+
+func (m *recordingMailbox) Pause() {}
+
+func (m *recordingMailbox) Resume() {}
+
+func (m *recordingMailbox) IsPaused() bool {
+	return false
+}
+
+func TestSystem_KilledDropsDeathLetter(t *testing.T) {
+	sys := NewTestSystem(t)
+	if err := sys.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if state := atomic.LoadInt32(&sys.state); state != killed {
+		t.Fatalf("expected system root to be killed, got state %d", state)
+	}
+
+	// 替换已停止系统的邮箱，观察 HandleEnvelop 向自身投递的死信
+	recorder := new(recordingMailbox)
+	sys.mailbox = recorder
+
+	sys.HandleEnvelop(mailbox.NewEnvelop(false, sys.ref, sys.ref, "message"))
+	if len(recorder.envelops) != 1 {
+		t.Fatalf("expected a death letter for an ordinary message, got %d envelops", len(recorder.envelops))
+	}
+
+	// 死信本身再次进入已停止的系统时必须被丢弃，否则会无限循环投递
+	sys.HandleEnvelop(recorder.envelops[0])
+	if len(recorder.envelops) != 1 {
+		t.Fatalf("expected the death letter to be dropped, got %d envelops", len(recorder.envelops))
+	}
+	if _, ok := recorder.envelops[0].Message().(ves.DeathLetterEvent); !ok {
+		t.Fatalf("expected ves.DeathLetterEvent, got %T", recorder.envelops[0].Message())
+	}
+}
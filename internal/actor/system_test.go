@@ -1,12 +1,19 @@
 package actor_test
 
 import (
+	"context"
+	"encoding/binary"
+	"net"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/kercylan98/vivid"
 	"github.com/kercylan98/vivid/internal/actor"
+	"github.com/kercylan98/vivid/internal/mailbox"
+	"github.com/kercylan98/vivid/internal/remoting"
+	"github.com/kercylan98/vivid/internal/remoting/serialize"
 	"github.com/kercylan98/vivid/pkg/log"
 	"github.com/kercylan98/vivid/pkg/metrics"
 	"github.com/kercylan98/vivid/pkg/ves"
@@ -166,6 +173,63 @@ func TestSystem_Stop(t *testing.T) {
 	})
 }
 
+func TestSystem_CoordinatedShutdown(t *testing.T) {
+	t.Run("phase order", func(t *testing.T) {
+		system := actor.NewTestSystem(t)
+		shutdown := system.CoordinatedShutdown()
+
+		var lock sync.Mutex
+		var order []vivid.ShutdownPhase
+		alive := make(chan bool, 1)
+		ref, err := system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {}))
+		assert.NoError(t, err)
+
+		phases := vivid.ShutdownPhases()
+		for i := len(phases) - 1; i >= 0; i-- {
+			phase := phases[i]
+			assert.NoError(t, shutdown.AddTask(phase, string(phase), func(ctx context.Context) error {
+				lock.Lock()
+				defer lock.Unlock()
+				order = append(order, phase)
+				if phase == vivid.ShutdownPhaseActorSystemTerminate {
+					_, err := system.FindActor(ref.String())
+					alive <- err == nil
+				}
+				return nil
+			}))
+		}
+		assert.ErrorIs(t, shutdown.AddTask("unknown", "x", func(ctx context.Context) error { return nil }), vivid.ErrorIllegalArgument)
+
+		assert.NoError(t, system.Stop())
+		assert.Equal(t, phases, order)
+		assert.True(t, <-alive, "actors should still run during actor-system-terminate tasks")
+		assert.ErrorIs(t, shutdown.AddTask(vivid.ShutdownPhaseBeforeServiceUnbind, "late", func(ctx context.Context) error { return nil }), vivid.ErrorActorSystemStopped)
+	})
+
+	t.Run("phase timeout", func(t *testing.T) {
+		system := actor.NewTestSystem(t, vivid.WithActorSystemShutdownPhaseTimeout(vivid.ShutdownPhaseBeforeServiceUnbind, 50*time.Millisecond))
+
+		cancelled := make(chan struct{})
+		next := make(chan struct{}, 1)
+		assert.NoError(t, system.CoordinatedShutdown().AddTask(vivid.ShutdownPhaseBeforeServiceUnbind, "slow", func(ctx context.Context) error {
+			<-ctx.Done()
+			close(cancelled)
+			time.Sleep(time.Second)
+			return ctx.Err()
+		}))
+		assert.NoError(t, system.CoordinatedShutdown().AddTask(vivid.ShutdownPhaseClusterShutdown, "next", func(ctx context.Context) error {
+			next <- struct{}{}
+			return nil
+		}))
+
+		start := time.Now()
+		assert.NoError(t, system.Stop())
+		assert.Less(t, time.Since(start), time.Second)
+		<-cancelled
+		<-next
+	})
+}
+
 func TestSystem_RemotingAsk(t *testing.T) {
 	type TestInternalMessage struct {
 		Text string `json:"text"`
@@ -209,6 +273,66 @@ func TestSystem_RemotingAsk(t *testing.T) {
 	assert.NoError(t, system2.Stop())
 }
 
+func TestSystem_RemotingBackToBackFrames(t *testing.T) {
+	type TestInternalMessage struct {
+		Text string `json:"text"`
+	}
+
+	codec := NewTestCodec().
+		Register("test_message", &TestInternalMessage{})
+
+	system := actor.NewTestSystem(t, vivid.WithActorSystemRemoting("127.0.0.1:8082"), vivid.WithActorSystemCodec(codec))
+	defer func() {
+		assert.NoError(t, system.Stop())
+	}()
+
+	const frameCount = 2
+	received := make(chan string, frameCount)
+	ref, err := system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+		if m, ok := ctx.Message().(*TestInternalMessage); ok {
+			received <- m.Text
+		}
+	}))
+	assert.NoError(t, err)
+
+	// 监听器异步启动，重试直到可以建立连接
+	var conn net.Conn
+	if !assert.Eventually(t, func() bool {
+		conn, err = net.Dial("tcp", "127.0.0.1:8082")
+		return err == nil
+	}, time.Second*3, time.Millisecond*10) {
+		return
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	handshake := &remoting.Handshake{AdvertiseAddr: "127.0.0.1:8083"}
+	assert.NoError(t, handshake.Send(conn))
+	assert.NoError(t, handshake.Wait(conn))
+
+	// 多个帧在同一次写入中连续到达，读缓冲预读的后续帧不能被丢弃
+	sender, err := actor.NewRef("127.0.0.1:8083", "/sender")
+	assert.NoError(t, err)
+	var frames []byte
+	for i := 0; i < frameCount; i++ {
+		data, err := serialize.EncodeEnvelopWithRemoting(codec, mailbox.NewEnvelop(false, sender, ref, &TestInternalMessage{Text: strconv.Itoa(i)}))
+		assert.NoError(t, err)
+		frames = binary.BigEndian.AppendUint32(frames, uint32(len(data)))
+		frames = append(frames, data...)
+	}
+	_, err = conn.Write(frames)
+	assert.NoError(t, err)
+
+	for i := 0; i < frameCount; i++ {
+		select {
+		case text := <-received:
+			assert.Equal(t, strconv.Itoa(i), text)
+		case <-time.After(time.Second * 3):
+			t.Fatalf("expected %d messages, got %d", frameCount, i)
+		}
+	}
+}
+
 func TestSystem_Metrics(t *testing.T) {
 	t.Run("metrics", func(t *testing.T) {
 		system := actor.NewTestSystem(t, vivid.WithActorSystemEnableMetrics(true))
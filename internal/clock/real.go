@@ -0,0 +1,34 @@
+package clock
+
+import (
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+var (
+	_ vivid.Clock = Real{}
+)
+
+// Real 基于标准库 time 的真实时钟，为 ActorSystem 未指定时钟时的默认实现。
+type Real struct{}
+
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+func (Real) AfterFunc(d time.Duration, f func()) vivid.ClockTimer {
+	return time.AfterFunc(d, f)
+}
+
+func (Real) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// OrReal 返回 c，c 为 nil 时返回 Real。
+func OrReal(c vivid.Clock) vivid.Clock {
+	if c == nil {
+		return Real{}
+	}
+	return c
+}
@@ -0,0 +1,83 @@
+package cluster
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CompareAppVersion 比较两个应用版本，a 较旧返回 -1、相同返回 0、较新返回 1。
+// 版本去除前缀 "v" 后按 "." 分段逐段比较：两段均为数字时按数值比较，否则按字典序；段数不足视为 0。
+// 空版本视为最旧。
+func CompareAppVersion(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return -1
+	}
+	if b == "" {
+		return 1
+	}
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		x, y := "0", "0"
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		if c := compareVersionSegment(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareVersionSegment(x, y string) int {
+	xn, xErr := strconv.ParseUint(x, 10, 64)
+	yn, yErr := strconv.ParseUint(y, 10, 64)
+	if xErr == nil && yErr == nil {
+		switch {
+		case xn < yn:
+			return -1
+		case xn > yn:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(x, y)
+}
+
+// newestAppVersion 返回视图中 Up 成员的最新应用版本，无 Up 成员时返回空。
+func newestAppVersion(v *ClusterView) string {
+	var newest string
+	for _, m := range v.Members {
+		if m != nil && m.Status == MemberStatusUp && CompareAppVersion(m.AppVersion(), newest) > 0 {
+			newest = m.AppVersion()
+		}
+	}
+	return newest
+}
+
+// uniformAppVersion 返回视图中在役成员（不含 Leaving/Exiting/Down/Removed）共同的应用版本及成员数；
+// 版本不一致、存在未配置版本的成员或无在役成员时 ok 为 false。
+func uniformAppVersion(v *ClusterView) (version string, count int, ok bool) {
+	for _, m := range v.Members {
+		if m == nil {
+			continue
+		}
+		switch m.Status {
+		case MemberStatusLeaving, MemberStatusExiting, MemberStatusDown, MemberStatusRemoved:
+			continue
+		}
+		ver := m.AppVersion()
+		if ver == "" || (count > 0 && ver != version) {
+			return "", 0, false
+		}
+		version = ver
+		count++
+	}
+	return version, count, count > 0
+}
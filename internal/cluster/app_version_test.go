@@ -0,0 +1,59 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareAppVersion(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.0", "1.2.0", 0},
+		{"1.2", "1.2.0", 0},
+		{"v1.10.0", "1.9.3", 1},
+		{"1.9.3", "1.10.0", -1},
+		{"", "0.0.1", -1},
+		{"2.0.0-rc1", "2.0.0-rc2", -1},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, CompareAppVersion(c.a, c.b), "%s vs %s", c.a, c.b)
+	}
+}
+
+func TestComputeLeaderAddr_PreferNewestAppVersion(t *testing.T) {
+	v := newTestView(
+		testMembers(MemberStatusUp, "127.0.0.1:7001").withLabel(LabelAppVersion, "1.0.0"),
+		testMembers(MemberStatusUp, "127.0.0.1:7002").withLabel(LabelAppVersion, "1.1.0"),
+		testMembers(MemberStatusUp, "127.0.0.1:7003").withLabel(LabelAppVersion, "1.1.0"),
+	)
+	assert.Equal(t, "127.0.0.1:7001", ComputeLeaderAddr(v, false))
+	assert.Equal(t, "127.0.0.1:7002", ComputeLeaderAddr(v, true))
+
+	// 最新版本成员不可用时退回其余 Up 成员中的最新版本
+	v.Members["127.0.0.1:7002"].Status = MemberStatusSuspect
+	v.Members["127.0.0.1:7003"].Status = MemberStatusLeaving
+	assert.Equal(t, "127.0.0.1:7001", ComputeLeaderAddr(v, true))
+}
+
+func TestUniformAppVersion(t *testing.T) {
+	v := newTestView(
+		testMembers(MemberStatusUp, "127.0.0.1:7001").withLabel(LabelAppVersion, "1.0.0"),
+		testMembers(MemberStatusUp, "127.0.0.1:7002").withLabel(LabelAppVersion, "1.1.0"),
+	)
+	_, _, ok := uniformAppVersion(v)
+	assert.False(t, ok)
+
+	// 旧版本成员退出中不影响收敛判定
+	v.Members["127.0.0.1:7001"].Status = MemberStatusExiting
+	version, count, ok := uniformAppVersion(v)
+	assert.True(t, ok)
+	assert.Equal(t, "1.1.0", version)
+	assert.Equal(t, 1, count)
+
+	v.AddMember(newNodeState("127.0.0.1:7003", "c1", "127.0.0.1:7003"))
+	_, _, ok = uniformAppVersion(v)
+	assert.False(t, ok)
+}
@@ -5,33 +5,45 @@ import (
 	"github.com/kercylan98/vivid/pkg/ves"
 )
 
-// EventPublisher 管理集群事件发布状态（上次 quorum/leader/DC 健康），并发布视图与成员变更事件。
+// EventPublisher 管理集群事件发布状态（上次 quorum/leader/DC 健康/是否已达到目标应用版本），并发布视图与成员变更事件。
 type EventPublisher struct {
-	lastInQuorum   bool
-	lastLeaderAddr string
-	lastDCHealth   map[string]bool
+	options           vivid.ClusterOptions
+	lastInQuorum      bool
+	lastLeaderAddr    string
+	lastDCHealth      map[string]bool
+	appVersionReached bool
 }
 
-// NewClusterEventPublisher 创建集群事件发布器。
-func NewClusterEventPublisher() *EventPublisher {
+// NewClusterEventPublisher 根据集群配置创建集群事件发布器。
+func NewClusterEventPublisher(options vivid.ClusterOptions) *EventPublisher {
 	return &EventPublisher{
+		options:      options,
 		lastDCHealth: make(map[string]bool),
 	}
 }
 
-// PublishMembersChanged 发布成员变更事件。
-func (p *EventPublisher) PublishMembersChanged(ctx vivid.ActorContext, members []string, addedNum int, removed []string) {
-	if ctx == nil {
+// PublishMembersChanged 发布成员变更事件，成员地址与状态取自视图 v。
+func (p *EventPublisher) PublishMembersChanged(ctx vivid.ActorContext, v *ClusterView, addedNum int, removed []string) {
+	if ctx == nil || v == nil {
 		return
 	}
 	es := ctx.EventStream()
 	if es == nil {
 		return
 	}
+	members := make([]string, 0, len(v.Members))
+	statuses := make(map[string]string, len(v.Members))
+	for _, m := range v.Members {
+		if m != nil && m.Address != "" {
+			members = append(members, m.Address)
+			statuses[m.Address] = m.Status.String()
+		}
+	}
 	removedNum := len(removed)
 	es.Publish(ctx, ves.ClusterMembersChangedEvent{
 		NodeRef:    ctx.Ref(),
 		Members:    members,
+		Statuses:   statuses,
 		AddedNum:   addedNum,
 		RemovedNum: removedNum,
 		Removed:    removed,
@@ -57,6 +69,31 @@ func (p *EventPublisher) PublishViewChanged(ctx vivid.ActorContext, v *ClusterVi
 		RemovedNum:     len(removed),
 		Removed:        removed,
 	})
+	p.PublishAppVersionReachedIfNeeded(ctx, v)
+}
+
+// PublishAppVersionReachedIfNeeded 在所有在役成员的应用版本一致达到配置的 TargetAppVersion 时发布 ClusterAppVersionReachedEvent；
+// 未配置目标版本时不发布，达到后再次出现其他版本的成员时重新判定。
+func (p *EventPublisher) PublishAppVersionReachedIfNeeded(ctx vivid.ActorContext, v *ClusterView) {
+	if ctx == nil || v == nil || p.options.TargetAppVersion == "" {
+		return
+	}
+	version, count, ok := uniformAppVersion(v)
+	reached := ok && CompareAppVersion(version, p.options.TargetAppVersion) == 0
+	if !reached || p.appVersionReached {
+		p.appVersionReached = reached
+		return
+	}
+	es := ctx.EventStream()
+	if es == nil {
+		return
+	}
+	p.appVersionReached = true
+	es.Publish(ctx, ves.ClusterAppVersionReachedEvent{
+		NodeRef:     ctx.Ref(),
+		AppVersion:  version,
+		MemberCount: count,
+	})
 }
 
 // PublishDCHealthChangedIfNeeded 在 DC 健康状态变化时发布事件。
@@ -113,7 +150,7 @@ func (p *EventPublisher) PublishLeaderIfChanged(ctx vivid.ActorContext, v *Clust
 	if es == nil {
 		return
 	}
-	leaderAddr := ComputeLeaderAddr(v)
+	leaderAddr := ComputeLeaderAddr(v, p.options.PreferNewestAppVersion)
 	if p.lastInQuorum && !inQuorum {
 		es.Publish(ctx, ves.ClusterQuorumLostEvent{
 			NodeRef:        ctx.Ref(),
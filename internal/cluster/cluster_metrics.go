@@ -48,6 +48,22 @@ func (u *MetricsUpdater) Update(ctx vivid.ActorContext, v *ClusterView) {
 	}
 }
 
+// RecordGossipSent 记录一次 Gossip 发送；delta 非 nil 时为增量发送，累计相对全量视图（fullBytes 字节）节省的字节数。
+func (u *MetricsUpdater) RecordGossipSent(ctx vivid.ActorContext, fullBytes int, delta *ClusterView) {
+	if ctx == nil || !ctx.MetricsEnabled() {
+		return
+	}
+	m := ctx.Metrics()
+	if delta == nil {
+		m.Counter("cluster.gossip.full_sent").Inc()
+		return
+	}
+	m.Counter("cluster.gossip.delta_sent").Inc()
+	if saved := fullBytes - encodedViewSize(delta); saved > 0 {
+		m.Counter("cluster.gossip.delta_bytes_saved").Add(uint64(saved))
+	}
+}
+
 // UpdateViewDivergence 更新与另一视图的差异指标。
 func (u *MetricsUpdater) UpdateViewDivergence(ctx vivid.ActorContext, local, other *ClusterView) {
 	if ctx == nil || !ctx.MetricsEnabled() || local == nil || other == nil {
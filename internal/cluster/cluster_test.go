@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/cluster"
 	"github.com/kercylan98/vivid/internal/messages"
 	"github.com/kercylan98/vivid/pkg/bootstrap"
 	"github.com/kercylan98/vivid/pkg/log"
@@ -303,3 +304,292 @@ func TestCluster_Singleton(t *testing.T) {
 		assert.NoError(t, system.Stop())
 	}
 }
+
+func TestCluster_AdminOperations(t *testing.T) {
+	newNode := func(addr string) vivid.ActorSystem {
+		system := bootstrap.NewActorSystem(
+			vivid.WithActorSystemRemoting(addr),
+			vivid.WithActorSystemRemotingOptions(
+				vivid.NewActorSystemRemotingOptions(),
+				vivid.WithActorSystemRemotingClusterOption(
+					vivid.WithClusterAdminSecret("admin-secret"),
+				),
+			),
+		)
+		assert.NoError(t, system.Start())
+		return system
+	}
+	const addrA, addrB = "127.0.0.1:17100", "127.0.0.1:17101"
+	nodeA, nodeB := newNode(addrA), newNode(addrB)
+	defer func() {
+		assert.NoError(t, nodeB.Stop())
+		assert.NoError(t, nodeA.Stop())
+	}()
+
+	assert.ErrorIs(t, nodeB.Cluster().Join(nil), vivid.ErrorIllegalArgument)
+	assert.NoError(t, nodeB.Cluster().Join([]string{addrA}))
+	assert.Eventually(t, func() bool {
+		members, err := nodeA.Cluster().GetMembers()
+		return err == nil && len(members) == 2
+	}, 3*time.Second, 50*time.Millisecond)
+
+	assert.NoError(t, nodeA.Cluster().ForceBroadcast())
+	assert.ErrorIs(t, nodeA.Cluster().Down("127.0.0.1:17199"), vivid.ErrorNotFound)
+
+	// 远程退出请求须携带匹配的管理令牌
+	refB, err := nodeA.CreateRef(addrB, "/@cluster")
+	assert.NoError(t, err)
+	_, err = nodeA.Ask(refB, &cluster.LeaveRequest{AdminToken: "invalid"}, time.Second).Result()
+	assert.ErrorIs(t, err, vivid.ErrorClusterAdminAuthFailed)
+
+	// 远程帧中的 sender 地址可被伪造为接收方自身地址，不得因此跳过令牌校验
+	remoting, ok := nodeB.(interface {
+		HandleRemotingEnvelop(system bool, senderAddr, senderPath, receiverAddr, receiverPath string, messageInstance any) error
+	})
+	if assert.True(t, ok) {
+		left := make(chan struct{}, 1)
+		listener, err := nodeB.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+			switch ctx.Message().(type) {
+			case *vivid.OnLaunch:
+				ctx.EventStream().Subscribe(ctx, ves.ClusterLeaveCompletedEvent{})
+			case ves.ClusterLeaveCompletedEvent:
+				select {
+				case left <- struct{}{}:
+				default:
+				}
+			case string:
+				ctx.Reply(ctx.Message())
+			}
+		}))
+		assert.NoError(t, err)
+		_, err = nodeB.Ask(listener, "subscribed").Result() // 确保订阅已生效
+		assert.NoError(t, err)
+		assert.NoError(t, remoting.HandleRemotingEnvelop(false, addrB, "/spoofed", addrB, "/@cluster", &cluster.LeaveRequest{}))
+		select {
+		case <-left:
+			t.Fatal("spoofed local leave request bypassed admin token")
+		case <-time.After(300 * time.Millisecond):
+		}
+	}
+
+	assert.NoError(t, nodeA.Cluster().LeaveAddress(addrB))
+	assert.NoError(t, nodeA.Cluster().Down(addrB))
+	members, err := nodeA.Cluster().GetMembers()
+	assert.NoError(t, err)
+	assert.Len(t, members, 1)
+}
+
+func TestCluster_JoiningPromotedOnConvergence(t *testing.T) {
+	const addrA, addrB = "127.0.0.1:17110", "127.0.0.1:17111"
+	newNode := func(addr string) vivid.ActorSystem {
+		system := bootstrap.NewActorSystem(
+			vivid.WithActorSystemRemoting(addr),
+			vivid.WithActorSystemRemotingOptions(
+				vivid.NewActorSystemRemotingOptions(),
+				vivid.WithActorSystemRemotingClusterOption(
+					vivid.WithClusterSeeds([]string{addrA}),
+					vivid.WithClusterWeaklyUp(5*time.Second),
+				),
+			),
+		)
+		assert.NoError(t, system.Start())
+		return system
+	}
+	nodeA := newNode(addrA)
+	nodeB := newNode(addrB)
+	defer func() {
+		assert.NoError(t, nodeB.Stop())
+		assert.NoError(t, nodeA.Stop())
+	}()
+
+	statusOf := func(system vivid.ActorSystem, addr string) string {
+		members, err := system.Cluster().GetMembers()
+		if err != nil {
+			return ""
+		}
+		for _, m := range members {
+			if m.Address == addr {
+				return m.Status
+			}
+		}
+		return ""
+	}
+	assert.Eventually(t, func() bool {
+		return statusOf(nodeA, addrB) == "up" && statusOf(nodeB, addrB) == "up"
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestCluster_PreferNewestAppVersionLeader(t *testing.T) {
+	const addrA, addrB = "127.0.0.1:17130", "127.0.0.1:17131"
+	newNode := func(addr, appVersion string) vivid.ActorSystem {
+		system := bootstrap.NewActorSystem(
+			vivid.WithActorSystemRemoting(addr),
+			vivid.WithActorSystemRemotingOptions(
+				vivid.NewActorSystemRemotingOptions(),
+				vivid.WithActorSystemRemotingClusterOption(
+					vivid.WithClusterSeeds([]string{addrA}),
+					vivid.WithClusterAppVersion(appVersion),
+					vivid.WithClusterPreferNewestAppVersion(true),
+				),
+			),
+		)
+		assert.NoError(t, system.Start())
+		return system
+	}
+	nodeA := newNode(addrA, "1.0.0")
+	nodeB := newNode(addrB, "1.1.0")
+	defer func() {
+		assert.NoError(t, nodeB.Stop())
+		assert.NoError(t, nodeA.Stop())
+	}()
+
+	// 按地址排序 A 为 Leader，但 B 的应用版本更新
+	assert.Eventually(t, func() bool {
+		view, err := nodeA.Cluster().GetView()
+		return err == nil && view.LeaderAddr == addrB
+	}, 5*time.Second, 50*time.Millisecond)
+
+	members, err := nodeB.Cluster().GetMembers()
+	assert.NoError(t, err)
+	versions := make(map[string]string, len(members))
+	for _, m := range members {
+		versions[m.Address] = m.AppVersion
+	}
+	assert.Equal(t, map[string]string{addrA: "1.0.0", addrB: "1.1.0"}, versions)
+}
+
+func TestCluster_AppVersionReachedViaGossip(t *testing.T) {
+	const addrA, addrB, addrC = "127.0.0.1:17132", "127.0.0.1:17133", "127.0.0.1:17134"
+	newNode := func(addr, target string) vivid.ActorSystem {
+		system := bootstrap.NewActorSystem(
+			vivid.WithActorSystemRemoting(addr),
+			vivid.WithActorSystemRemotingOptions(
+				vivid.NewActorSystemRemotingOptions(),
+				vivid.WithActorSystemRemotingClusterOption(
+					vivid.WithClusterSeeds([]string{addrA}),
+					vivid.WithClusterAppVersion("1.1.0"),
+					vivid.WithClusterTargetAppVersion(target),
+				),
+			),
+		)
+		assert.NoError(t, system.Start())
+		return system
+	}
+	// A 的目标版本尚未达到，不应发布
+	nodeA := newNode(addrA, "1.2.0")
+	nodeB := newNode(addrB, "1.1.0")
+	defer func() {
+		assert.NoError(t, nodeB.Stop())
+		assert.NoError(t, nodeA.Stop())
+	}()
+
+	subscribe := func(system vivid.ActorSystem) <-chan ves.ClusterAppVersionReachedEvent {
+		reached := make(chan ves.ClusterAppVersionReachedEvent, 1)
+		_, err := system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+			switch m := ctx.Message().(type) {
+			case *vivid.OnLaunch:
+				ctx.EventStream().Subscribe(ctx, ves.ClusterAppVersionReachedEvent{})
+			case ves.ClusterAppVersionReachedEvent:
+				select {
+				case reached <- m:
+				default:
+				}
+			}
+		}))
+		assert.NoError(t, err)
+		return reached
+	}
+	reachedA := subscribe(nodeA)
+	reachedB := subscribe(nodeB)
+
+	// B 既非 Leader 也非 C 的种子，仅经 Gossip 得知 C 加入
+	nodeC := newNode(addrC, "")
+	defer func() {
+		assert.NoError(t, nodeC.Stop())
+	}()
+	select {
+	case m := <-reachedB:
+		assert.Equal(t, "1.1.0", m.AppVersion)
+	case <-time.After(3 * time.Second):
+		assert.Fail(t, "app version reached event not published")
+	}
+	select {
+	case m := <-reachedA:
+		assert.Fail(t, "app version reached event published before the target version", m.AppVersion)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestCluster_ServiceRegistry(t *testing.T) {
+	const addrA, addrB = "127.0.0.1:17150", "127.0.0.1:17151"
+	newNode := func(addr string) vivid.ActorSystem {
+		system := bootstrap.NewActorSystem(
+			vivid.WithActorSystemRemoting(addr),
+			vivid.WithActorSystemRemotingOptions(
+				vivid.NewActorSystemRemotingOptions(),
+				vivid.WithActorSystemRemotingClusterOption(vivid.WithClusterSeeds([]string{addrA})),
+			),
+		)
+		assert.NoError(t, system.Start())
+		return system
+	}
+	nodeA := newNode(addrA)
+	nodeB := newNode(addrB)
+	defer func() {
+		assert.NoError(t, nodeB.Stop())
+		assert.NoError(t, nodeA.Stop())
+	}()
+
+	key := vivid.NewServiceKey[*TestRemoteMessage]("worker")
+	_, err := key.Find(nil)
+	assert.ErrorIs(t, err, vivid.ErrorClusterDisabled)
+
+	listings := make(chan ves.ClusterServiceListingEvent, 8)
+	subscriber, err := nodeA.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+		if ev, ok := ctx.Message().(ves.ClusterServiceListingEvent); ok {
+			listings <- ev
+		}
+	}))
+	assert.NoError(t, err)
+	assert.NoError(t, key.Subscribe(nodeA.Cluster(), subscriber))
+	assert.Empty(t, (<-listings).Refs)
+
+	received := make(chan string, 1)
+	worker, err := nodeB.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+		if m, ok := ctx.Message().(*TestRemoteMessage); ok {
+			received <- m.Text
+		}
+	}))
+	assert.NoError(t, err)
+	assert.NoError(t, key.Register(nodeB.Cluster(), worker))
+	assert.ErrorIs(t, nodeA.Cluster().Register(key, worker), vivid.ErrorIllegalArgument)
+
+	var found []vivid.ServiceRef[*TestRemoteMessage]
+	assert.Eventually(t, func() bool {
+		found, err = key.Find(nodeA.Cluster())
+		return err == nil && len(found) == 1 && found[0].Ref().Equals(worker)
+	}, 15*time.Second, 50*time.Millisecond)
+	select {
+	case ev := <-listings:
+		assert.Equal(t, "worker", ev.Key)
+		assert.Len(t, key.Refs(ev.Refs), 1)
+	case <-time.After(time.Second):
+		t.Fatal("listing change not delivered")
+	}
+	if assert.Len(t, found, 1) {
+		found[0].Tell(nodeA, &TestRemoteMessage{Text: "job"})
+		select {
+		case text := <-received:
+			assert.Equal(t, "job", text)
+		case <-time.After(3 * time.Second):
+			t.Fatal("typed service ref message not delivered")
+		}
+	}
+
+	// 注册的 Actor 终止后自动从全集群移除
+	nodeB.Kill(worker, false, "test")
+	assert.Eventually(t, func() bool {
+		refs, err := nodeA.Cluster().Find(key)
+		return err == nil && len(refs) == 0
+	}, 5*time.Second, 50*time.Millisecond)
+}
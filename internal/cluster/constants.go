@@ -9,6 +9,7 @@ const (
 	SchedRefFailureDetection = "cluster-failure-detection"
 	SchedRefJoinRetry        = "cluster-join-retry"
 	SchedRefLeaveDelay       = "cluster-leave-delay"
+	SchedRefServiceRegistry  = "cluster-service-registry-sync"
 )
 
 // ClusterSingletonsPathPrefix 集群单例 Manager 及其子 Actor 的路径前缀，用于 SingletonRef 解析。
@@ -21,4 +22,15 @@ const (
 	MaxJoinRetryDelay        = 30 * time.Second
 	MaxGetViewTargets        = 5
 	MaxJoinRateLimitEntries  = 10000
+
+	DefaultSplitBrainStableAfter = 20 * time.Second
+
+	DefaultPhiAccrualThreshold       = 8.0
+	DefaultPhiAccrualMinStdDeviation = 100 * time.Millisecond
+	DefaultPhiAccrualMaxSampleSize   = 200
+
+	DefaultIndirectProbeTimeout = 1 * time.Second
+
+	// ServiceRegistrySyncInterval 服务注册表向其他成员全量同步本节点注册的周期，用于修复丢失的增量推送。
+	ServiceRegistrySyncInterval = 5 * time.Second
 )
@@ -7,20 +7,22 @@ import (
 	"time"
 
 	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/utils"
 	"github.com/kercylan98/vivid/pkg/ves"
 )
 
 const (
-	getViewTimeout = 3 * time.Second
+	getViewTimeout     = 3 * time.Second
+	remoteLeaveTimeout = 10 * time.Second
 )
 
-// NewContext 根据已创建的 NodeActor 引用构造集群上下文。
-// singletonNames 为已注册的集群单例名称集合，用于 SingletonRef 校验；nil 表示不校验。
-func NewContext(system vivid.ActorSystem, clusterRef vivid.ActorRef, singletonNames []string) *Context {
+// NewContext 根据已创建的 NodeActor 引用及其集群配置构造集群上下文。
+// options.SingletonTemplates 中的名称用于 SingletonRef 校验（为空表示不校验），options.AdminSecret 用于在管理操作中自动计算 AdminToken。
+func NewContext(system vivid.ActorSystem, clusterRef vivid.ActorRef, options vivid.ClusterOptions) *Context {
 	var names map[string]struct{}
-	if len(singletonNames) > 0 {
-		names = make(map[string]struct{}, len(singletonNames))
-		for _, n := range singletonNames {
+	if len(options.SingletonTemplates) > 0 {
+		names = make(map[string]struct{}, len(options.SingletonTemplates))
+		for n := range options.SingletonTemplates {
 			names[n] = struct{}{}
 		}
 	}
@@ -28,6 +30,8 @@ func NewContext(system vivid.ActorSystem, clusterRef vivid.ActorRef, singletonNa
 		system:         system,
 		clusterRef:     clusterRef,
 		singletonNames: names,
+		adminToken:     ComputeAdminToken(options.AdminSecret),
+		joinTimeout:    JoinAskTimeout(options),
 	}
 }
 
@@ -37,7 +41,11 @@ type Context struct {
 	system          vivid.ActorSystem
 	clusterRef      vivid.ActorRef
 	proxyManagerRef vivid.ActorRef
+	receptionistRef vivid.ActorRef
+	registryRef     vivid.ActorRef
 	singletonNames  map[string]struct{}
+	adminToken      string        // 由 AdminSecret 计算，未配置时为空
+	joinTimeout     time.Duration // 单个种子的 Join Ask 超时，Join 按种子数放大
 	leaveLock       sync.Mutex
 	leaveWait       chan struct{}
 }
@@ -50,6 +58,22 @@ func (c *Context) SetProxyManagerRef(ref vivid.ActorRef) {
 	c.proxyManagerRef = ref
 }
 
+// SetReceptionistRef 设置集群客户端接待者的 ActorRef，由 initializeCluster 在创建接待者后调用。
+func (c *Context) SetReceptionistRef(ref vivid.ActorRef) {
+	if c == nil {
+		return
+	}
+	c.receptionistRef = ref
+}
+
+// SetServiceRegistryRef 设置集群服务注册表的 ActorRef，由 initializeCluster 在创建注册表后调用。
+func (c *Context) SetServiceRegistryRef(ref vivid.ActorRef) {
+	if c == nil {
+		return
+	}
+	c.registryRef = ref
+}
+
 // GetMembers 返回当前视图中的成员列表；未启用集群或 clusterRef 为空时返回 ErrorClusterDisabled。
 func (c *Context) GetMembers() ([]vivid.ClusterMemberInfo, error) {
 	if c == nil || c.clusterRef == nil || c.system == nil {
@@ -70,13 +94,26 @@ func (c *Context) GetMembers() ([]vivid.ClusterMemberInfo, error) {
 			continue
 		}
 		ver := resp.View.VersionVector.Get(m.ID)
+		var labels map[string]string
+		if len(m.Labels) > 0 {
+			labels = make(map[string]string, len(m.Labels))
+			for k, v := range m.Labels {
+				labels[k] = v
+			}
+		}
 		out = append(out, vivid.ClusterMemberInfo{
+			NodeID:     m.ID,
 			Address:    m.Address,
+			Status:     m.Status.String(),
+			Generation: m.Generation,
+			Labels:     labels,
 			Version:    strconv.FormatUint(ver, 10),
 			Datacenter: m.Datacenter(),
 			Rack:       m.Rack(),
 			Region:     m.Region(),
 			Zone:       m.Zone(),
+			AppVersion: m.AppVersion(),
+			Available:  m.Status.IsAvailable(),
 		})
 	}
 	return out, nil
@@ -97,9 +134,13 @@ func (c *Context) Leave() {
 			switch ctx.Message().(type) {
 			case *vivid.OnLaunch:
 				ctx.EventStream().Subscribe(ctx, ves.ClusterLeaveCompletedEvent{})
-				ctx.Tell(c.clusterRef, &LeaveRequest{})
+				ctx.Tell(c.clusterRef, &localLeaveRequest{})
 			case ves.ClusterLeaveCompletedEvent:
-				close(c.leaveWait)
+				select {
+				case <-c.leaveWait:
+				default:
+					close(c.leaveWait)
+				}
 			}
 		}))
 	}
@@ -122,31 +163,128 @@ func (c *Context) GetView() (*vivid.ClusterView, error) {
 		return nil, vivid.ErrorIllegalArgument
 	}
 	return &vivid.ClusterView{
-		LeaderAddr: resp.LeaderAddr,
-		InQuorum:   resp.InQuorum,
+		LeaderAddr:   resp.LeaderAddr,
+		InQuorum:     resp.InQuorum,
+		HealthyCount: resp.View.HealthyCount,
+		QuorumSize:   resp.View.QuorumSize,
 	}, nil
 }
 
-// SingletonRef 返回名为 name 的集群单例的 ActorRef（本地代理）。
-// 代理会订阅 Leader 变更并转发消息到当前单例，单例迁移后无需重新获取 ref；无可用单例时消息会缓存在代理中，待单例就绪后转发。
-// 集群未启用、代理管理器未就绪或未配置该 name 的模板时返回错误。
-func (c *Context) SingletonRef(name string) (vivid.ActorRef, error) {
+// forceMemberDown 向本节点 NodeActor 发送 ForceMemberDown 管理消息并等待结果。
+func (c *Context) forceMemberDown(nodeID string, adminToken string) error {
 	if c == nil || c.clusterRef == nil || c.system == nil {
-		return nil, vivid.ErrorClusterDisabled
+		return vivid.ErrorClusterDisabled
 	}
-	if c.proxyManagerRef == nil {
-		return nil, vivid.ErrorClusterDisabled
+	if strings.TrimSpace(nodeID) == "" {
+		return vivid.ErrorIllegalArgument
+	}
+	_, err := c.system.Ask(c.clusterRef, &ForceMemberDown{NodeID: nodeID, AdminToken: adminToken}, getViewTimeout).Result()
+	return err
+}
+
+// triggerViewBroadcast 向本节点 NodeActor 发送 TriggerViewBroadcast 管理消息并等待结果。
+func (c *Context) triggerViewBroadcast(adminToken string) error {
+	if c == nil || c.clusterRef == nil || c.system == nil {
+		return vivid.ErrorClusterDisabled
+	}
+	_, err := c.system.Ask(c.clusterRef, &TriggerViewBroadcast{AdminToken: adminToken}, getViewTimeout).Result()
+	return err
+}
+
+// Down 将 address 对应的成员强制下线，自动携带本节点 AdminSecret 计算的令牌；视图中不存在该地址时返回 ErrorNotFound。
+func (c *Context) Down(address string) error {
+	nodeID, err := c.nodeIDOf(address)
+	if err != nil {
+		return err
+	}
+	return c.forceMemberDown(nodeID, c.adminToken)
+}
+
+// ForceBroadcast 立即触发一轮视图广播，自动携带本节点 AdminSecret 计算的令牌。
+func (c *Context) ForceBroadcast() error {
+	return c.triggerViewBroadcast(c.adminToken)
+}
+
+// Join 令本节点向 seeds 发起加入并合并其视图，成功后返回 nil；所有种子均失败时返回最后一个错误。
+// 可用于启动时未配置种子的节点延迟入群，或将已分离的集群手动合并。
+func (c *Context) Join(seeds []string) error {
+	if c == nil || c.clusterRef == nil || c.system == nil {
+		return vivid.ErrorClusterDisabled
+	}
+	if len(seeds) == 0 {
+		return vivid.ErrorIllegalArgument
+	}
+	timeout := c.joinTimeout*time.Duration(len(seeds)) + getViewTimeout
+	_, err := c.system.Ask(c.clusterRef, &StartJoinRequest{Seeds: append([]string(nil), seeds...)}, timeout).Result()
+	return err
+}
+
+// LeaveAddress 令 address 对应节点优雅退出集群；address 为本节点时等价于 Leave。
+// 远程节点配置了 AdminSecret 时须与本节点一致，否则返回 ErrorClusterAdminAuthFailed。
+func (c *Context) LeaveAddress(address string) error {
+	if c == nil || c.clusterRef == nil || c.system == nil {
+		return vivid.ErrorClusterDisabled
+	}
+	addr, ok := utils.NormalizeAddress(address)
+	if !ok {
+		return vivid.ErrorIllegalArgument.WithMessage(address)
+	}
+	if self, ok := utils.NormalizeAddress(c.clusterRef.GetAddress()); ok && self == addr {
+		c.Leave()
+		return nil
+	}
+	ref, err := c.system.CreateRef(addr, "/@cluster")
+	if err != nil {
+		return err
+	}
+	_, err = c.system.Ask(ref, &LeaveRequest{AdminToken: c.adminToken}, remoteLeaveTimeout).Result()
+	return err
+}
+
+// singletonProxyRequest 校验单例名称，返回代理管理者及获取代理的请求；Actor 内可据此以 PipeTo 异步获取代理，避免阻塞。
+func (c *Context) singletonProxyRequest(name string) (vivid.ActorRef, *GetOrCreateProxyRequest, error) {
+	if c == nil || c.clusterRef == nil || c.system == nil || c.proxyManagerRef == nil {
+		return nil, nil, vivid.ErrorClusterDisabled
 	}
 	name = strings.TrimSpace(name)
 	if name == "" {
-		return nil, vivid.ErrorIllegalArgument
+		return nil, nil, vivid.ErrorIllegalArgument
 	}
 	if c.singletonNames != nil {
 		if _, ok := c.singletonNames[name]; !ok {
-			return nil, vivid.ErrorNotFound
+			return nil, nil, vivid.ErrorNotFound
+		}
+	}
+	return c.proxyManagerRef, &GetOrCreateProxyRequest{Name: name}, nil
+}
+
+// nodeIDOf 在当前视图中查找 address 对应成员的 NodeID。
+func (c *Context) nodeIDOf(address string) (string, error) {
+	addr, ok := utils.NormalizeAddress(address)
+	if !ok {
+		return "", vivid.ErrorIllegalArgument.WithMessage(address)
+	}
+	members, err := c.GetMembers()
+	if err != nil {
+		return "", err
+	}
+	for _, m := range members {
+		if norm, ok := utils.NormalizeAddress(m.Address); ok && norm == addr {
+			return m.NodeID, nil
 		}
 	}
-	future := c.system.Ask(c.proxyManagerRef, &GetOrCreateProxyRequest{Name: name}, getViewTimeout)
+	return "", vivid.ErrorNotFound.WithMessage("member " + address)
+}
+
+// SingletonRef 返回名为 name 的集群单例的 ActorRef（本地代理）。
+// 代理会订阅 Leader 变更并转发消息到当前单例，单例迁移后无需重新获取 ref；无可用单例时消息会缓存在代理中，待单例就绪后转发。
+// 集群未启用、代理管理器未就绪或未配置该 name 的模板时返回错误。
+func (c *Context) SingletonRef(name string) (vivid.ActorRef, error) {
+	proxyManager, request, err := c.singletonProxyRequest(name)
+	if err != nil {
+		return nil, err
+	}
+	future := c.system.Ask(proxyManager, request, getViewTimeout)
 	reply, err := future.Result()
 	if err != nil {
 		return nil, err
@@ -160,3 +298,107 @@ func (c *Context) SingletonRef(name string) (vivid.ActorRef, error) {
 	}
 	return resp.Ref, nil
 }
+
+// RegisterService 将 ref 注册到本节点接待者，集群客户端可按 ref 的路径向其发送消息；ref 终止后自动注销。
+func (c *Context) RegisterService(ref vivid.ActorRef) error {
+	if c == nil || c.receptionistRef == nil || c.system == nil {
+		return vivid.ErrorClusterDisabled
+	}
+	if ref == nil {
+		return vivid.ErrorRefEmpty
+	}
+	_, err := c.system.Ask(c.receptionistRef, &RegisterServiceRequest{Ref: ref}, getViewTimeout).Result()
+	return err
+}
+
+// UnregisterService 从本节点接待者注销 ref，未注册时不报错。
+func (c *Context) UnregisterService(ref vivid.ActorRef) error {
+	if c == nil || c.receptionistRef == nil || c.system == nil {
+		return vivid.ErrorClusterDisabled
+	}
+	if ref == nil {
+		return vivid.ErrorRefEmpty
+	}
+	_, err := c.system.Ask(c.receptionistRef, &UnregisterServiceRequest{Ref: ref}, getViewTimeout).Result()
+	return err
+}
+
+// Register 将本节点的 ref 以 key 注册到集群服务注册表；ref 须为本节点 Actor，重复注册不报错。
+func (c *Context) Register(key vivid.ServiceKeyID, ref vivid.ActorRef) error {
+	id, err := c.serviceKeyID(key)
+	if err != nil {
+		return err
+	}
+	if ref == nil {
+		return vivid.ErrorRefEmpty
+	}
+	_, err = c.system.Ask(c.registryRef, &ServiceRegisterRequest{Key: id, Ref: ref}, getViewTimeout).Result()
+	return err
+}
+
+// Deregister 移除 ref 在 key 下的注册，未注册时不报错。
+func (c *Context) Deregister(key vivid.ServiceKeyID, ref vivid.ActorRef) error {
+	id, err := c.serviceKeyID(key)
+	if err != nil {
+		return err
+	}
+	if ref == nil {
+		return vivid.ErrorRefEmpty
+	}
+	_, err = c.system.Ask(c.registryRef, &ServiceDeregisterRequest{Key: id, Ref: ref}, getViewTimeout).Result()
+	return err
+}
+
+// Find 返回本节点已知的 key 在全集群的注册列表。
+func (c *Context) Find(key vivid.ServiceKeyID) ([]vivid.ActorRef, error) {
+	id, err := c.serviceKeyID(key)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := c.system.Ask(c.registryRef, &ServiceFindRequest{Key: id}, getViewTimeout).Result()
+	if err != nil {
+		return nil, err
+	}
+	resp, ok := reply.(*ServiceFindResponse)
+	if !ok {
+		return nil, vivid.ErrorFutureMessageTypeMismatch
+	}
+	return resp.Refs, nil
+}
+
+// Subscribe 订阅 key 的注册列表变化；subscriber 须为本节点 Actor。
+func (c *Context) Subscribe(key vivid.ServiceKeyID, subscriber vivid.ActorRef) error {
+	id, err := c.serviceKeyID(key)
+	if err != nil {
+		return err
+	}
+	if subscriber == nil {
+		return vivid.ErrorRefEmpty
+	}
+	_, err = c.system.Ask(c.registryRef, &ServiceSubscribeRequest{Key: id, Subscriber: subscriber}, getViewTimeout).Result()
+	return err
+}
+
+// Unsubscribe 取消 subscriber 对 key 的订阅，未订阅时不报错。
+func (c *Context) Unsubscribe(key vivid.ServiceKeyID, subscriber vivid.ActorRef) error {
+	id, err := c.serviceKeyID(key)
+	if err != nil {
+		return err
+	}
+	if subscriber == nil {
+		return vivid.ErrorRefEmpty
+	}
+	_, err = c.system.Ask(c.registryRef, &ServiceUnsubscribeRequest{Key: id, Subscriber: subscriber}, getViewTimeout).Result()
+	return err
+}
+
+// serviceKeyID 校验注册表可用并返回服务键标识。
+func (c *Context) serviceKeyID(key vivid.ServiceKeyID) (string, error) {
+	if c == nil || c.registryRef == nil || c.system == nil {
+		return "", vivid.ErrorClusterDisabled
+	}
+	if key == nil || key.ID() == "" {
+		return "", vivid.ErrorIllegalArgument.WithMessage("service key is empty")
+	}
+	return key.ID(), nil
+}
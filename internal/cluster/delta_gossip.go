@@ -0,0 +1,134 @@
+package cluster
+
+import (
+	"github.com/kercylan98/vivid"
+)
+
+// memberDigest 成员状态中参与合并判定的字段摘要，用于发现成员变更（LastSeen 等本地字段不参与）。
+type memberDigest struct {
+	Generation   int
+	Timestamp    int64
+	SeqNo        uint64
+	Status       MemberStatus
+	Unreachable  bool
+	LogicalClock uint64
+	Checksum     uint32
+}
+
+func digestOf(n *NodeState) memberDigest {
+	return memberDigest{
+		Generation:   n.Generation,
+		Timestamp:    n.Timestamp,
+		SeqNo:        n.SeqNo,
+		Status:       n.Status,
+		Unreachable:  n.Unreachable,
+		LogicalClock: n.LogicalClock,
+		Checksum:     n.Checksum,
+	}
+}
+
+// DeltaGossipTracker 记录每个成员最近一次变更时本地视图的版本向量与本地变更序号，
+// 据此为每个目标仅挑选其上次确认版本之后、或上次向其发送之后变更过的成员，实现增量 Gossip。
+// 部分成员变更（如直接通信恢复后解除 Suspect）不推进版本向量，仅靠版本向量判定会漏发，因此以本地序号兜底。
+type DeltaGossipTracker struct {
+	enabled    bool
+	digests    map[string]memberDigest
+	changedAt  map[string]VersionVector
+	changedSeq map[string]uint64 // 成员最近一次变更时的本地序号
+	sentSeq    map[string]uint64 // 目标地址 -> 最近一次向其发送时的本地序号
+	seq        uint64
+}
+
+// NewDeltaGossipTracker 根据集群配置创建增量 Gossip 追踪器。
+func NewDeltaGossipTracker(options vivid.ClusterOptions) *DeltaGossipTracker {
+	return &DeltaGossipTracker{
+		enabled:    options.DeltaGossip,
+		digests:    make(map[string]memberDigest),
+		changedAt:  make(map[string]VersionVector),
+		changedSeq: make(map[string]uint64),
+		sentSeq:    make(map[string]uint64),
+	}
+}
+
+// Enabled 返回是否启用了增量 Gossip。
+func (t *DeltaGossipTracker) Enabled() bool {
+	return t.enabled
+}
+
+// Observe 对比视图与上次观察的成员摘要，为新增或变更的成员记录当前版本向量与新的本地序号，并清除已离开视图的成员。
+// 变更记录的版本不早于实际变更发生时的版本，因而只会多发、不会漏发。
+func (t *DeltaGossipTracker) Observe(v *ClusterView) {
+	if !t.enabled || v == nil {
+		return
+	}
+	changed := false
+	for id, m := range v.Members {
+		if m == nil {
+			continue
+		}
+		d := digestOf(m)
+		if prev, ok := t.digests[id]; ok && prev == d {
+			continue
+		}
+		if !changed {
+			changed = true
+			t.seq++
+		}
+		t.digests[id] = d
+		t.changedAt[id] = v.VersionVector.Clone()
+		t.changedSeq[id] = t.seq
+	}
+	for id := range t.digests {
+		if v.Members[id] == nil {
+			delete(t.digests, id)
+			delete(t.changedAt, id)
+			delete(t.changedSeq, id)
+		}
+	}
+}
+
+// Prune 仅保留 allowed 中目标地址的发送记录，避免 map 无限增长。
+func (t *DeltaGossipTracker) Prune(allowed map[string]bool) {
+	for addr := range t.sentSeq {
+		if !allowed[addr] {
+			delete(t.sentSeq, addr)
+		}
+	}
+}
+
+// HasPending 返回是否存在上次向 addr 发送之后变更过的成员；用于在双方版本向量相同时仍发送未推进版本向量的变更。
+func (t *DeltaGossipTracker) HasPending(addr string) bool {
+	return t.enabled && t.seq > t.sentSeq[addr]
+}
+
+// Delta 基于目标 addr 上次确认的版本向量 acked 从快照中挑选变更成员，返回仅含这些成员的视图。
+// 成员在 acked 之后变更、或在上次向 addr 发送之后变更时均会被选中；调用方在调用后须向 addr 发送增量或全量视图，
+// 本方法据此记录发送时的本地序号。
+// 返回 nil 表示应回退为全量同步：未知目标版本、无成员变更（如仅有移除，需依赖全量视图的版本向量传播），或增量已覆盖全部成员。
+func (t *DeltaGossipTracker) Delta(addr string, snap *ClusterView, acked VersionVector, known bool) *ClusterView {
+	if !t.enabled || snap == nil {
+		return nil
+	}
+	sentSeq := t.sentSeq[addr]
+	t.sentSeq[addr] = t.seq
+	if !known {
+		return nil
+	}
+	members := make(map[string]*NodeState)
+	for id, m := range snap.Members {
+		changedAt, ok := t.changedAt[id]
+		if !ok || t.changedSeq[id] > sentSeq {
+			members[id] = m
+			continue
+		}
+		if order := changedAt.Compare(acked); order == VersionAfter || order == VersionConcurrent {
+			members[id] = m
+		}
+	}
+	if len(members) == 0 || len(members) >= len(snap.Members) {
+		return nil
+	}
+	delta := *snap
+	delta.Members = members
+	return &delta
+}
@@ -0,0 +1,103 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/kercylan98/vivid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeltaGossipTracker_Disabled(t *testing.T) {
+	assertDisabledByDefault(t, NewDeltaGossipTracker, func(tr *DeltaGossipTracker) {
+		v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001", "127.0.0.1:8002"))
+		tr.Observe(v)
+		assert.Nil(t, tr.Delta("127.0.0.1:8002", v.Snapshot(), NewVersionVector(), true))
+	})
+}
+
+func TestDeltaGossipTracker_OnlyChangedMembers(t *testing.T) {
+	tr := NewDeltaGossipTracker(*testClusterOptions(vivid.WithClusterDeltaGossip(true)))
+	v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001", "127.0.0.1:8002", "127.0.0.1:8003"))
+	tr.Observe(v)
+	acked := v.VersionVector.Clone()
+	// 模拟此前已向目标发送过全量视图
+	assert.Nil(t, tr.Delta("127.0.0.1:8003", v.Snapshot(), acked, false))
+
+	v.Members["127.0.0.1:8002"].Status = MemberStatusSuspect
+	v.IncrementVersion("127.0.0.1:8001")
+	tr.Observe(v)
+
+	delta := tr.Delta("127.0.0.1:8003", v.Snapshot(), acked, true)
+	if assert.NotNil(t, delta) {
+		assert.Len(t, delta.Members, 1)
+		assert.Contains(t, delta.Members, "127.0.0.1:8002")
+		assert.True(t, delta.VersionVector.Equal(v.VersionVector))
+	}
+
+	merged := newClusterView()
+	merged.MergeFrom(v.Snapshot())
+	v.Members["127.0.0.1:8002"].Status = MemberStatusUp
+	v.Members["127.0.0.1:8002"].LogicalClock++
+	v.IncrementVersion("127.0.0.1:8001")
+	tr.Observe(v)
+	assert.True(t, merged.MergeFromWithOptions(tr.Delta("127.0.0.1:8003", v.Snapshot(), acked, true), MergeOptions{}))
+	assert.Equal(t, MemberStatusUp, merged.Members["127.0.0.1:8002"].Status)
+}
+
+func TestDeltaGossipTracker_FallbackToFull(t *testing.T) {
+	tr := NewDeltaGossipTracker(*testClusterOptions(vivid.WithClusterDeltaGossip(true)))
+	v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001", "127.0.0.1:8002"))
+	tr.Observe(v)
+
+	// 目标版本未知
+	assert.Nil(t, tr.Delta("127.0.0.1:8002", v.Snapshot(), VersionVector{}, false))
+	// 目标落后于全部成员变更，增量即全量
+	assert.Nil(t, tr.Delta("127.0.0.1:8002", v.Snapshot(), NewVersionVector(), true))
+	// 目标已确认当前版本，无成员变更
+	assert.Nil(t, tr.Delta("127.0.0.1:8002", v.Snapshot(), v.VersionVector.Clone(), true))
+}
+
+func TestDeltaGossipTracker_ChangeWithoutVersionBump(t *testing.T) {
+	tr := NewDeltaGossipTracker(*testClusterOptions(vivid.WithClusterDeltaGossip(true)))
+	v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001", "127.0.0.1:8002", "127.0.0.1:8003"))
+	v.Members["127.0.0.1:8002"].Status = MemberStatusSuspect
+	tr.Observe(v)
+	acked := v.VersionVector.Clone()
+	assert.Nil(t, tr.Delta("127.0.0.1:8003", v.Snapshot(), acked, true))
+	assert.False(t, tr.HasPending("127.0.0.1:8003"))
+
+	// 直接通信恢复后解除 Suspect，不推进版本向量
+	v.Members["127.0.0.1:8002"].Status = MemberStatusUp
+	tr.Observe(v)
+	assert.True(t, v.VersionVector.Equal(acked))
+	assert.True(t, tr.HasPending("127.0.0.1:8003"))
+
+	delta := tr.Delta("127.0.0.1:8003", v.Snapshot(), acked, true)
+	if assert.NotNil(t, delta) {
+		assert.Len(t, delta.Members, 1)
+		assert.Equal(t, MemberStatusUp, delta.Members["127.0.0.1:8002"].Status)
+	}
+	assert.False(t, tr.HasPending("127.0.0.1:8003"))
+}
+
+func TestDeltaGossipTracker_PruneRemoved(t *testing.T) {
+	tr := NewDeltaGossipTracker(*testClusterOptions(vivid.WithClusterDeltaGossip(true)))
+	v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001", "127.0.0.1:8002"))
+	tr.Observe(v)
+	v.RemoveMember("127.0.0.1:8002")
+	tr.Observe(v)
+	assert.NotContains(t, tr.digests, "127.0.0.1:8002")
+	assert.NotContains(t, tr.changedAt, "127.0.0.1:8002")
+	assert.NotContains(t, tr.changedSeq, "127.0.0.1:8002")
+
+	tr.Delta("127.0.0.1:8002", v.Snapshot(), VersionVector{}, false)
+	tr.Prune(map[string]bool{"127.0.0.1:8001": true})
+	assert.NotContains(t, tr.sentSeq, "127.0.0.1:8002")
+}
+
+func TestEncodedViewSize_DeltaSmallerThanFull(t *testing.T) {
+	v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001", "127.0.0.1:8002", "127.0.0.1:8003"))
+	delta := *v.Snapshot()
+	delta.Members = map[string]*NodeState{"127.0.0.1:8001": v.Members["127.0.0.1:8001"]}
+	assert.Greater(t, encodedViewSize(v.Snapshot()), encodedViewSize(&delta))
+}
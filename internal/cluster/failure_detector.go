@@ -6,14 +6,28 @@ import (
 	"github.com/kercylan98/vivid"
 )
 
-// FailureDetector 根据 LastSeen 与超时配置判断成员 Suspect/Down，输出待标记与待移除列表。
+// FailureDetector 根据 LastSeen 与超时配置（或 Phi 累积检测）判断成员 Suspect/Down，输出待标记与待移除列表。
 type FailureDetector struct {
-	options vivid.ClusterOptions
+	options     vivid.ClusterOptions
+	phi         *PhiAccrualDetector  // 仅 FailureDetectorPhiAccrual 时非空
+	suspectedAt map[string]time.Time // Phi 模式下成员首次 φ 超阈值的时间，用于 Suspect 确认时长
 }
 
 // NewFailureDetector 根据集群配置创建故障检测器。
 func NewFailureDetector(options vivid.ClusterOptions) *FailureDetector {
-	return &FailureDetector{options: options}
+	f := &FailureDetector{options: options}
+	if options.FailureDetectorStrategy == vivid.FailureDetectorPhiAccrual {
+		f.phi = NewPhiAccrualDetector(options)
+		f.suspectedAt = make(map[string]time.Time)
+	}
+	return f
+}
+
+// Heartbeat 记录收到成员 id 直接消息的时刻；仅 Phi 累积检测使用，固定超时模式下为空操作。
+func (f *FailureDetector) Heartbeat(id string, now time.Time) {
+	if f.phi != nil {
+		f.phi.Heartbeat(id, now)
+	}
 }
 
 // TimeoutFor 返回对某成员的故障检测超时（同 DC 与跨 DC 可不同）。
@@ -32,6 +46,7 @@ func (f *FailureDetector) TimeoutFor(member *NodeState, selfDC string) time.Dura
 }
 
 // RunDetection 根据当前视图与时间运行一轮检测，返回应标记为 Suspect 与应移除的节点 ID 列表。
+// 启用脑裂处理时超时成员仅标记为 Suspect，不返回待移除列表，剔除由 SplitBrainResolver 裁决。
 func (f *FailureDetector) RunDetection(v *ClusterView, selfAddr string, selfDC string, now time.Time) (toSuspect, toRemove []string) {
 	if v == nil {
 		return nil, nil
@@ -40,6 +55,10 @@ func (f *FailureDetector) RunDetection(v *ClusterView, selfAddr string, selfDC s
 	if confirmDur < 0 {
 		confirmDur = 0
 	}
+	if f.phi != nil {
+		return f.runPhiDetection(v, selfAddr, selfDC, now, confirmDur)
+	}
+	splitBrain := splitBrainEnabled(f.options)
 	for id, m := range v.Members {
 		if m == nil || m.Address == selfAddr {
 			continue
@@ -48,12 +67,18 @@ func (f *FailureDetector) RunDetection(v *ClusterView, selfAddr string, selfDC s
 		if suspectTimeout <= 0 {
 			continue
 		}
+		if splitBrain {
+			if m.Status.IsAvailable() && m.LastSeen < now.Add(-suspectTimeout).UnixNano() {
+				toSuspect = append(toSuspect, id)
+			}
+			continue
+		}
 		downTimeout := suspectTimeout + confirmDur
 		suspectThreshold := now.Add(-suspectTimeout).UnixNano()
 		downThreshold := now.Add(-downTimeout).UnixNano()
 		if m.LastSeen < downThreshold {
 			toRemove = append(toRemove, id)
-		} else if m.Status == MemberStatusUp && m.LastSeen < suspectThreshold && confirmDur > 0 {
+		} else if m.Status.IsAvailable() && m.LastSeen < suspectThreshold && confirmDur > 0 {
 			toSuspect = append(toSuspect, id)
 		} else if m.Status == MemberStatusSuspect && m.LastSeen < downThreshold {
 			toRemove = append(toRemove, id)
@@ -61,3 +86,44 @@ func (f *FailureDetector) RunDetection(v *ClusterView, selfAddr string, selfDC s
 	}
 	return toSuspect, toRemove
 }
+
+// runPhiDetection 以 φ 超过阈值代替固定超时：首次超阈值记为疑似，持续 confirmDur 后移除；φ 回落则清除疑似记录。
+func (f *FailureDetector) runPhiDetection(v *ClusterView, selfAddr string, selfDC string, now time.Time, confirmDur time.Duration) (toSuspect, toRemove []string) {
+	f.phi.Prune(v)
+	for id := range f.suspectedAt {
+		if v.Members[id] == nil {
+			delete(f.suspectedAt, id)
+		}
+	}
+	splitBrain := splitBrainEnabled(f.options)
+	for id, m := range v.Members {
+		if m == nil || m.Address == selfAddr {
+			continue
+		}
+		if f.TimeoutFor(m, selfDC) <= 0 {
+			continue
+		}
+		if f.phi.IsAvailable(id, m.LastSeen, now) {
+			delete(f.suspectedAt, id)
+			continue
+		}
+		if splitBrain {
+			if m.Status.IsAvailable() {
+				toSuspect = append(toSuspect, id)
+			}
+			continue
+		}
+		since, ok := f.suspectedAt[id]
+		if !ok {
+			since = now
+			f.suspectedAt[id] = now
+		}
+		if now.Sub(since) >= confirmDur {
+			toRemove = append(toRemove, id)
+			delete(f.suspectedAt, id)
+		} else if m.Status.IsAvailable() {
+			toSuspect = append(toSuspect, id)
+		}
+	}
+	return toSuspect, toRemove
+}
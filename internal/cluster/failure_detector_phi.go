@@ -0,0 +1,134 @@
+package cluster
+
+import (
+	"math"
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+// heartbeatHistory 单个成员的心跳到达间隔样本（环形窗口），维护和与平方和以 O(1) 计算均值与方差。
+type heartbeatHistory struct {
+	intervals  []float64 // 毫秒
+	next       int
+	sum        float64
+	squaredSum float64
+	last       time.Time // 最近一次心跳到达时间
+}
+
+func (h *heartbeatHistory) add(interval float64, maxSamples int) {
+	if len(h.intervals) < maxSamples {
+		h.intervals = append(h.intervals, interval)
+	} else {
+		old := h.intervals[h.next]
+		h.sum -= old
+		h.squaredSum -= old * old
+		h.intervals[h.next] = interval
+		h.next = (h.next + 1) % maxSamples
+	}
+	h.sum += interval
+	h.squaredSum += interval * interval
+}
+
+func (h *heartbeatHistory) mean() float64 {
+	return h.sum / float64(len(h.intervals))
+}
+
+func (h *heartbeatHistory) stdDeviation() float64 {
+	m := h.mean()
+	variance := h.squaredSum/float64(len(h.intervals)) - m*m
+	if variance < 0 {
+		variance = 0
+	}
+	return math.Sqrt(variance)
+}
+
+// PhiAccrualDetector 基于心跳到达间隔历史的 Phi 累积故障检测器：φ = -log10(P(下次心跳晚于当前已等待时长))。
+// 无样本时以 DiscoveryInterval 为首个间隔估计，避免新成员在收集到足够样本前被误判。
+type PhiAccrualDetector struct {
+	threshold       float64
+	minStdDeviation float64 // 毫秒
+	maxSamples      int
+	firstEstimate   float64 // 毫秒
+	histories       map[string]*heartbeatHistory
+}
+
+// NewPhiAccrualDetector 根据集群配置创建 Phi 累积检测器，未配置的参数使用默认值。
+func NewPhiAccrualDetector(options vivid.ClusterOptions) *PhiAccrualDetector {
+	d := &PhiAccrualDetector{
+		threshold:       options.PhiAccrualThreshold,
+		minStdDeviation: float64(options.PhiAccrualMinStdDeviation) / float64(time.Millisecond),
+		maxSamples:      options.PhiAccrualMaxSampleSize,
+		firstEstimate:   float64(options.DiscoveryInterval) / float64(time.Millisecond),
+		histories:       make(map[string]*heartbeatHistory),
+	}
+	if d.threshold <= 0 {
+		d.threshold = DefaultPhiAccrualThreshold
+	}
+	if d.minStdDeviation <= 0 {
+		d.minStdDeviation = float64(DefaultPhiAccrualMinStdDeviation) / float64(time.Millisecond)
+	}
+	if d.maxSamples <= 0 {
+		d.maxSamples = DefaultPhiAccrualMaxSampleSize
+	}
+	if d.firstEstimate <= 0 {
+		d.firstEstimate = float64(DefaultGossipInterval) / float64(time.Millisecond)
+	}
+	return d
+}
+
+// Heartbeat 记录成员 id 在 now 时刻的一次心跳，并将与上次心跳的间隔加入样本。
+func (d *PhiAccrualDetector) Heartbeat(id string, now time.Time) {
+	h := d.histories[id]
+	if h == nil {
+		d.histories[id] = &heartbeatHistory{last: now}
+		return
+	}
+	if interval := now.Sub(h.last); interval > 0 {
+		h.add(float64(interval)/float64(time.Millisecond), d.maxSamples)
+	}
+	h.last = now
+}
+
+// Phi 返回成员在 now 时刻的 φ 值；lastSeen 为视图中记录的最近可见时间（UnixNano），在尚无心跳记录时作为起点。
+func (d *PhiAccrualDetector) Phi(id string, lastSeen int64, now time.Time) float64 {
+	last := time.Unix(0, lastSeen)
+	mean, stdDeviation := d.firstEstimate, d.firstEstimate/4
+	if h := d.histories[id]; h != nil {
+		if h.last.After(last) {
+			last = h.last
+		}
+		if len(h.intervals) > 0 {
+			mean, stdDeviation = h.mean(), h.stdDeviation()
+		}
+	}
+	if stdDeviation < d.minStdDeviation {
+		stdDeviation = d.minStdDeviation
+	}
+	elapsed := float64(now.Sub(last)) / float64(time.Millisecond)
+	return phi(elapsed, mean, stdDeviation)
+}
+
+// IsAvailable 返回成员在 now 时刻的 φ 是否低于阈值。
+func (d *PhiAccrualDetector) IsAvailable(id string, lastSeen int64, now time.Time) bool {
+	return d.Phi(id, lastSeen, now) < d.threshold
+}
+
+// Prune 清除已不在视图中的成员历史，避免长期运行时内存增长。
+func (d *PhiAccrualDetector) Prune(v *ClusterView) {
+	for id := range d.histories {
+		if v == nil || v.Members[id] == nil {
+			delete(d.histories, id)
+		}
+	}
+}
+
+// phi 使用正态分布累积函数的 logistic 近似计算 φ，与 Akka PhiAccrualFailureDetector 一致。
+func phi(elapsed, mean, stdDeviation float64) float64 {
+	y := (elapsed - mean) / stdDeviation
+	e := math.Exp(-y * (1.5976 + 0.070566*y*y))
+	if elapsed > mean {
+		return -math.Log10(e / (1.0 + e))
+	}
+	return -math.Log10(1.0 - 1.0/(1.0+e))
+}
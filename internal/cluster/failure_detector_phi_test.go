@@ -0,0 +1,89 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhiAccrualDetector_PhiGrowsWithElapsed(t *testing.T) {
+	d := NewPhiAccrualDetector(*testClusterOptions(vivid.WithClusterPhiAccrualFailureDetector(0, 0)))
+	start := time.Now()
+	for i := 0; i <= 10; i++ {
+		d.Heartbeat("n1", start.Add(time.Duration(i)*100*time.Millisecond))
+	}
+	last := start.Add(time.Second)
+	onTime := d.Phi("n1", 0, last.Add(100*time.Millisecond))
+	late := d.Phi("n1", 0, last.Add(time.Second))
+	assert.Less(t, onTime, DefaultPhiAccrualThreshold)
+	assert.Greater(t, late, DefaultPhiAccrualThreshold)
+	assert.True(t, d.IsAvailable("n1", 0, last.Add(100*time.Millisecond)))
+	assert.False(t, d.IsAvailable("n1", 0, last.Add(time.Second)))
+}
+
+func TestPhiAccrualDetector_MinStdDeviation(t *testing.T) {
+	start := time.Now()
+	strict := NewPhiAccrualDetector(*testClusterOptions(vivid.WithClusterPhiAccrualFailureDetector(0, time.Millisecond)))
+	tolerant := NewPhiAccrualDetector(*testClusterOptions(vivid.WithClusterPhiAccrualFailureDetector(0, 500*time.Millisecond)))
+	for i := 0; i <= 10; i++ {
+		strict.Heartbeat("n1", start.Add(time.Duration(i)*100*time.Millisecond))
+		tolerant.Heartbeat("n1", start.Add(time.Duration(i)*100*time.Millisecond))
+	}
+	now := start.Add(time.Second + 300*time.Millisecond)
+	assert.Greater(t, strict.Phi("n1", 0, now), tolerant.Phi("n1", 0, now))
+}
+
+func TestPhiAccrualDetector_MaxSampleSize(t *testing.T) {
+	d := NewPhiAccrualDetector(*testClusterOptions(
+		vivid.WithClusterPhiAccrualFailureDetector(0, 0),
+		vivid.WithClusterPhiAccrualMaxSampleSize(3),
+	))
+	start := time.Now()
+	for i := 0; i <= 10; i++ {
+		d.Heartbeat("n1", start.Add(time.Duration(i)*100*time.Millisecond))
+	}
+	h := d.histories["n1"]
+	assert.Len(t, h.intervals, 3)
+	assert.InDelta(t, 100.0, h.mean(), 0.001)
+}
+
+func TestFailureDetector_PhiAccrualSuspectAndRemove(t *testing.T) {
+	opts := *testClusterOptions(
+		vivid.WithClusterPhiAccrualFailureDetector(0, 0),
+		vivid.WithClusterSuspectConfirmDuration(time.Second),
+	)
+	f := NewFailureDetector(opts)
+	v := newClusterView()
+	v.AddMember(newNodeState("self", "c1", "127.0.0.1:8001"))
+	peer := newNodeState("peer", "c1", "127.0.0.1:8002")
+	peer.Status = MemberStatusUp
+	v.AddMember(peer)
+
+	start := time.Now()
+	for i := 0; i <= 10; i++ {
+		f.Heartbeat("peer", start.Add(time.Duration(i)*50*time.Millisecond))
+	}
+	last := start.Add(500 * time.Millisecond)
+
+	toSuspect, toRemove := f.RunDetection(v, "127.0.0.1:8001", "", last.Add(50*time.Millisecond))
+	assert.Empty(t, toSuspect)
+	assert.Empty(t, toRemove)
+
+	silent := last.Add(5 * time.Second)
+	toSuspect, toRemove = f.RunDetection(v, "127.0.0.1:8001", "", silent)
+	assert.Equal(t, []string{"peer"}, toSuspect)
+	assert.Empty(t, toRemove)
+
+	peer.Status = MemberStatusSuspect
+	toSuspect, toRemove = f.RunDetection(v, "127.0.0.1:8001", "", silent.Add(time.Second))
+	assert.Empty(t, toSuspect)
+	assert.Equal(t, []string{"peer"}, toRemove)
+}
+
+func TestFailureDetector_TimeoutIsDefault(t *testing.T) {
+	opts := *testClusterOptions()
+	assert.Equal(t, vivid.FailureDetectorTimeout, opts.FailureDetectorStrategy)
+	assert.Nil(t, NewFailureDetector(opts).phi)
+}
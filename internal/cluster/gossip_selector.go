@@ -159,3 +159,35 @@ func (g *GossipTargetSelector) SelectTargetsCrossDC(v *ClusterView, nodeState *N
 	rand.Shuffle(len(otherDC), func(i, j int) { otherDC[i], otherDC[j] = otherDC[j], otherDC[i] })
 	return otherDC[:dc]
 }
+
+// SelectIndirectProbers 为间接探测选择至多 k 个协助节点：仅选 Up/WeaklyUp 成员，排除自身与被探测目标，同 DC 优先并随机打散。
+func (g *GossipTargetSelector) SelectIndirectProbers(v *ClusterView, nodeState *NodeState, target string, k int) []string {
+	if v == nil || k <= 0 {
+		return nil
+	}
+	self, _ := utils.NormalizeAddress(nodeState.Address)
+	target, _ = utils.NormalizeAddress(target)
+	selfDC := nodeState.Datacenter()
+	var sameDC, otherDC []string
+	for _, m := range v.Members {
+		if m == nil || !m.Status.IsAvailable() {
+			continue
+		}
+		addr, ok := utils.NormalizeAddress(m.Address)
+		if !ok || addr == self || addr == target {
+			continue
+		}
+		if selfDC != "" && m.Datacenter() != selfDC {
+			otherDC = append(otherDC, addr)
+		} else {
+			sameDC = append(sameDC, addr)
+		}
+	}
+	rand.Shuffle(len(sameDC), func(i, j int) { sameDC[i], sameDC[j] = sameDC[j], sameDC[i] })
+	rand.Shuffle(len(otherDC), func(i, j int) { otherDC[i], otherDC[j] = otherDC[j], otherDC[i] })
+	candidates := append(sameDC, otherDC...)
+	if len(candidates) <= k {
+		return candidates
+	}
+	return candidates[:k]
+}
@@ -0,0 +1,86 @@
+package cluster
+
+import (
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+// pendingProbe 一次进行中的间接探测。
+type pendingProbe struct {
+	probeID  uint64
+	deadline time.Time
+}
+
+// IndirectProber 维护 SWIM 式间接探测状态：成员超时后先由 K 个协助节点代为探测，超时未收到任何 IndirectProbeAck 才确认为故障。
+type IndirectProber struct {
+	options     vivid.ClusterOptions
+	nextProbeID uint64
+	pending     map[string]*pendingProbe // key 为被探测成员 ID
+}
+
+// NewIndirectProber 根据集群配置创建间接探测器。
+func NewIndirectProber(options vivid.ClusterOptions) *IndirectProber {
+	return &IndirectProber{options: options, pending: make(map[string]*pendingProbe)}
+}
+
+// Enabled 返回是否启用了间接探测。
+func (p *IndirectProber) Enabled() bool {
+	return p.options.IndirectProbeCount > 0
+}
+
+// Count 返回每次间接探测请求的协助节点数 K。
+func (p *IndirectProber) Count() int {
+	return p.options.IndirectProbeCount
+}
+
+// Timeout 返回等待间接探测回复的时长，未配置时返回 DefaultIndirectProbeTimeout。
+func (p *IndirectProber) Timeout() time.Duration {
+	if p.options.IndirectProbeTimeout <= 0 {
+		return DefaultIndirectProbeTimeout
+	}
+	return p.options.IndirectProbeTimeout
+}
+
+// Filter 对故障检测给出的候选成员 ID 进行间接探测过滤：
+// confirmed 为间接探测已超时失败、可继续标记 Suspect/移除的成员；toProbe 为尚未探测、需发起间接探测的成员；其余等待中的成员本轮跳过。
+// 不在 candidates 中的进行中探测视为成员已恢复，直接丢弃。
+func (p *IndirectProber) Filter(candidates []string, now time.Time) (confirmed, toProbe []string) {
+	seen := make(map[string]bool, len(candidates))
+	for _, id := range candidates {
+		seen[id] = true
+		probe := p.pending[id]
+		if probe == nil {
+			toProbe = append(toProbe, id)
+			continue
+		}
+		if !now.Before(probe.deadline) {
+			delete(p.pending, id)
+			confirmed = append(confirmed, id)
+		}
+	}
+	for id := range p.pending {
+		if !seen[id] {
+			delete(p.pending, id)
+		}
+	}
+	return confirmed, toProbe
+}
+
+// Start 为成员 id 登记一次间接探测并返回 ProbeID。
+func (p *IndirectProber) Start(id string, now time.Time) uint64 {
+	p.nextProbeID++
+	p.pending[id] = &pendingProbe{probeID: p.nextProbeID, deadline: now.Add(p.Timeout())}
+	return p.nextProbeID
+}
+
+// Ack 处理协助节点转发回的确认，返回对应成员 ID；未知或已过期的 ProbeID 返回 false。
+func (p *IndirectProber) Ack(probeID uint64) (string, bool) {
+	for id, probe := range p.pending {
+		if probe.probeID == probeID {
+			delete(p.pending, id)
+			return id, true
+		}
+	}
+	return "", false
+}
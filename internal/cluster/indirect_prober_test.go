@@ -0,0 +1,80 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndirectProber_Disabled(t *testing.T) {
+	assertDisabledByDefault(t, NewIndirectProber, func(p *IndirectProber) {
+		assert.Equal(t, DefaultIndirectProbeTimeout, p.Timeout())
+	})
+}
+
+func TestIndirectProber_ConfirmAfterTimeout(t *testing.T) {
+	p := NewIndirectProber(*testClusterOptions(vivid.WithClusterIndirectProbe(3, time.Second)))
+	assert.True(t, p.Enabled())
+	now := time.Now()
+
+	confirmed, toProbe := p.Filter([]string{"n1"}, now)
+	assert.Empty(t, confirmed)
+	assert.Equal(t, []string{"n1"}, toProbe)
+	p.Start("n1", now)
+
+	confirmed, toProbe = p.Filter([]string{"n1"}, now.Add(500*time.Millisecond))
+	assert.Empty(t, confirmed)
+	assert.Empty(t, toProbe)
+
+	confirmed, toProbe = p.Filter([]string{"n1"}, now.Add(time.Second))
+	assert.Equal(t, []string{"n1"}, confirmed)
+	assert.Empty(t, toProbe)
+}
+
+func TestIndirectProber_Ack(t *testing.T) {
+	p := NewIndirectProber(*testClusterOptions(vivid.WithClusterIndirectProbe(3, time.Second)))
+	now := time.Now()
+	probeID := p.Start("n1", now)
+
+	_, ok := p.Ack(probeID + 1)
+	assert.False(t, ok)
+	id, ok := p.Ack(probeID)
+	assert.True(t, ok)
+	assert.Equal(t, "n1", id)
+	_, ok = p.Ack(probeID)
+	assert.False(t, ok)
+
+	_, toProbe := p.Filter([]string{"n1"}, now.Add(2*time.Second))
+	assert.Equal(t, []string{"n1"}, toProbe)
+}
+
+func TestIndirectProber_DropRecovered(t *testing.T) {
+	p := NewIndirectProber(*testClusterOptions(vivid.WithClusterIndirectProbe(3, time.Second)))
+	now := time.Now()
+	probeID := p.Start("n1", now)
+	p.Filter(nil, now)
+	_, ok := p.Ack(probeID)
+	assert.False(t, ok)
+}
+
+func TestGossipTargetSelector_SelectIndirectProbers(t *testing.T) {
+	opts := *testClusterOptions()
+	g := NewGossipTargetSelector(opts, func() ([]string, map[string]string) { return nil, nil })
+	v := newClusterView()
+	self := newNodeState("self", "c1", "127.0.0.1:8001")
+	for i, addr := range []string{"127.0.0.1:8001", "127.0.0.1:8002", "127.0.0.1:8003", "127.0.0.1:8004", "127.0.0.1:8005"} {
+		n := newNodeState(addr, "c1", addr)
+		n.Status = MemberStatusUp
+		if i == 4 {
+			n.Status = MemberStatusSuspect
+		}
+		v.AddMember(n)
+	}
+
+	helpers := g.SelectIndirectProbers(v, self, "127.0.0.1:8002", 5)
+	assert.ElementsMatch(t, []string{"127.0.0.1:8003", "127.0.0.1:8004"}, helpers)
+	assert.Len(t, g.SelectIndirectProbers(v, self, "127.0.0.1:8002", 1), 1)
+	assert.Empty(t, g.SelectIndirectProbers(v, self, "127.0.0.1:8002", 0))
+}
@@ -4,7 +4,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kercylan98/vivid/internal/messages"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestComputeJoinToken_VerifyJoinToken(t *testing.T) {
@@ -54,3 +56,16 @@ func TestVerifyAdminToken_EmptySecret(t *testing.T) {
 func TestComputeAdminToken_EmptySecret(t *testing.T) {
 	assert.Empty(t, ComputeAdminToken(""))
 }
+
+func TestLeaveRequest_ReadEmptyPayload(t *testing.T) {
+	// 旧版本节点发送的 LeaveRequest 无载荷，应解码为空令牌
+	m := &LeaveRequest{}
+	require.NoError(t, clusterLeaveRequestReader(m, messages.NewReader(nil), nil))
+	assert.Empty(t, m.AdminToken)
+
+	w := messages.NewWriter()
+	require.NoError(t, clusterLeaveRequestWriter(&LeaveRequest{AdminToken: "token"}, w, nil))
+	dec := &LeaveRequest{}
+	require.NoError(t, clusterLeaveRequestReader(dec, messages.NewReader(w.Bytes()), nil))
+	assert.Equal(t, "token", dec.AdminToken)
+}
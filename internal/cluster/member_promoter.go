@@ -0,0 +1,86 @@
+package cluster
+
+import (
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+// MemberPromoter 维护收敛门控的成员晋升：启用后新成员以 Joining 加入，由 Leader 周期调用 Promote，
+// 视图收敛时将 Joining/WeaklyUp 晋升为 Up，未收敛且 Joining 超过 WeaklyUpAfter 时晋升为 WeaklyUp。
+// Joining 计时仅记录在当前 Leader 本地，Leader 变更后重新计时。
+type MemberPromoter struct {
+	options      vivid.ClusterOptions
+	joiningSince map[string]time.Time // key 为成员 ID，首次观察到 Joining 的时间
+}
+
+// NewMemberPromoter 根据集群配置创建成员晋升器。
+func NewMemberPromoter(options vivid.ClusterOptions) *MemberPromoter {
+	return &MemberPromoter{options: options, joiningSince: make(map[string]time.Time)}
+}
+
+// Enabled 返回是否启用了收敛门控（即配置了 WeaklyUpAfter）。
+func (p *MemberPromoter) Enabled() bool {
+	return p.options.WeaklyUpAfter > 0
+}
+
+// JoinedStatus 返回加入被接受后成员的初始状态：启用时为 Joining，等待 Leader 晋升；否则直接为 Up。
+func (p *MemberPromoter) JoinedStatus() MemberStatus {
+	if p.Enabled() {
+		return MemberStatusJoining
+	}
+	return MemberStatusUp
+}
+
+// Promote 根据视图计算本轮需晋升的成员 ID，由调用方负责修改状态并扩散。
+func (p *MemberPromoter) Promote(v *ClusterView, now time.Time) (toUp, toWeaklyUp []string) {
+	if !p.Enabled() || v == nil {
+		return nil, nil
+	}
+	for id := range p.joiningSince {
+		if m := v.Members[id]; m == nil || m.Status != MemberStatusJoining {
+			delete(p.joiningSince, id)
+		}
+	}
+	converged := IsConverged(v)
+	for id, m := range v.Members {
+		if m == nil {
+			continue
+		}
+		switch m.Status {
+		case MemberStatusJoining:
+			if converged {
+				toUp = append(toUp, id)
+				delete(p.joiningSince, id)
+				continue
+			}
+			since, ok := p.joiningSince[id]
+			if !ok {
+				p.joiningSince[id] = now
+				continue
+			}
+			if now.Sub(since) >= p.options.WeaklyUpAfter {
+				toWeaklyUp = append(toWeaklyUp, id)
+				delete(p.joiningSince, id)
+			}
+		case MemberStatusWeaklyUp:
+			if converged {
+				toUp = append(toUp, id)
+			}
+		}
+	}
+	return toUp, toWeaklyUp
+}
+
+// IsConverged 判断视图是否收敛：不存在 Suspect 或 Unreachable 成员，即所有成员均可达、可就成员变更达成一致。
+func IsConverged(v *ClusterView) bool {
+	if v == nil {
+		return false
+	}
+	for _, m := range v.Members {
+		if m != nil && (m.Status == MemberStatusSuspect || m.Status == MemberStatusUnreachable) {
+			return false
+		}
+	}
+	return true
+}
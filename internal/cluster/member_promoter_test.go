@@ -0,0 +1,137 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberPromoter_Disabled(t *testing.T) {
+	assertDisabledByDefault(t, NewMemberPromoter, func(p *MemberPromoter) {
+		assert.Equal(t, MemberStatusUp, p.JoinedStatus())
+		toUp, toWeaklyUp := p.Promote(newTestView(testMembers(MemberStatusJoining, "127.0.0.1:7001")), time.Now())
+		assert.Empty(t, toUp)
+		assert.Empty(t, toWeaklyUp)
+	})
+}
+
+func TestMemberPromoter_ConvergedPromotesToUp(t *testing.T) {
+	p := NewMemberPromoter(*testClusterOptions(vivid.WithClusterWeaklyUp(time.Second)))
+	assert.True(t, p.Enabled())
+	assert.Equal(t, MemberStatusJoining, p.JoinedStatus())
+
+	v := newTestView(
+		testMembers(MemberStatusUp, "127.0.0.1:7001"),
+		testMembers(MemberStatusJoining, "127.0.0.1:7002"),
+		testMembers(MemberStatusWeaklyUp, "127.0.0.1:7003"),
+	)
+	toUp, toWeaklyUp := p.Promote(v, time.Now())
+	assert.ElementsMatch(t, []string{"127.0.0.1:7002", "127.0.0.1:7003"}, toUp)
+	assert.Empty(t, toWeaklyUp)
+}
+
+func TestMemberPromoter_WeaklyUpAfterTimeout(t *testing.T) {
+	p := NewMemberPromoter(*testClusterOptions(vivid.WithClusterWeaklyUp(time.Second)))
+	v := newTestView(
+		testMembers(MemberStatusUp, "127.0.0.1:7001"),
+		testMembers(MemberStatusSuspect, "127.0.0.1:7002"),
+		testMembers(MemberStatusJoining, "127.0.0.1:7003"),
+	)
+	assert.False(t, IsConverged(v))
+	now := time.Now()
+
+	toUp, toWeaklyUp := p.Promote(v, now)
+	assert.Empty(t, toUp)
+	assert.Empty(t, toWeaklyUp)
+
+	_, toWeaklyUp = p.Promote(v, now.Add(500*time.Millisecond))
+	assert.Empty(t, toWeaklyUp)
+
+	toUp, toWeaklyUp = p.Promote(v, now.Add(time.Second))
+	assert.Empty(t, toUp)
+	assert.Equal(t, []string{"127.0.0.1:7003"}, toWeaklyUp)
+
+	// 收敛后 WeaklyUp 晋升为 Up
+	v.Members["127.0.0.1:7003"].Status = MemberStatusWeaklyUp
+	v.Members["127.0.0.1:7002"].Status = MemberStatusUp
+	toUp, _ = p.Promote(v, now.Add(2*time.Second))
+	assert.Equal(t, []string{"127.0.0.1:7003"}, toUp)
+}
+
+func TestMemberPromoter_ForgetsRemovedJoining(t *testing.T) {
+	p := NewMemberPromoter(*testClusterOptions(vivid.WithClusterWeaklyUp(time.Second)))
+	v := newTestView(
+		testMembers(MemberStatusUnreachable, "127.0.0.1:7001"),
+		testMembers(MemberStatusJoining, "127.0.0.1:7002"),
+	)
+	now := time.Now()
+	p.Promote(v, now)
+	v.RemoveMember("127.0.0.1:7002")
+	p.Promote(v, now.Add(time.Second))
+	assert.Empty(t, p.joiningSince)
+}
+
+func TestNodeState_TouchIsNewer(t *testing.T) {
+	n := newNodeState("n1", "c1", "127.0.0.1:8001")
+	before := n.Clone()
+	n.touch(time.Unix(0, before.Timestamp))
+	assert.True(t, n.IsNewerThan(before))
+	assert.True(t, MemberStatusWeaklyUp.IsAvailable())
+	assert.False(t, MemberStatusJoining.IsAvailable())
+	assert.Equal(t, "weakly-up", MemberStatusWeaklyUp.String())
+}
+
+func TestNodeActor_RecoverSuspectKeepsPromotionGate(t *testing.T) {
+	a := NewNodeActor("127.0.0.1:7001", *testClusterOptions(vivid.WithClusterWeaklyUp(time.Second)))
+
+	weak := newNodeState("7002", "c1", "127.0.0.1:7002")
+	weak.Status = MemberStatusSuspect
+	a.suspectedFrom[weak.ID] = MemberStatusWeaklyUp
+	a.recoverSuspect(weak)
+	assert.Equal(t, MemberStatusWeaklyUp, weak.Status)
+	assert.NotContains(t, a.suspectedFrom, weak.ID)
+
+	up := newNodeState("7003", "c1", "127.0.0.1:7003")
+	up.Status = MemberStatusSuspect
+	a.suspectedFrom[up.ID] = MemberStatusUp
+	a.recoverSuspect(up)
+	assert.Equal(t, MemberStatusUp, up.Status)
+
+	// 经 Gossip 获知的 Suspect 前状态未知，启用门控时还原为 WeaklyUp 等待 Leader 晋升
+	learned := newNodeState("7004", "c1", "127.0.0.1:7004")
+	learned.Status = MemberStatusSuspect
+	a.recoverSuspect(learned)
+	assert.Equal(t, MemberStatusWeaklyUp, learned.Status)
+
+	b := NewNodeActor("127.0.0.1:7001", *testClusterOptions())
+	learned.Status = MemberStatusSuspect
+	b.recoverSuspect(learned)
+	assert.Equal(t, MemberStatusUp, learned.Status)
+}
+
+// listingTestRef 仅实现 listing 用到的方法。
+type listingTestRef struct {
+	vivid.ActorRef
+	addr string
+}
+
+func (r listingTestRef) GetAddress() string { return r.addr }
+func (r listingTestRef) String() string     { return r.addr + "/svc" }
+
+func TestServiceRegistry_ListingSkipsJoiningMembers(t *testing.T) {
+	r := NewServiceRegistry().(*serviceRegistry)
+	r.members["127.0.0.1:7002"] = false
+	r.members["127.0.0.1:7003"] = true
+	r.remote["127.0.0.1:7002"] = &remoteServices{services: map[string][]vivid.ActorRef{"svc": {listingTestRef{addr: "127.0.0.1:7002"}}}}
+	r.remote["127.0.0.1:7003"] = &remoteServices{services: map[string][]vivid.ActorRef{"svc": {listingTestRef{addr: "127.0.0.1:7003"}}}}
+
+	refs := r.listing("svc")
+	if assert.Len(t, refs, 1) {
+		assert.Equal(t, "127.0.0.1:7003", refs[0].GetAddress())
+	}
+
+	r.members["127.0.0.1:7002"] = true
+	assert.Len(t, r.listing("svc"), 2)
+}
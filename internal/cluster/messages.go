@@ -1,10 +1,15 @@
 package cluster
 
-import "time"
+import (
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
 
 // 以下消息类型在序列化时仍使用 "ClusterInternalMessageFor*" 作为 wire 名称以保持兼容。
 
-// StartJoinRequest 启动加入集群请求（当前未使用，保留供扩展）。
+// StartJoinRequest 请求本节点向给定种子发起加入（Ask 后回复 nil 或错误），由 Context.Join 发送。
+// 仅限本地使用，不注册为可跨节点传输的消息。
 type StartJoinRequest struct {
 	Seeds []string
 }
@@ -25,6 +30,11 @@ type GossipMessage struct {
 	View *ClusterView
 }
 
+// GossipDeltaMessage 增量 Gossip 消息：View 仅包含接收方上次确认版本之后变更的成员，版本向量等元数据与全量视图一致。
+type GossipDeltaMessage struct {
+	View *ClusterView
+}
+
 // GossipTick 触发本 DC 内一轮 Gossip 的定时消息。
 type GossipTick struct{}
 
@@ -34,8 +44,43 @@ type GossipCrossDCTick struct{}
 // FailureDetectionTick 触发故障检测轮次的定时消息。
 type FailureDetectionTick struct{}
 
-// LeaveRequest 请求本节点优雅退出集群（Ask 后等待 LeaveAck）。
-type LeaveRequest struct{}
+// LeaveRequest 请求节点优雅退出集群（Ask 后等待 LeaveAck），由其他节点经 LeaveAddress 发送。
+// 接收方配置了 AdminSecret 时须携带匹配的 AdminToken。
+type LeaveRequest struct {
+	AdminToken string
+}
+
+// localLeaveRequest 本节点内发起的退出请求（Context.Leave、脑裂决策下线自身），语义同 LeaveRequest 但免于令牌校验。
+// 仅限本地使用，不注册为可跨节点传输的消息，因此无法由其他节点伪造。
+type localLeaveRequest struct{}
+
+// ClusterClientRequest 集群客户端经接待者发往集群内服务或单例的请求，接待者以 *ClusterClientResponse 应答。
+// Singleton 非空时发往该名称的集群单例，否则发往 Path 对应的已注册服务。
+// Reply 为 true 时接待者以 Timeout（≤0 使用默认 Ask 超时）等待目标回复并转交客户端，否则投递后立即确认。
+type ClusterClientRequest struct {
+	Path      string
+	Singleton string
+	Reply     bool
+	Timeout   time.Duration
+	Message   vivid.Message
+}
+
+// ClusterClientResponse 接待者对 ClusterClientRequest 的应答：Message 为目标回复（仅投递时为 nil），
+// Error 为目标解析失败或等待回复失败的原因，跨节点传输时非 *vivid.Error 的错误会包装为 ErrorException。
+type ClusterClientResponse struct {
+	Message vivid.Message
+	Error   error
+}
+
+// ServiceRegistryUpdate 节点服务注册表推送给其他成员的本节点注册快照，Version 单调递增，接收方仅接受更新的版本。
+// Keys、Addresses、Paths 按下标一一对应，每组描述一个注册。
+type ServiceRegistryUpdate struct {
+	Address   string
+	Version   uint64
+	Keys      []string
+	Addresses []string
+	Paths     []string
+}
 
 // LeaveAck 本节点完成「广播离开视图并进入 Exiting」后回复给 Leave 调用方。
 type LeaveAck struct{}
@@ -73,3 +118,26 @@ type ForceMemberDown struct {
 type TriggerViewBroadcast struct {
 	AdminToken string
 }
+
+// IndirectProbeRequest 间接探测请求：发起方请求协助方代为探测 Target（host:port）是否存活。
+type IndirectProbeRequest struct {
+	ProbeID uint64
+	Target  string
+}
+
+// ProbeRequest 协助方发往被探测节点的探测消息，Origin 为发起方地址，随 ProbeAck 原样带回。
+type ProbeRequest struct {
+	ProbeID uint64
+	Origin  string
+}
+
+// ProbeAck 被探测节点对 ProbeRequest 的回复，发往协助方。
+type ProbeAck struct {
+	ProbeID uint64
+	Origin  string
+}
+
+// IndirectProbeAck 协助方确认 Target 存活后转发给发起方的回复。
+type IndirectProbeAck struct {
+	ProbeID uint64
+}
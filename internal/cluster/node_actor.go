@@ -29,6 +29,9 @@ func NewNodeActor(address string, options vivid.ClusterOptions) *NodeActor {
 	if options.Zone != "" {
 		state.Labels[LabelZone] = options.Zone
 	}
+	if options.AppVersion != "" {
+		state.Labels[LabelAppVersion] = options.AppVersion
+	}
 	cv := newClusterView()
 	cv.MaxVersionVectorEntries = options.MaxVersionVectorEntries
 	seedsProvider := NewSeedsProvider(options)
@@ -46,11 +49,16 @@ func NewNodeActor(address string, options vivid.ClusterOptions) *NodeActor {
 		gossipRateLimiter:       gossipLimiter,
 		gossipSelector:          NewGossipTargetSelector(options, seedsProvider.GetAllSeedsWithDC),
 		failureDetector:         NewFailureDetector(options),
-		events:                  NewClusterEventPublisher(),
+		splitBrainResolver:      NewSplitBrainResolver(options),
+		indirectProber:          NewIndirectProber(options),
+		memberPromoter:          NewMemberPromoter(options),
+		deltaGossip:             NewDeltaGossipTracker(options),
+		events:                  NewClusterEventPublisher(options),
 		leaveCoordinator:        NewLeaveCoordinator(),
 		metricsUpdater:          NewClusterMetricsUpdater(),
 		joinBackoff:             utils.NewExponentialBackoffWithDefault(InitialJoinRetryDelay, MaxJoinRetryDelay),
 		lastVersionVectorByAddr: make(map[string]VersionVector),
+		suspectedFrom:           make(map[string]MemberStatus),
 	}
 }
 
@@ -65,11 +73,16 @@ type NodeActor struct {
 	gossipRateLimiter       *GossipRateLimiter
 	gossipSelector          *GossipTargetSelector
 	failureDetector         *FailureDetector
+	splitBrainResolver      *SplitBrainResolver
+	indirectProber          *IndirectProber
+	memberPromoter          *MemberPromoter
+	deltaGossip             *DeltaGossipTracker
 	events                  *EventPublisher
 	leaveCoordinator        *LeaveCoordinator
 	metricsUpdater          *MetricsUpdater
 	joinBackoff             *utils.ExponentialBackoff
 	lastVersionVectorByAddr map[string]VersionVector // 各地址上次发来的视图版本，用于发送前跳过“目标合并后不会变更”的同步
+	suspectedFrom           map[string]MemberStatus  // 被本节点标记为 Suspect 的成员此前的状态，恢复可达时还原
 }
 
 func (a *NodeActor) OnReceive(ctx vivid.ActorContext) {
@@ -78,22 +91,40 @@ func (a *NodeActor) OnReceive(ctx vivid.ActorContext) {
 		a.onLaunch(ctx)
 	case *JoinRetryTick:
 		a.onJoinRetryTick(ctx)
+	case *localLeaveRequest:
+		a.leave(ctx)
 	case *LeaveRequest:
-		if a.nodeState.Status == MemberStatusJoining {
-			a.onLeaveWhileJoining(ctx)
-		} else {
-			a.handleLeaveRequest(ctx)
+		if !a.authorizeLeave(m) {
+			ctx.Reply(vivid.ErrorClusterAdminAuthFailed)
+			return
 		}
+		a.leave(ctx)
+	case *StartJoinRequest:
+		a.handleStartJoin(ctx, m)
 	case *JoinRequest:
 		a.handleJoinRequest(ctx, m)
 	case *GossipMessage:
-		a.handleGossip(ctx, m)
+		if m != nil {
+			a.handleGossip(ctx, m.View, false)
+		}
+	case *GossipDeltaMessage:
+		if m != nil {
+			a.handleGossip(ctx, m.View, true)
+		}
 	case *GossipTick:
 		a.runGossipRound(ctx)
 	case *GossipCrossDCTick:
 		a.runGossipRoundCrossDC(ctx)
 	case *FailureDetectionTick:
 		a.runFailureDetection(ctx)
+	case *IndirectProbeRequest:
+		a.handleIndirectProbeRequest(ctx, m)
+	case *ProbeRequest:
+		a.handleProbeRequest(ctx, m)
+	case *ProbeAck:
+		a.handleProbeAck(ctx, m)
+	case *IndirectProbeAck:
+		a.handleIndirectProbeAck(ctx, m)
 	case *GetViewRequest:
 		a.handleGetView(ctx)
 	case *ForceMemberDown:
@@ -147,6 +178,65 @@ func (a *NodeActor) onJoinRetryTick(ctx vivid.ActorContext) {
 	ctx.Logger().Debug("join retry failed, next in "+nextDelay.String(), log.Any("nextDelay", nextDelay))
 }
 
+// handleStartJoin 处理 Context.Join：向给定种子发起加入并合并其视图，成功后停止 Join 重试并确保 Gossip 与故障检测循环已启动。
+// 已处于 Up 状态时同样会合并种子所在集群的视图，可用于运维侧的手动合并；离开中的节点拒绝加入。
+// StartJoinRequest 未注册为可跨节点传输的消息，只能由本节点内发起。
+func (a *NodeActor) handleStartJoin(ctx vivid.ActorContext, m *StartJoinRequest) {
+	if m == nil || len(m.Seeds) == 0 {
+		ctx.Reply(vivid.ErrorIllegalArgument)
+		return
+	}
+	if a.nodeState.Status == MemberStatusLeaving || a.nodeState.Status == MemberStatusExiting {
+		ctx.Reply(vivid.ErrorIllegalArgument.WithMessage("node is leaving"))
+		return
+	}
+	self, _ := utils.NormalizeAddress(a.nodeState.Address)
+	seeds := make([]string, 0, len(m.Seeds))
+	for _, s := range m.Seeds {
+		if norm, ok := utils.NormalizeAddress(s); ok && norm != self {
+			seeds = append(seeds, norm)
+		}
+	}
+	if len(seeds) == 0 {
+		ctx.Reply(vivid.ErrorIllegalArgument.WithMessage("no valid seeds"))
+		return
+	}
+	// 种子只接受 Joining 状态的加入请求；已自举为 Up 的节点以 Joining 身份发起，失败时恢复原状态
+	prevStatus := a.nodeState.Status
+	a.nodeState.Status = MemberStatusJoining
+	if err := a.tryJoinSeeds(ctx, seeds); err != nil {
+		a.nodeState.Status = prevStatus
+		ctx.Reply(err)
+		return
+	}
+	_ = ctx.Scheduler().Cancel(SchedRefJoinRetry)
+	a.joinBackoff.Reset()
+	if !ctx.Scheduler().Exists(SchedRefGossip) {
+		a.startGossipLoop(ctx)
+	}
+	if !ctx.Scheduler().Exists(SchedRefFailureDetection) {
+		a.startFailureDetectionLoop(ctx)
+	}
+	a.metricsUpdater.Update(ctx, a.clusterView)
+	ctx.Logger().Debug("joined cluster by request", log.Any("seeds", seeds))
+	ctx.Reply(nil)
+}
+
+// authorizeLeave 校验其他节点发来的退出请求：配置了 AdminSecret 时须携带匹配的令牌。
+// 不依据 sender 地址判断请求是否来自本节点，该地址取自远程帧，可被伪造；本节点内的退出使用 localLeaveRequest。
+func (a *NodeActor) authorizeLeave(m *LeaveRequest) bool {
+	return m != nil && VerifyAdminToken(a.options.AdminSecret, m.AdminToken)
+}
+
+// leave 执行退出：尚未加入视图的 Joining 节点直接退出，否则广播离开后退出。
+func (a *NodeActor) leave(ctx vivid.ActorContext) {
+	if a.nodeState.Status == MemberStatusJoining && a.clusterView.Members[a.nodeState.ID] == nil {
+		a.onLeaveWhileJoining(ctx)
+	} else {
+		a.handleLeaveRequest(ctx)
+	}
+}
+
 func (a *NodeActor) onLeaveWhileJoining(ctx vivid.ActorContext) {
 	a.cancelAllSchedulers(ctx)
 	a.nodeState.Status = MemberStatusExiting
@@ -243,7 +333,7 @@ func (a *NodeActor) tryJoinSeeds(ctx vivid.ActorContext, seeds []string) error {
 			lastErr = vivid.ErrorClusterProtocolVersionMismatch
 			continue
 		}
-		a.nodeState.Status = MemberStatusUp
+		a.nodeState.Status = a.memberPromoter.JoinedStatus()
 		a.clusterView.AddMember(a.nodeState)
 		a.incrementLocalVersion()
 		a.clusterView.MergeFromWithOptions(resp.View, a.getMergeOptions())
@@ -286,7 +376,7 @@ func (a *NodeActor) handleGetView(ctx vivid.ActorContext) {
 	ctx.Reply(&GetViewResponse{
 		View:       snap,
 		InQuorum:   a.quorumCalc.SatisfiesQuorum(a.clusterView),
-		LeaderAddr: ComputeLeaderAddr(snap),
+		LeaderAddr: ComputeLeaderAddr(snap, a.options.PreferNewestAppVersion),
 	})
 }
 
@@ -310,7 +400,7 @@ func (a *NodeActor) handleForceMemberDown(ctx vivid.ActorContext, m *ForceMember
 	removedAddr := member.Address
 	a.clusterView.RemoveMember(m.NodeID)
 	a.incrementLocalVersion()
-	a.events.PublishMembersChanged(ctx, a.memberAddresses(), 0, []string{removedAddr})
+	a.events.PublishMembersChanged(ctx, a.clusterView, 0, []string{removedAddr})
 	a.events.PublishViewChanged(ctx, a.clusterView, 0, []string{removedAddr})
 	a.events.PublishLeaderIfChanged(ctx, a.clusterView, a.nodeState.Address, a.quorumCalc.SatisfiesQuorum(a.clusterView))
 	a.metricsUpdater.Update(ctx, a.clusterView)
@@ -368,10 +458,10 @@ func (a *NodeActor) handleJoinRequest(ctx vivid.ActorContext, m *JoinRequest) {
 		return
 	}
 	accepted := m.NodeState.Clone()
-	accepted.Status = MemberStatusUp
+	accepted.Status = a.memberPromoter.JoinedStatus()
 	a.clusterView.AddMember(accepted)
 	a.incrementLocalVersion()
-	a.events.PublishMembersChanged(ctx, a.memberAddresses(), 1, nil)
+	a.events.PublishMembersChanged(ctx, a.clusterView, 1, nil)
 	a.events.PublishLeaderIfChanged(ctx, a.clusterView, a.nodeState.Address, a.quorumCalc.SatisfiesQuorum(a.clusterView))
 	a.metricsUpdater.Update(ctx, a.clusterView)
 	snap := a.clusterView.Snapshot()
@@ -383,38 +473,120 @@ func (a *NodeActor) handleJoinRequest(ctx vivid.ActorContext, m *JoinRequest) {
 	a.broadcastViewOnce(ctx) // 状态变更立即同步
 }
 
-func (a *NodeActor) handleGossip(ctx vivid.ActorContext, m *GossipMessage) {
-	if m == nil || m.View == nil {
+// handleGossip 合并收到的视图；delta 为 true 时视图仅含变更成员，不参与视图差异指标计算。
+func (a *NodeActor) handleGossip(ctx vivid.ActorContext, view *ClusterView, delta bool) {
+	if view == nil {
 		return
 	}
 	if a.clusterView == nil {
 		return
 	}
-	if !a.acceptProtocolVersion(m.View.ProtocolVersion) {
-		ctx.Logger().Debug("gossip view rejected: protocol version out of range", log.Any("version", m.View.ProtocolVersion))
+	if !a.acceptProtocolVersion(view.ProtocolVersion) {
+		ctx.Logger().Debug("gossip view rejected: protocol version out of range", log.Any("version", view.ProtocolVersion))
 		return
 	}
-	a.metricsUpdater.UpdateViewDivergence(ctx, a.clusterView, m.View)
+	if !delta {
+		a.metricsUpdater.UpdateViewDivergence(ctx, a.clusterView, view)
+	}
 	sender := ctx.Sender()
 	if sender != nil {
 		if addr := sender.GetAddress(); addr != "" {
 			if norm, ok := utils.NormalizeAddress(addr); ok {
-				a.lastVersionVectorByAddr[norm] = m.View.VersionVector.Clone()
+				a.lastVersionVectorByAddr[norm] = view.VersionVector.Clone()
 			}
 			if member := a.clusterView.MemberByAddress(addr); member != nil {
-				member.LastSeen = time.Now().UnixNano()
-				if member.Status == MemberStatusSuspect {
-					member.Status = MemberStatusUp
-				}
+				now := time.Now()
+				member.LastSeen = now.UnixNano()
+				a.failureDetector.Heartbeat(member.ID, now)
+				a.recoverSuspect(member)
 			}
 		}
 	}
-	if a.clusterView.MergeFromWithOptions(m.View, a.getMergeOptions()) {
+	before := memberAddressSet(a.clusterView)
+	if a.clusterView.MergeFromWithOptions(view, a.getMergeOptions()) {
+		a.adoptPromotedSelf(ctx)
+		a.publishMembersDiff(ctx, before)
 		a.events.PublishLeaderIfChanged(ctx, a.clusterView, a.nodeState.Address, a.quorumCalc.SatisfiesQuorum(a.clusterView))
+		a.events.PublishAppVersionReachedIfNeeded(ctx, a.clusterView)
 		a.broadcastViewOnce(ctx)
 	}
 }
 
+// publishMembersDiff 在合并后成员地址集合相对 before 发生变化时发布成员变更事件，使经 Gossip 获知的加入与移除同样可被订阅方感知。
+func (a *NodeActor) publishMembersDiff(ctx vivid.ActorContext, before map[string]struct{}) {
+	after := memberAddressSet(a.clusterView)
+	added := 0
+	for addr := range after {
+		if _, ok := before[addr]; !ok {
+			added++
+		}
+	}
+	var removed []string
+	for addr := range before {
+		if _, ok := after[addr]; !ok {
+			removed = append(removed, addr)
+		}
+	}
+	if added == 0 && len(removed) == 0 {
+		return
+	}
+	a.events.PublishMembersChanged(ctx, a.clusterView, added, removed)
+}
+
+// memberAddressSet 返回视图中成员地址集合。
+func memberAddressSet(v *ClusterView) map[string]struct{} {
+	set := make(map[string]struct{}, len(v.Members))
+	for _, m := range v.Members {
+		if m != nil && m.Address != "" {
+			set[m.Address] = struct{}{}
+		}
+	}
+	return set
+}
+
+// promoteMembers 由 Leader 执行收敛门控的成员晋升（Joining→WeaklyUp/Up、WeaklyUp→Up），并推进被晋升成员的时钟以便变更随 Gossip 扩散。
+func (a *NodeActor) promoteMembers(ctx vivid.ActorContext, now time.Time) {
+	if !a.memberPromoter.Enabled() || ComputeLeaderAddr(a.clusterView, a.options.PreferNewestAppVersion) != a.nodeState.Address {
+		return
+	}
+	toUp, toWeaklyUp := a.memberPromoter.Promote(a.clusterView, now)
+	if len(toUp) == 0 && len(toWeaklyUp) == 0 {
+		return
+	}
+	promote := func(ids []string, status MemberStatus) {
+		for _, id := range ids {
+			if m := a.clusterView.Members[id]; m != nil {
+				m.Status = status
+				m.touch(now)
+				ctx.Logger().Debug("member promoted", log.String("nodeId", id), log.String("address", m.Address), log.String("status", status.String()))
+			}
+		}
+	}
+	promote(toUp, MemberStatusUp)
+	promote(toWeaklyUp, MemberStatusWeaklyUp)
+	a.clusterView.recomputeCounts()
+	a.incrementLocalVersion()
+	a.events.PublishMembersChanged(ctx, a.clusterView, 0, nil)
+	a.events.PublishViewChanged(ctx, a.clusterView, 0, nil)
+	a.broadcastViewOnce(ctx)
+}
+
+// adoptPromotedSelf 在合并后同步 Leader 对本节点的晋升结果（Joining→WeaklyUp/Up、WeaklyUp→Up），使本节点后续发出的状态不回退。
+func (a *NodeActor) adoptPromotedSelf(ctx vivid.ActorContext) {
+	self := a.clusterView.Members[a.nodeState.ID]
+	if self == nil || self.Status == a.nodeState.Status || !self.Status.IsAvailable() {
+		return
+	}
+	if a.nodeState.Status != MemberStatusJoining && a.nodeState.Status != MemberStatusWeaklyUp {
+		return
+	}
+	a.nodeState.Status = self.Status
+	a.nodeState.LogicalClock = self.LogicalClock
+	a.nodeState.Timestamp = self.Timestamp
+	ctx.Logger().Debug("self promoted", log.String("status", self.Status.String()))
+	a.events.PublishMembersChanged(ctx, a.clusterView, 0, nil)
+}
+
 // runGossipRoundWithTargets
 func (a *NodeActor) runGossipRoundWithTargets(ctx vivid.ActorContext, targets []string) {
 	if len(targets) == 0 {
@@ -424,25 +596,38 @@ func (a *NodeActor) runGossipRoundWithTargets(ctx vivid.ActorContext, targets []
 	if snap == nil {
 		return
 	}
+	a.deltaGossip.Observe(a.clusterView)
+	fullBytes := 0
+	if a.deltaGossip.Enabled() && ctx.MetricsEnabled() {
+		fullBytes = encodedViewSize(snap)
+	}
 	msg := &GossipMessage{View: snap}
 	for _, addr := range targets {
 		if !a.gossipRateLimiter.Allow() {
 			break
 		}
-		if !a.shouldSendGossipTo(snap.VersionVector, addr) {
+		if !a.shouldSendGossipTo(snap.VersionVector, addr) && !a.deltaGossip.HasPending(addr) {
 			continue
 		}
 		ref, err := ctx.System().CreateRef(addr, "/@cluster")
 		if err != nil {
 			continue
 		}
+		acked, known := a.lastVersionVectorOf(addr)
+		if delta := a.deltaGossip.Delta(addr, snap, acked, known); delta != nil {
+			ctx.Tell(ref, &GossipDeltaMessage{View: delta})
+			a.metricsUpdater.RecordGossipSent(ctx, fullBytes, delta)
+			continue
+		}
 		ctx.Tell(ref, msg)
+		a.metricsUpdater.RecordGossipSent(ctx, fullBytes, nil)
 	}
 }
 
 // runGossipRound 执行一轮 Gossip，由调度器周期触发。
 func (a *NodeActor) runGossipRound(ctx vivid.ActorContext) {
 	a.pruneLastVersionVectors()
+	a.promoteMembers(ctx, time.Now())
 	targets := a.gossipSelector.SelectTargets(a.clusterView, a.nodeState)
 	a.runGossipRoundWithTargets(ctx, targets)
 }
@@ -470,11 +655,20 @@ func (a *NodeActor) runFailureDetection(ctx vivid.ActorContext) {
 	if a.clusterView == nil {
 		return
 	}
+	for id := range a.suspectedFrom {
+		if m := a.clusterView.Members[id]; m == nil || m.Status != MemberStatusSuspect {
+			delete(a.suspectedFrom, id)
+		}
+	}
 	now := time.Now()
 	toSuspect, toRemove := a.failureDetector.RunDetection(a.clusterView, a.nodeState.Address, a.nodeState.Datacenter(), now)
+	toSuspect, toRemove = a.filterByIndirectProbe(ctx, toSuspect, toRemove, now)
 	for _, id := range toSuspect {
 		if m := a.clusterView.Members[id]; m != nil {
 			ctx.Logger().Debug("member suspect", log.String("nodeId", id), log.String("address", m.Address))
+			if m.Status != MemberStatusSuspect {
+				a.suspectedFrom[id] = m.Status
+			}
 			m.Status = MemberStatusSuspect
 			a.incrementLocalVersion()
 		}
@@ -493,10 +687,11 @@ func (a *NodeActor) runFailureDetection(ctx vivid.ActorContext) {
 		a.incrementLocalVersion()
 	}
 	if len(removedAddresses) > 0 {
-		a.events.PublishMembersChanged(ctx, a.memberAddresses(), 0, removedAddresses)
+		a.events.PublishMembersChanged(ctx, a.clusterView, 0, removedAddresses)
 		a.events.PublishViewChanged(ctx, a.clusterView, 0, removedAddresses)
 		a.broadcastViewOnce(ctx)
 	}
+	a.resolveSplitBrain(ctx, now)
 	inQuorum := a.quorumCalc.SatisfiesQuorum(a.clusterView)
 	a.events.PublishLeaderIfChanged(ctx, a.clusterView, a.nodeState.Address, inQuorum)
 	a.metricsUpdater.Update(ctx, a.clusterView)
@@ -507,6 +702,175 @@ func (a *NodeActor) runFailureDetection(ctx vivid.ActorContext) {
 	}
 }
 
+// filterByIndirectProbe 在标记 Suspect（或无确认时长时直接移除）前先发起间接探测：
+// 首次超时的 Up 成员本轮保留，由 K 个协助节点代为探测，仅当探测超时仍无回复时才放行；已是 Suspect 的成员不再重复探测。
+func (a *NodeActor) filterByIndirectProbe(ctx vivid.ActorContext, toSuspect, toRemove []string, now time.Time) ([]string, []string) {
+	if !a.indirectProber.Enabled() {
+		return toSuspect, toRemove
+	}
+	candidates := append([]string(nil), toSuspect...)
+	for _, id := range toRemove {
+		if m := a.clusterView.Members[id]; m != nil && m.Status.IsAvailable() {
+			candidates = append(candidates, id)
+		}
+	}
+	probed := make(map[string]bool, len(candidates))
+	for _, id := range candidates {
+		probed[id] = true
+	}
+	confirmed, toProbe := a.indirectProber.Filter(candidates, now)
+	allowed := make(map[string]bool, len(confirmed))
+	for _, id := range confirmed {
+		allowed[id] = true
+	}
+	for _, id := range toProbe {
+		m := a.clusterView.Members[id]
+		if m == nil {
+			continue
+		}
+		helpers := a.gossipSelector.SelectIndirectProbers(a.clusterView, a.nodeState, m.Address, a.indirectProber.Count())
+		if len(helpers) == 0 {
+			allowed[id] = true // 无可用协助节点，退化为直接判定
+			continue
+		}
+		req := &IndirectProbeRequest{ProbeID: a.indirectProber.Start(id, now), Target: m.Address}
+		for _, addr := range helpers {
+			ref, err := ctx.System().CreateRef(addr, "/@cluster")
+			if err != nil {
+				continue
+			}
+			ctx.Tell(ref, req)
+		}
+		ctx.Logger().Debug("member timed out, probing indirectly",
+			log.String("nodeId", id), log.String("address", m.Address), log.Any("helpers", helpers))
+	}
+	keep := func(ids []string) []string {
+		out := ids[:0]
+		for _, id := range ids {
+			if !probed[id] || allowed[id] {
+				out = append(out, id)
+			}
+		}
+		return out
+	}
+	toSuspect, toRemove = keep(toSuspect), keep(toRemove)
+	return toSuspect, toRemove
+}
+
+// handleIndirectProbeRequest 作为协助节点代发起方探测目标，ProbeAck 回到本节点后再转发给发起方。
+func (a *NodeActor) handleIndirectProbeRequest(ctx vivid.ActorContext, m *IndirectProbeRequest) {
+	sender := ctx.Sender()
+	if m == nil || m.Target == "" || sender == nil {
+		return
+	}
+	ref, err := ctx.System().CreateRef(m.Target, "/@cluster")
+	if err != nil {
+		return
+	}
+	ctx.Tell(ref, &ProbeRequest{ProbeID: m.ProbeID, Origin: sender.GetAddress()})
+}
+
+func (a *NodeActor) handleProbeRequest(ctx vivid.ActorContext, m *ProbeRequest) {
+	if m == nil || ctx.Sender() == nil {
+		return
+	}
+	ctx.Tell(ctx.Sender(), &ProbeAck{ProbeID: m.ProbeID, Origin: m.Origin})
+}
+
+func (a *NodeActor) handleProbeAck(ctx vivid.ActorContext, m *ProbeAck) {
+	if m == nil || m.Origin == "" {
+		return
+	}
+	ref, err := ctx.System().CreateRef(m.Origin, "/@cluster")
+	if err != nil {
+		return
+	}
+	ctx.Tell(ref, &IndirectProbeAck{ProbeID: m.ProbeID})
+}
+
+// recoverSuspect 在成员恢复可达时解除 Suspect，还原其被怀疑前的状态，使 WeaklyUp 成员仍须经 Leader 在收敛后晋升。
+// 前状态未知（经 Gossip 获知的 Suspect）时，启用收敛门控则还原为 WeaklyUp 等待晋升，否则还原为 Up。
+func (a *NodeActor) recoverSuspect(member *NodeState) {
+	if member.Status != MemberStatusSuspect {
+		return
+	}
+	prev, ok := a.suspectedFrom[member.ID]
+	if !ok {
+		prev = MemberStatusUp
+		if a.memberPromoter.Enabled() {
+			prev = MemberStatusWeaklyUp
+		}
+	}
+	delete(a.suspectedFrom, member.ID)
+	member.Status = prev
+}
+
+// handleIndirectProbeAck 间接探测成功：目标仍存活，刷新 LastSeen 以免本轮被误判。
+func (a *NodeActor) handleIndirectProbeAck(ctx vivid.ActorContext, m *IndirectProbeAck) {
+	if m == nil || a.clusterView == nil {
+		return
+	}
+	id, ok := a.indirectProber.Ack(m.ProbeID)
+	if !ok {
+		return
+	}
+	if member := a.clusterView.Members[id]; member != nil {
+		member.LastSeen = time.Now().UnixNano()
+		a.recoverSuspect(member)
+		ctx.Logger().Debug("member reachable via indirect probe", log.String("nodeId", id), log.String("address", member.Address))
+	}
+}
+
+// resolveSplitBrain 运行脑裂处理器：本侧保留时剔除不可达成员；本侧下线或不稳定保护触发时发布事件并通过 Leave 退出集群。
+func (a *NodeActor) resolveSplitBrain(ctx vivid.ActorContext, now time.Time) {
+	if !a.splitBrainResolver.Enabled() || a.nodeState.Status != MemberStatusUp {
+		return
+	}
+	result := a.splitBrainResolver.Observe(a.clusterView, a.nodeState.ID, now)
+	if result.Decision == SplitBrainDecisionNone {
+		return
+	}
+	a.splitBrainResolver.Reset()
+	reachable, unreachable := memberAddressesOf(result.Reachable), memberAddressesOf(result.Unreachable)
+	a.publishSplitBrainResolved(ctx, result.Decision, reachable, unreachable)
+
+	if result.Decision == SplitBrainDecisionDownUnreachable {
+		for _, m := range result.Unreachable {
+			a.clusterView.RemoveMember(m.ID)
+			a.incrementLocalVersion()
+		}
+		ctx.Logger().Debug("split brain resolved, downing unreachable members",
+			log.String("strategy", a.options.SplitBrainStrategy.String()),
+			log.Any("unreachable", unreachable))
+		a.events.PublishMembersChanged(ctx, a.clusterView, 0, unreachable)
+		a.events.PublishViewChanged(ctx, a.clusterView, 0, unreachable)
+		a.broadcastViewOnce(ctx)
+		return
+	}
+
+	ctx.Logger().Warn("split brain resolved, downing self",
+		log.String("strategy", a.options.SplitBrainStrategy.String()),
+		log.Bool("downAll", result.Decision == SplitBrainDecisionDownAll),
+		log.Any("reachable", reachable),
+		log.Any("unreachable", unreachable))
+	ctx.TellSelf(&localLeaveRequest{})
+}
+
+func (a *NodeActor) publishSplitBrainResolved(ctx vivid.ActorContext, decision SplitBrainDecision, reachable, unreachable []string) {
+	es := ctx.EventStream()
+	if es == nil {
+		return
+	}
+	es.Publish(ctx, ves.ClusterSplitBrainResolvedEvent{
+		NodeRef:     ctx.Ref(),
+		Strategy:    a.options.SplitBrainStrategy,
+		DownedSelf:  decision == SplitBrainDecisionDownSelf || decision == SplitBrainDecisionDownAll,
+		DownedAll:   decision == SplitBrainDecisionDownAll,
+		Reachable:   reachable,
+		Unreachable: unreachable,
+	})
+}
+
 func (a *NodeActor) tryQuorumRecovery(ctx vivid.ActorContext) {
 	if a.clusterView == nil {
 		return
@@ -563,6 +927,10 @@ func (a *NodeActor) handleLeaveRequest(ctx vivid.ActorContext) {
 		if sender != nil {
 			ctx.Tell(sender, &LeaveAck{})
 		}
+		if a.nodeState.Status == MemberStatusExiting {
+			// 已由远程 LeaveAddress 等触发退出时，再次通知本地 Leave 等待方
+			a.publishLeaveCompleted(ctx)
+		}
 		return
 	}
 	a.leaveCoordinator.SetReplyTo(sender)
@@ -588,6 +956,16 @@ func (a *NodeActor) publishLeaveCompleted(ctx vivid.ActorContext) {
 	es.Publish(ctx, ves.ClusterLeaveCompletedEvent{NodeRef: ctx.Ref()})
 }
 
+// lastVersionVectorOf 返回目标地址上次发来的视图版本（即其已确认的版本），未知时 known 为 false。
+func (a *NodeActor) lastVersionVectorOf(addr string) (VersionVector, bool) {
+	norm, ok := utils.NormalizeAddress(addr)
+	if !ok {
+		return VersionVector{}, false
+	}
+	vv, ok := a.lastVersionVectorByAddr[norm]
+	return vv, ok
+}
+
 // shouldSendGossipTo 判断向 targetAddr 发送当前视图是否可能使对方发生变更。
 // 若已知对方上次发来的视图版本且我方视图相对其为 Before 或 Equal，则对方合并后不会变更，返回 false 以跳过不必要的同步。
 func (a *NodeActor) shouldSendGossipTo(ourVersion VersionVector, targetAddr string) bool {
@@ -603,7 +981,7 @@ func (a *NodeActor) shouldSendGossipTo(ourVersion VersionVector, targetAddr stri
 	return order != VersionBefore && !ourVersion.Equal(theirs)
 }
 
-// pruneLastVersionVectors 仅保留当前视图成员与种子地址的版本记录，避免 map 无限增长。
+// pruneLastVersionVectors 仅保留当前视图成员与种子地址的版本记录与增量发送记录，避免 map 无限增长。
 func (a *NodeActor) pruneLastVersionVectors() {
 	allowed := make(map[string]bool)
 	if a.clusterView != nil && a.clusterView.Members != nil {
@@ -626,39 +1004,10 @@ func (a *NodeActor) pruneLastVersionVectors() {
 			delete(a.lastVersionVectorByAddr, k)
 		}
 	}
+	a.deltaGossip.Prune(allowed)
 }
 
 func (a *NodeActor) broadcastViewOnce(ctx vivid.ActorContext) {
 	a.pruneLastVersionVectors()
-	snap := a.clusterView.Snapshot()
-	if snap == nil {
-		return
-	}
-	msg := &GossipMessage{View: snap}
-	for _, addr := range a.gossipSelector.SelectTargets(a.clusterView, a.nodeState) {
-		if !a.gossipRateLimiter.Allow() {
-			break
-		}
-		if !a.shouldSendGossipTo(snap.VersionVector, addr) {
-			continue
-		}
-		ref, err := ctx.System().CreateRef(addr, "/@cluster")
-		if err != nil {
-			continue
-		}
-		ctx.Tell(ref, msg)
-	}
-}
-
-func (a *NodeActor) memberAddresses() []string {
-	if a.clusterView == nil || a.clusterView.Members == nil {
-		return nil
-	}
-	out := make([]string, 0, len(a.clusterView.Members))
-	for _, m := range a.clusterView.Members {
-		if m != nil && m.Address != "" {
-			out = append(out, m.Address)
-		}
-	}
-	return out
+	a.runGossipRoundWithTargets(ctx, a.gossipSelector.SelectTargets(a.clusterView, a.nodeState))
 }
@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"maps"
 	"testing"
 	"time"
 
@@ -24,6 +25,57 @@ func testClusterOptions(opts ...vivid.ClusterOption) *vivid.ClusterOptions {
 	return vivid.NewClusterOptions(append(base, opts...)...)
 }
 
+// testMemberGroup 描述测试视图中状态与标签相同的一组成员。
+type testMemberGroup struct {
+	status MemberStatus
+	addrs  []string
+	labels map[string]string
+}
+
+// testMembers 以给定状态声明一组测试成员，地址同时作为 NodeID。
+func testMembers(status MemberStatus, addrs ...string) testMemberGroup {
+	return testMemberGroup{status: status, addrs: addrs}
+}
+
+// withLabel 为该组成员附加标签。
+func (g testMemberGroup) withLabel(key, value string) testMemberGroup {
+	g.labels = maps.Clone(g.labels)
+	if g.labels == nil {
+		g.labels = make(map[string]string)
+	}
+	g.labels[key] = value
+	return g
+}
+
+// newTestView 按组构建单测用视图：成员按声明顺序依次启动（Timestamp 递增），并由首个成员递增一次版本。
+func newTestView(groups ...testMemberGroup) *ClusterView {
+	v := newClusterView()
+	base := time.Now().UnixNano()
+	for _, g := range groups {
+		for _, addr := range g.addrs {
+			n := newNodeState(addr, "c1", addr)
+			n.Timestamp = base + int64(len(v.Members))
+			n.Status = g.status
+			maps.Copy(n.Labels, g.labels)
+			v.AddMember(n)
+		}
+	}
+	if len(groups) > 0 && len(groups[0].addrs) > 0 {
+		v.IncrementVersion(groups[0].addrs[0])
+	}
+	return v
+}
+
+// assertDisabledByDefault 断言可选组件在默认单测选项下未启用；check 非 nil 时进一步断言其禁用时的行为。
+func assertDisabledByDefault[T interface{ Enabled() bool }](t *testing.T, newComponent func(vivid.ClusterOptions) T, check func(T)) {
+	t.Helper()
+	c := newComponent(*testClusterOptions())
+	assert.False(t, c.Enabled())
+	if check != nil {
+		check(c)
+	}
+}
+
 func TestTestClusterOptions_ShortIntervalsAndTimeouts(t *testing.T) {
 	deadline := time.Now().Add(testCaseTimeout)
 	if d, ok := t.Deadline(); ok && d.Before(deadline) {
@@ -3,11 +3,13 @@ package cluster
 import "time"
 
 // 多数据中心 / 拓扑标签键，用于 NodeState.Labels，供 Gossip 与故障检测区分同 DC / 跨 DC；Region/Zone 用于全球多层级拓扑。
+// LabelAppVersion 为应用版本标签，随 Gossip 扩散，用于滚动升级时的选主偏好与版本收敛事件。
 const (
 	LabelDatacenter = "datacenter"
 	LabelRack       = "rack"
 	LabelRegion     = "region"
 	LabelZone       = "zone"
+	LabelAppVersion = "app-version"
 )
 
 type MemberStatus int
@@ -21,6 +23,9 @@ const (
 	MemberStatusLeaving
 	MemberStatusExiting
 	MemberStatusRemoved
+	// MemberStatusWeaklyUp 加入后在限定时间内视图仍未收敛时由 Leader 赋予：可用于路由，但不参与选主与法定人数，收敛后晋升为 Up。
+	// 追加在末尾以保持既有状态值的线上编码不变。
+	MemberStatusWeaklyUp
 )
 
 func (s MemberStatus) String() string {
@@ -41,11 +46,18 @@ func (s MemberStatus) String() string {
 		return "exiting"
 	case MemberStatusRemoved:
 		return "removed"
+	case MemberStatusWeaklyUp:
+		return "weakly-up"
 	default:
 		return "unknown"
 	}
 }
 
+// IsAvailable 返回该状态的成员是否可用于路由（Up 或 WeaklyUp）。
+func (s MemberStatus) IsAvailable() bool {
+	return s == MemberStatusUp || s == MemberStatusWeaklyUp
+}
+
 func newNodeState(id string, clusterName string, address string) *NodeState {
 	now := time.Now().UnixNano()
 	return &NodeState{
@@ -97,6 +109,14 @@ func (n *NodeState) Zone() string {
 	return n.Labels[LabelZone]
 }
 
+// AppVersion 返回节点的应用版本（来自 Labels[LabelAppVersion]），空表示未配置。
+func (n *NodeState) AppVersion() string {
+	if n == nil || n.Labels == nil {
+		return ""
+	}
+	return n.Labels[LabelAppVersion]
+}
+
 // NodeState 表示集群中某一节点的状态，用于 Gossip 与故障检测。
 // 节点在视图中的因果版本由 ClusterView.VersionVector 维护，GetMembers 等从 VersionVector.Get(nodeID) 获取。
 // Generation 在节点重启后递增，用于区分同一节点的不同 incarnation，避免脑裂时采纳旧实例。
@@ -139,6 +159,18 @@ func (n *NodeState) Clone() *NodeState {
 	return &out
 }
 
+// touch 在由其他节点（如 Leader 晋升）修改本成员状态后推进其时钟，使变更在合并时被各节点采纳。
+func (n *NodeState) touch(now time.Time) {
+	if n.LogicalClock != 0 {
+		n.LogicalClock++
+	}
+	if ts := now.UnixNano(); ts > n.Timestamp {
+		n.Timestamp = ts
+	} else {
+		n.Timestamp++
+	}
+}
+
 // IsNewerThan 按分代、逻辑时钟与时间戳判断是否比 other 更新（用于合并时采纳新状态、避免脑裂采纳旧实例）。
 // 先比较 Generation；同分代且为同一节点时若双方均有 LogicalClock 则比较 LogicalClock；否则比较 Timestamp。
 func (n *NodeState) IsNewerThan(other *NodeState) bool {
@@ -109,15 +109,24 @@ func (q *QuorumCalculator) SatisfiesQuorum(v *ClusterView) bool {
 }
 
 // ComputeLeaderAddr 按当前视图做确定性选主：取状态为 Up 的成员按 Address 排序后的首个地址。
-func ComputeLeaderAddr(v *ClusterView) string {
+// preferNewestAppVersion 为 true 时仅在应用版本最新的 Up 成员中选取，滚动升级期间 Leader 与单例随之迁移到新版本节点。
+func ComputeLeaderAddr(v *ClusterView, preferNewestAppVersion bool) string {
 	if v == nil || len(v.Members) == 0 {
 		return ""
 	}
+	var newest string
+	if preferNewestAppVersion {
+		newest = newestAppVersion(v)
+	}
 	var addresses []string
 	for _, m := range v.Members {
-		if m != nil && m.Status == MemberStatusUp && m.Address != "" {
-			addresses = append(addresses, m.Address)
+		if m == nil || m.Status != MemberStatusUp || m.Address == "" {
+			continue
+		}
+		if preferNewestAppVersion && CompareAppVersion(m.AppVersion(), newest) != 0 {
+			continue
 		}
+		addresses = append(addresses, m.Address)
 	}
 	if len(addresses) == 0 {
 		return ""
@@ -0,0 +1,195 @@
+package cluster
+
+import (
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/pkg/log"
+)
+
+// ReceptionistActorName 集群客户端接待者在根下的 Actor 名称，每个集群节点均会启动。
+const ReceptionistActorName = "@cluster-receptionist"
+
+var _ vivid.Actor = (*receptionist)(nil)
+
+// RegisterServiceRequest 向接待者注册可供集群客户端访问的服务，以 Ref 的路径为服务路径，仅内部使用。
+type RegisterServiceRequest struct {
+	Ref vivid.ActorRef
+}
+
+// UnregisterServiceRequest 从接待者注销服务，仅内部使用。
+type UnregisterServiceRequest struct {
+	Ref vivid.ActorRef
+}
+
+// NewReceptionist 创建集群客户端接待者 Actor。
+// 接待者接收集群外部客户端的 ClusterClientRequest，转发到本节点已注册的服务或集群单例，
+// 并以 *ClusterClientResponse 应答客户端：需要回复时携带目标的回复或错误，否则在投递后立即确认。
+// 已注册服务终止时自动注销；集群单例代理以 PipeTo 异步获取并缓存，不阻塞接待者。
+func NewReceptionist() vivid.Actor {
+	return &receptionist{
+		services:   make(map[string]vivid.ActorRef),
+		singletons: make(map[string]vivid.ActorRef),
+		pending:    make(map[string]vivid.ActorRef),
+		resolving:  make(map[string]*resolvingRequest),
+	}
+}
+
+type receptionist struct {
+	services   map[string]vivid.ActorRef    // key 为服务路径
+	singletons map[string]vivid.ActorRef    // key 为单例名称，value 为已获取的单例代理
+	pending    map[string]vivid.ActorRef    // key 为 PipeTo 管道 ID，value 为等待回复的客户端
+	resolving  map[string]*resolvingRequest // key 为获取单例代理的 PipeTo 管道 ID
+}
+
+// resolvingRequest 等待单例代理就绪的客户端请求。
+type resolvingRequest struct {
+	client  vivid.ActorRef
+	request *ClusterClientRequest
+}
+
+func (r *receptionist) OnReceive(ctx vivid.ActorContext) {
+	switch m := ctx.Message().(type) {
+	case *RegisterServiceRequest:
+		r.onRegister(ctx, m.Ref)
+	case *UnregisterServiceRequest:
+		r.onUnregister(ctx, m.Ref)
+	case *vivid.OnKilled:
+		r.onServiceKilled(m.Ref)
+	case *ClusterClientRequest:
+		r.onClientRequest(ctx, m)
+	case *vivid.PipeResult:
+		r.onPipeResult(ctx, m)
+	}
+}
+
+func (r *receptionist) onRegister(ctx vivid.ActorContext, ref vivid.ActorRef) {
+	if ref == nil {
+		ctx.Reply(vivid.ErrorRefEmpty)
+		return
+	}
+	r.services[ref.GetPath()] = ref
+	ctx.Watch(ref)
+	ctx.Reply(ref)
+	ctx.Logger().Debug("cluster receptionist: service registered", log.String("path", ref.GetPath()))
+}
+
+func (r *receptionist) onUnregister(ctx vivid.ActorContext, ref vivid.ActorRef) {
+	if ref == nil {
+		ctx.Reply(vivid.ErrorRefEmpty)
+		return
+	}
+	if registered, ok := r.services[ref.GetPath()]; ok {
+		delete(r.services, ref.GetPath())
+		ctx.Unwatch(registered)
+	}
+	ctx.Reply(ref)
+}
+
+func (r *receptionist) onServiceKilled(ref vivid.ActorRef) {
+	if ref == nil {
+		return
+	}
+	if registered, ok := r.services[ref.GetPath()]; ok && registered.Equals(ref) {
+		delete(r.services, ref.GetPath())
+	}
+	for name, proxy := range r.singletons {
+		if proxy.Equals(ref) {
+			delete(r.singletons, name)
+		}
+	}
+}
+
+func (r *receptionist) onClientRequest(ctx vivid.ActorContext, m *ClusterClientRequest) {
+	if m.Singleton != "" {
+		r.resolveSingleton(ctx, m)
+		return
+	}
+	target, ok := r.services[m.Path]
+	if !ok {
+		// 未注册时返回 ErrorClusterServiceNotRegistered，客户端据此改由下一个联系点尝试
+		ctx.Reply(&ClusterClientResponse{Error: vivid.ErrorClusterServiceNotRegistered.WithMessage(m.Path)})
+		return
+	}
+	r.forward(ctx, ctx.Sender(), m, target)
+}
+
+// resolveSingleton 取集群单例代理作为请求目标：已缓存时直接转发，否则向代理管理者异步获取，结果由 onPipeResult 继续处理。
+func (r *receptionist) resolveSingleton(ctx vivid.ActorContext, m *ClusterClientRequest) {
+	if proxy, ok := r.singletons[m.Singleton]; ok {
+		r.forward(ctx, ctx.Sender(), m, proxy)
+		return
+	}
+	cluster, ok := ctx.Cluster().(*Context)
+	if !ok {
+		ctx.Reply(&ClusterClientResponse{Error: vivid.ErrorClusterDisabled})
+		return
+	}
+	proxyManager, request, err := cluster.singletonProxyRequest(m.Singleton)
+	if err != nil {
+		ctx.Reply(&ClusterClientResponse{Error: err})
+		return
+	}
+	pipeID := ctx.PipeTo(proxyManager, request, vivid.ActorRefs{ctx.Ref()}, getViewTimeout)
+	r.resolving[pipeID] = &resolvingRequest{client: ctx.Sender(), request: m}
+}
+
+// forward 将请求投递到 target：无需回复时投递后立即确认，否则以 PipeTo 等待目标回复后再应答 client。
+func (r *receptionist) forward(ctx vivid.ActorContext, client vivid.ActorRef, m *ClusterClientRequest, target vivid.ActorRef) {
+	if !m.Reply {
+		ctx.Tell(target, m.Message)
+		if client != nil {
+			ctx.Tell(client, &ClusterClientResponse{})
+		}
+		return
+	}
+	var pipeID string
+	if m.Timeout > 0 {
+		pipeID = ctx.PipeTo(target, m.Message, vivid.ActorRefs{ctx.Ref()}, m.Timeout)
+	} else {
+		pipeID = ctx.PipeTo(target, m.Message, vivid.ActorRefs{ctx.Ref()})
+	}
+	r.pending[pipeID] = client
+}
+
+func (r *receptionist) onPipeResult(ctx vivid.ActorContext, m *vivid.PipeResult) {
+	if resolving, ok := r.resolving[m.Id]; ok {
+		delete(r.resolving, m.Id)
+		r.onSingletonResolved(ctx, resolving, m)
+		return
+	}
+	client, ok := r.pending[m.Id]
+	if !ok {
+		return
+	}
+	delete(r.pending, m.Id)
+	if client != nil {
+		ctx.Tell(client, &ClusterClientResponse{Message: m.Message, Error: m.Error})
+	}
+}
+
+// onSingletonResolved 处理单例代理的获取结果：成功时缓存并监视代理后转发请求，失败时以错误应答客户端。
+func (r *receptionist) onSingletonResolved(ctx vivid.ActorContext, resolving *resolvingRequest, m *vivid.PipeResult) {
+	err := m.Error
+	var proxy vivid.ActorRef
+	if err == nil {
+		resp, ok := m.Message.(*GetOrCreateProxyResponse)
+		switch {
+		case !ok || resp == nil:
+			err = vivid.ErrorIllegalArgument
+		case resp.Err != nil:
+			err = resp.Err
+		default:
+			proxy = resp.Ref
+		}
+	}
+	if err != nil {
+		if resolving.client != nil {
+			ctx.Tell(resolving.client, &ClusterClientResponse{Error: err})
+		}
+		return
+	}
+	if _, ok := r.singletons[resolving.request.Singleton]; !ok {
+		r.singletons[resolving.request.Singleton] = proxy
+		ctx.Watch(proxy)
+	}
+	r.forward(ctx, resolving.client, resolving.request, proxy)
+}
@@ -4,10 +4,12 @@
 package cluster
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"time"
 
+	"github.com/kercylan98/vivid"
 	"github.com/kercylan98/vivid/internal/messages"
 )
 
@@ -18,6 +20,8 @@ func init() {
 		"clusterJoinResponse", clusterJoinResponseReader, clusterJoinResponseWriter)
 	messages.RegisterInternalMessage[*GossipMessage](
 		"clusterGossip", clusterGossipReader, clusterGossipWriter)
+	messages.RegisterInternalMessage[*GossipDeltaMessage](
+		"clusterGossipDelta", clusterGossipDeltaReader, clusterGossipDeltaWriter)
 	messages.RegisterInternalMessage[*GossipTick](
 		"clusterGossipTick", clusterNoopReader, clusterNoopWriter)
 	messages.RegisterInternalMessage[*GossipCrossDCTick](
@@ -29,7 +33,13 @@ func init() {
 	messages.RegisterInternalMessage[*GetViewResponse](
 		"clusterGetViewResponse", clusterGetViewResponseReader, clusterGetViewResponseWriter)
 	messages.RegisterInternalMessage[*LeaveRequest](
-		"clusterLeaveRequest", clusterNoopReader, clusterNoopWriter)
+		"clusterLeaveRequest", clusterLeaveRequestReader, clusterLeaveRequestWriter)
+	messages.RegisterInternalMessage[*ClusterClientRequest](
+		"clusterClientRequest", clusterClientRequestReader, clusterClientRequestWriter)
+	messages.RegisterInternalMessage[*ClusterClientResponse](
+		"clusterClientResponse", clusterClientResponseReader, clusterClientResponseWriter)
+	messages.RegisterInternalMessage[*ServiceRegistryUpdate](
+		"clusterServiceRegistryUpdate", clusterServiceRegistryUpdateReader, clusterServiceRegistryUpdateWriter)
 	messages.RegisterInternalMessage[*LeaveAck](
 		"clusterLeaveAck", clusterNoopReader, clusterNoopWriter)
 	messages.RegisterInternalMessage[*ExitingReady](
@@ -42,6 +52,14 @@ func init() {
 		"clusterForceMemberDown", clusterForceMemberDownReader, clusterForceMemberDownWriter)
 	messages.RegisterInternalMessage[*TriggerViewBroadcast](
 		"clusterTriggerViewBroadcast", clusterTriggerViewBroadcastReader, clusterTriggerViewBroadcastWriter)
+	messages.RegisterInternalMessage[*IndirectProbeRequest](
+		"clusterIndirectProbeRequest", clusterIndirectProbeRequestReader, clusterIndirectProbeRequestWriter)
+	messages.RegisterInternalMessage[*ProbeRequest](
+		"clusterProbeRequest", clusterProbeRequestReader, clusterProbeRequestWriter)
+	messages.RegisterInternalMessage[*ProbeAck](
+		"clusterProbeAck", clusterProbeAckReader, clusterProbeAckWriter)
+	messages.RegisterInternalMessage[*IndirectProbeAck](
+		"clusterIndirectProbeAck", clusterIndirectProbeAckReader, clusterIndirectProbeAckWriter)
 	messages.RegisterInternalMessage[*singletonForwardedMessage](
 		"clusterSingletonForwardedMessage", clusterSingletonForwardedMessageReader, clusterSingletonForwardedMessageWriter)
 }
@@ -301,6 +319,31 @@ func clusterGossipWriter(message any, writer *messages.Writer, codec messages.Co
 	return writeClusterView(writer, m.View)
 }
 
+func clusterGossipDeltaReader(message any, reader *messages.Reader, codec messages.Codec) error {
+	m := message.(*GossipDeltaMessage)
+	view, err := readClusterView(reader)
+	if err != nil {
+		return err
+	}
+	m.View = view
+	return nil
+}
+
+func clusterGossipDeltaWriter(message any, writer *messages.Writer, codec messages.Codec) error {
+	m := message.(*GossipDeltaMessage)
+	return writeClusterView(writer, m.View)
+}
+
+// encodedViewSize 返回视图按 Gossip 二进制格式编码后的字节数，用于增量 Gossip 节省量指标。
+func encodedViewSize(v *ClusterView) int {
+	w := messages.NewWriterFromPool()
+	defer messages.ReleaseWriterToPool(w)
+	if err := writeClusterView(w, v); err != nil {
+		return 0
+	}
+	return w.Len()
+}
+
 func clusterGetViewResponseReader(message any, reader *messages.Reader, codec messages.Codec) error {
 	m := message.(*GetViewResponse)
 	v, err := readClusterView(reader)
@@ -355,6 +398,95 @@ func clusterJoinRetryTickWriter(message any, writer *messages.Writer, codec mess
 	return writer.WriteFrom(int64(m.NextDelay))
 }
 
+// clusterLeaveRequestReader 兼容旧版本节点发送的空载荷，此时视为未携带令牌。
+func clusterLeaveRequestReader(message any, reader *messages.Reader, codec messages.Codec) error {
+	m := message.(*LeaveRequest)
+	if reader.RemainingSize() == 0 {
+		return nil
+	}
+	return reader.ReadInto(&m.AdminToken)
+}
+
+func clusterLeaveRequestWriter(message any, writer *messages.Writer, codec messages.Codec) error {
+	m := message.(*LeaveRequest)
+	return writer.WriteFrom(m.AdminToken)
+}
+
+func clusterClientRequestReader(message any, reader *messages.Reader, codec messages.Codec) error {
+	m := message.(*ClusterClientRequest)
+	var nano int64
+	if err := reader.ReadInto(&m.Path, &m.Singleton, &m.Reply, &nano); err != nil {
+		return err
+	}
+	m.Timeout = time.Duration(nano)
+	msg, err := reader.ReadMessage(codec)
+	if err != nil {
+		return err
+	}
+	m.Message = msg
+	return nil
+}
+
+func clusterClientRequestWriter(message any, writer *messages.Writer, codec messages.Codec) error {
+	m := message.(*ClusterClientRequest)
+	if err := writer.WriteFrom(m.Path, m.Singleton, m.Reply, int64(m.Timeout)); err != nil {
+		return err
+	}
+	return writer.WriteMessage(m.Message, codec)
+}
+
+// clusterClientResponseReader 按「是否有回复、回复、是否有错误、错误」顺序读取，回复与错误均可为空。
+func clusterClientResponseReader(message any, reader *messages.Reader, codec messages.Codec) error {
+	m := message.(*ClusterClientResponse)
+	var hasMessage, hasError bool
+	if err := reader.ReadInto(&hasMessage); err != nil {
+		return err
+	}
+	if hasMessage {
+		msg, err := reader.ReadMessage(codec)
+		if err != nil {
+			return err
+		}
+		m.Message = msg
+	}
+	if err := reader.ReadInto(&hasError); err != nil {
+		return err
+	}
+	if hasError {
+		msg, err := reader.ReadMessage(codec)
+		if err != nil {
+			return err
+		}
+		if e, ok := msg.(error); ok {
+			m.Error = e
+		}
+	}
+	return nil
+}
+
+func clusterClientResponseWriter(message any, writer *messages.Writer, codec messages.Codec) error {
+	m := message.(*ClusterClientResponse)
+	if err := writer.WriteFrom(m.Message != nil); err != nil {
+		return err
+	}
+	if m.Message != nil {
+		if err := writer.WriteMessage(m.Message, codec); err != nil {
+			return err
+		}
+	}
+	if err := writer.WriteFrom(m.Error != nil); err != nil {
+		return err
+	}
+	if m.Error == nil {
+		return nil
+	}
+	var e *vivid.Error
+	if !errors.As(m.Error, &e) {
+		e = vivid.ErrorException.With(m.Error)
+	}
+	return writer.WriteMessage(e, codec)
+}
+
 func clusterForceMemberDownReader(message any, reader *messages.Reader, codec messages.Codec) error {
 	m := message.(*ForceMemberDown)
 	return reader.ReadInto(&m.NodeID, &m.AdminToken)
@@ -375,6 +507,46 @@ func clusterTriggerViewBroadcastWriter(message any, writer *messages.Writer, cod
 	return writer.WriteFrom(m.AdminToken)
 }
 
+func clusterIndirectProbeRequestReader(message any, reader *messages.Reader, codec messages.Codec) error {
+	m := message.(*IndirectProbeRequest)
+	return reader.ReadInto(&m.ProbeID, &m.Target)
+}
+
+func clusterIndirectProbeRequestWriter(message any, writer *messages.Writer, codec messages.Codec) error {
+	m := message.(*IndirectProbeRequest)
+	return writer.WriteFrom(m.ProbeID, m.Target)
+}
+
+func clusterProbeRequestReader(message any, reader *messages.Reader, codec messages.Codec) error {
+	m := message.(*ProbeRequest)
+	return reader.ReadInto(&m.ProbeID, &m.Origin)
+}
+
+func clusterProbeRequestWriter(message any, writer *messages.Writer, codec messages.Codec) error {
+	m := message.(*ProbeRequest)
+	return writer.WriteFrom(m.ProbeID, m.Origin)
+}
+
+func clusterProbeAckReader(message any, reader *messages.Reader, codec messages.Codec) error {
+	m := message.(*ProbeAck)
+	return reader.ReadInto(&m.ProbeID, &m.Origin)
+}
+
+func clusterProbeAckWriter(message any, writer *messages.Writer, codec messages.Codec) error {
+	m := message.(*ProbeAck)
+	return writer.WriteFrom(m.ProbeID, m.Origin)
+}
+
+func clusterIndirectProbeAckReader(message any, reader *messages.Reader, codec messages.Codec) error {
+	m := message.(*IndirectProbeAck)
+	return reader.ReadInto(&m.ProbeID)
+}
+
+func clusterIndirectProbeAckWriter(message any, writer *messages.Writer, codec messages.Codec) error {
+	m := message.(*IndirectProbeAck)
+	return writer.WriteFrom(m.ProbeID)
+}
+
 func clusterSingletonForwardedMessageReader(message any, reader *messages.Reader, codec messages.Codec) error {
 	m := message.(*singletonForwardedMessage)
 	if err := reader.ReadInto(&m.senderAddr, &m.senderPath); err != nil {
@@ -399,3 +571,13 @@ func clusterSingletonForwardedMessageWriter(message any, writer *messages.Writer
 	}
 	return writer.WriteMessage(m.message, codec)
 }
+
+func clusterServiceRegistryUpdateReader(message any, reader *messages.Reader, codec messages.Codec) error {
+	m := message.(*ServiceRegistryUpdate)
+	return reader.ReadInto(&m.Address, &m.Version, &m.Keys, &m.Addresses, &m.Paths)
+}
+
+func clusterServiceRegistryUpdateWriter(message any, writer *messages.Writer, codec messages.Codec) error {
+	m := message.(*ServiceRegistryUpdate)
+	return writer.WriteFrom(m.Address, m.Version, m.Keys, m.Addresses, m.Paths)
+}
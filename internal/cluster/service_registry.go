@@ -0,0 +1,387 @@
+package cluster
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/pkg/log"
+	"github.com/kercylan98/vivid/pkg/ves"
+)
+
+// ServiceRegistryActorName 集群服务注册表在根下的 Actor 名称，每个集群节点均会启动。
+const ServiceRegistryActorName = "@cluster-service-registry"
+
+var _ vivid.Actor = (*serviceRegistry)(nil)
+
+// ServiceRegisterRequest 将本节点的 Ref 注册到服务键 Key 下，仅内部使用。
+type ServiceRegisterRequest struct {
+	Key string
+	Ref vivid.ActorRef
+}
+
+// ServiceDeregisterRequest 移除 Ref 在服务键 Key 下的注册，仅内部使用。
+type ServiceDeregisterRequest struct {
+	Key string
+	Ref vivid.ActorRef
+}
+
+// ServiceFindRequest 查询服务键 Key 的注册列表，应答为 *ServiceFindResponse，仅内部使用。
+type ServiceFindRequest struct {
+	Key string
+}
+
+// ServiceFindResponse ServiceFindRequest 的应答。
+type ServiceFindResponse struct {
+	Refs []vivid.ActorRef
+}
+
+// ServiceSubscribeRequest 订阅服务键 Key 的注册列表变化，仅内部使用。
+type ServiceSubscribeRequest struct {
+	Key        string
+	Subscriber vivid.ActorRef
+}
+
+// ServiceUnsubscribeRequest 取消订阅，仅内部使用。
+type ServiceUnsubscribeRequest struct {
+	Key        string
+	Subscriber vivid.ActorRef
+}
+
+// ServiceRegistrySyncTick 周期性向其他成员推送本节点注册快照的定时消息。
+type ServiceRegistrySyncTick struct{}
+
+// NewServiceRegistry 创建集群服务注册表 Actor。
+// 每个节点只维护本节点 Actor 的注册，变更时以带版本号的全量快照（*ServiceRegistryUpdate）推送给其他成员，
+// 并按 ServiceRegistrySyncInterval 周期重推以修复丢失的更新；收到的其他节点快照按版本号取新，
+// 节点从成员列表移除时丢弃其全部注册。仍处于 Joining 的成员上的注册保留但不参与查询，成员晋升为 WeaklyUp/Up 后即可被查询到。
+// 已注册的 Actor 与订阅者终止时自动清理。
+func NewServiceRegistry() vivid.Actor {
+	return &serviceRegistry{
+		local:       make(map[string]map[string]vivid.ActorRef),
+		remote:      make(map[string]*remoteServices),
+		members:     make(map[string]bool),
+		subscribers: make(map[string]map[string]vivid.ActorRef),
+		notified:    make(map[string]string),
+		version:     uint64(time.Now().UnixNano()),
+	}
+}
+
+// remoteServices 其他节点最近一次推送的注册快照。
+type remoteServices struct {
+	version  uint64
+	services map[string][]vivid.ActorRef // key 为服务键
+}
+
+type serviceRegistry struct {
+	local       map[string]map[string]vivid.ActorRef // 服务键 -> ref 字符串 -> 本节点注册的 ref
+	remote      map[string]*remoteServices           // 节点地址 -> 该节点的注册快照
+	members     map[string]bool                      // 当前成员地址（不含本节点）-> 是否已可用于路由（非 Joining）
+	subscribers map[string]map[string]vivid.ActorRef // 服务键 -> ref 字符串 -> 订阅者
+	notified    map[string]string                    // 服务键 -> 最近一次通知订阅者的列表签名
+	version     uint64                               // 本节点快照版本，以启动时间为初值保证重启后仍递增
+}
+
+func (r *serviceRegistry) OnReceive(ctx vivid.ActorContext) {
+	switch m := ctx.Message().(type) {
+	case *vivid.OnLaunch:
+		r.onLaunch(ctx)
+	case *ServiceRegisterRequest:
+		r.onRegister(ctx, m)
+	case *ServiceDeregisterRequest:
+		r.onDeregister(ctx, m)
+	case *ServiceFindRequest:
+		ctx.Reply(&ServiceFindResponse{Refs: r.listing(m.Key)})
+	case *ServiceSubscribeRequest:
+		r.onSubscribe(ctx, m)
+	case *ServiceUnsubscribeRequest:
+		r.onUnsubscribe(ctx, m)
+	case *vivid.OnKilled:
+		r.onKilled(ctx, m.Ref)
+	case ves.ClusterMembersChangedEvent:
+		r.onMembersChanged(ctx, m)
+	case *ServiceRegistryUpdate:
+		r.onUpdate(ctx, m)
+	case *ServiceRegistrySyncTick:
+		r.broadcast(ctx)
+	}
+}
+
+func (r *serviceRegistry) onLaunch(ctx vivid.ActorContext) {
+	ctx.EventStream().Subscribe(ctx, ves.ClusterMembersChangedEvent{})
+	if members, err := ctx.Cluster().GetMembers(); err == nil {
+		self := ctx.Ref().GetAddress()
+		for _, m := range members {
+			if m.Address != "" && m.Address != self {
+				r.members[m.Address] = m.Status != MemberStatusJoining.String()
+			}
+		}
+	}
+	_ = ctx.Scheduler().Loop(ctx.Ref(), ServiceRegistrySyncInterval, &ServiceRegistrySyncTick{},
+		vivid.WithSchedulerReference(SchedRefServiceRegistry))
+}
+
+func (r *serviceRegistry) onRegister(ctx vivid.ActorContext, m *ServiceRegisterRequest) {
+	if m.Ref == nil {
+		ctx.Reply(vivid.ErrorRefEmpty)
+		return
+	}
+	if m.Ref.GetAddress() != ctx.Ref().GetAddress() {
+		ctx.Reply(vivid.ErrorIllegalArgument.WithMessage("only local actors can be registered"))
+		return
+	}
+	refs := r.local[m.Key]
+	if refs == nil {
+		refs = make(map[string]vivid.ActorRef)
+		r.local[m.Key] = refs
+	}
+	if _, ok := refs[m.Ref.String()]; !ok {
+		refs[m.Ref.String()] = m.Ref
+		ctx.Watch(m.Ref)
+		r.localChanged(ctx, m.Key)
+		ctx.Logger().Debug("cluster service registry: registered", log.String("key", m.Key), log.String("ref", m.Ref.String()))
+	}
+	ctx.Reply(m.Ref)
+}
+
+func (r *serviceRegistry) onDeregister(ctx vivid.ActorContext, m *ServiceDeregisterRequest) {
+	if m.Ref == nil {
+		ctx.Reply(vivid.ErrorRefEmpty)
+		return
+	}
+	if r.removeLocal(m.Key, m.Ref) {
+		r.localChanged(ctx, m.Key)
+	}
+	ctx.Reply(m.Ref)
+}
+
+func (r *serviceRegistry) onSubscribe(ctx vivid.ActorContext, m *ServiceSubscribeRequest) {
+	if m.Subscriber == nil {
+		ctx.Reply(vivid.ErrorRefEmpty)
+		return
+	}
+	if m.Subscriber.GetAddress() != ctx.Ref().GetAddress() {
+		ctx.Reply(vivid.ErrorIllegalArgument.WithMessage("only local actors can subscribe"))
+		return
+	}
+	subs := r.subscribers[m.Key]
+	if subs == nil {
+		subs = make(map[string]vivid.ActorRef)
+		r.subscribers[m.Key] = subs
+	}
+	subs[m.Subscriber.String()] = m.Subscriber
+	ctx.Watch(m.Subscriber)
+	refs := r.listing(m.Key)
+	r.notified[m.Key] = listingSignature(refs)
+	ctx.Tell(m.Subscriber, ves.ClusterServiceListingEvent{Key: m.Key, Refs: refs})
+	ctx.Reply(m.Subscriber)
+}
+
+func (r *serviceRegistry) onUnsubscribe(ctx vivid.ActorContext, m *ServiceUnsubscribeRequest) {
+	if m.Subscriber == nil {
+		ctx.Reply(vivid.ErrorRefEmpty)
+		return
+	}
+	if subs := r.subscribers[m.Key]; subs != nil {
+		delete(subs, m.Subscriber.String())
+		if len(subs) == 0 {
+			delete(r.subscribers, m.Key)
+			delete(r.notified, m.Key)
+		}
+	}
+	ctx.Reply(m.Subscriber)
+}
+
+// onKilled 清理终止 Actor 的全部注册与订阅；同一 Actor 可能同时是服务与订阅者。
+func (r *serviceRegistry) onKilled(ctx vivid.ActorContext, ref vivid.ActorRef) {
+	if ref == nil {
+		return
+	}
+	var changed []string
+	for key := range r.local {
+		if r.removeLocal(key, ref) {
+			changed = append(changed, key)
+		}
+	}
+	for key, subs := range r.subscribers {
+		delete(subs, ref.String())
+		if len(subs) == 0 {
+			delete(r.subscribers, key)
+			delete(r.notified, key)
+		}
+	}
+	if len(changed) > 0 {
+		r.localChanged(ctx, changed...)
+	}
+}
+
+func (r *serviceRegistry) onMembersChanged(ctx vivid.ActorContext, ev ves.ClusterMembersChangedEvent) {
+	self := ctx.Ref().GetAddress()
+	current := make(map[string]bool, len(ev.Members))
+	var added []string
+	for _, addr := range ev.Members {
+		if addr == "" || addr == self {
+			continue
+		}
+		switch ev.Statuses[addr] {
+		case MemberStatusDown.String(), MemberStatusRemoved.String():
+			continue
+		}
+		current[addr] = ev.Statuses[addr] != MemberStatusJoining.String()
+		if _, ok := r.members[addr]; !ok {
+			added = append(added, addr)
+		}
+	}
+	previous := r.members
+	r.members = current
+
+	var changed []string
+	for addr, snapshot := range r.remote {
+		routable, ok := current[addr]
+		if ok && routable == previous[addr] {
+			continue
+		}
+		for key := range snapshot.services {
+			changed = append(changed, key)
+		}
+		if !ok {
+			delete(r.remote, addr)
+		}
+	}
+	r.notify(ctx, changed...)
+
+	if len(added) > 0 {
+		update := r.snapshot(self)
+		for _, addr := range added {
+			r.sendTo(ctx, addr, update)
+		}
+	}
+}
+
+func (r *serviceRegistry) onUpdate(ctx vivid.ActorContext, m *ServiceRegistryUpdate) {
+	if m.Address == "" || m.Address == ctx.Ref().GetAddress() {
+		return
+	}
+	if _, ok := r.members[m.Address]; !ok {
+		return
+	}
+	previous := r.remote[m.Address]
+	if previous != nil && m.Version <= previous.version {
+		return
+	}
+	services := make(map[string][]vivid.ActorRef)
+	for i := range m.Keys {
+		if i >= len(m.Addresses) || i >= len(m.Paths) {
+			break
+		}
+		ref, err := ctx.System().CreateRef(m.Addresses[i], m.Paths[i])
+		if err != nil {
+			continue
+		}
+		services[m.Keys[i]] = append(services[m.Keys[i]], ref)
+	}
+	r.remote[m.Address] = &remoteServices{version: m.Version, services: services}
+
+	changed := make([]string, 0, len(services))
+	for key := range services {
+		changed = append(changed, key)
+	}
+	if previous != nil {
+		for key := range previous.services {
+			changed = append(changed, key)
+		}
+	}
+	r.notify(ctx, changed...)
+}
+
+// localChanged 在本节点注册变化后递增版本、推送快照并通知 keys 的订阅者。
+func (r *serviceRegistry) localChanged(ctx vivid.ActorContext, keys ...string) {
+	r.version++
+	r.broadcast(ctx)
+	r.notify(ctx, keys...)
+}
+
+func (r *serviceRegistry) removeLocal(key string, ref vivid.ActorRef) bool {
+	refs := r.local[key]
+	if _, ok := refs[ref.String()]; !ok {
+		return false
+	}
+	delete(refs, ref.String())
+	if len(refs) == 0 {
+		delete(r.local, key)
+	}
+	return true
+}
+
+// broadcast 向所有其他成员推送本节点的注册快照。
+func (r *serviceRegistry) broadcast(ctx vivid.ActorContext) {
+	if len(r.members) == 0 {
+		return
+	}
+	update := r.snapshot(ctx.Ref().GetAddress())
+	for addr := range r.members {
+		r.sendTo(ctx, addr, update)
+	}
+}
+
+func (r *serviceRegistry) sendTo(ctx vivid.ActorContext, addr string, update *ServiceRegistryUpdate) {
+	ref, err := ctx.System().CreateRef(addr, "/"+ServiceRegistryActorName)
+	if err != nil {
+		return
+	}
+	ctx.Tell(ref, update)
+}
+
+func (r *serviceRegistry) snapshot(self string) *ServiceRegistryUpdate {
+	update := &ServiceRegistryUpdate{Address: self, Version: r.version}
+	for key, refs := range r.local {
+		for _, ref := range refs {
+			update.Keys = append(update.Keys, key)
+			update.Addresses = append(update.Addresses, ref.GetAddress())
+			update.Paths = append(update.Paths, ref.GetPath())
+		}
+	}
+	return update
+}
+
+// listing 返回 key 在全集群可路由的注册列表，按 ref 字符串排序；仍处于 Joining 的成员上的注册暂不返回。
+func (r *serviceRegistry) listing(key string) []vivid.ActorRef {
+	refs := make([]vivid.ActorRef, 0, len(r.local[key]))
+	for _, ref := range r.local[key] {
+		refs = append(refs, ref)
+	}
+	for addr, snapshot := range r.remote {
+		if r.members[addr] {
+			refs = append(refs, snapshot.services[key]...)
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].String() < refs[j].String() })
+	return refs
+}
+
+// notify 向 keys 的订阅者投递变化后的注册列表，列表与上次通知相同时跳过。
+func (r *serviceRegistry) notify(ctx vivid.ActorContext, keys ...string) {
+	for _, key := range keys {
+		subs := r.subscribers[key]
+		if len(subs) == 0 {
+			continue
+		}
+		refs := r.listing(key)
+		signature := listingSignature(refs)
+		if r.notified[key] == signature {
+			continue
+		}
+		r.notified[key] = signature
+		for _, sub := range subs {
+			ctx.Tell(sub, ves.ClusterServiceListingEvent{Key: key, Refs: refs})
+		}
+	}
+}
+
+func listingSignature(refs []vivid.ActorRef) string {
+	var signature string
+	for _, ref := range refs {
+		signature += ref.String() + ";"
+	}
+	return signature
+}
@@ -0,0 +1,169 @@
+package cluster
+
+import (
+	"maps"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+// SplitBrainDecision 脑裂处理器的裁决结果。
+type SplitBrainDecision int
+
+const (
+	// SplitBrainDecisionNone 无需处理：无不可达成员或尚未稳定。
+	SplitBrainDecisionNone SplitBrainDecision = iota
+	// SplitBrainDecisionDownUnreachable 本侧保留，剔除不可达成员。
+	SplitBrainDecisionDownUnreachable
+	// SplitBrainDecisionDownSelf 本侧为少数派，本节点下线。
+	SplitBrainDecisionDownSelf
+	// SplitBrainDecisionDownAll 不稳定保护触发，所有节点下线。
+	SplitBrainDecisionDownAll
+)
+
+// SplitBrainResult 一次裁决的结果与依据。
+type SplitBrainResult struct {
+	Decision    SplitBrainDecision
+	Reachable   []*NodeState // 本侧可达成员（含自身）
+	Unreachable []*NodeState // 不可达成员（Suspect/Unreachable）
+}
+
+// SplitBrainResolver 在不可达成员集合稳定 StableAfter 后按策略裁决分区中保留的一侧，并提供不稳定时全部下线的保护。
+type SplitBrainResolver struct {
+	options       vivid.ClusterOptions
+	signature     string    // 上次观察到的不可达成员集合
+	lastChange    time.Time // 不可达成员集合最近一次变化时间
+	unstableSince time.Time // 首次出现不可达成员的时间
+}
+
+// NewSplitBrainResolver 根据集群配置创建脑裂处理器。
+func NewSplitBrainResolver(options vivid.ClusterOptions) *SplitBrainResolver {
+	return &SplitBrainResolver{options: options}
+}
+
+// Enabled 返回是否启用了脑裂处理。
+func (r *SplitBrainResolver) Enabled() bool {
+	return splitBrainEnabled(r.options)
+}
+
+// splitBrainEnabled 返回配置是否启用了脑裂处理：策略非 nil 且不为 SplitBrainStrategyNone。
+func splitBrainEnabled(options vivid.ClusterOptions) bool {
+	return options.SplitBrainStrategy != nil && options.SplitBrainStrategy != vivid.SplitBrainStrategyNone
+}
+
+// StableAfter 返回不可达成员集合需保持稳定的时长，未配置时返回 DefaultSplitBrainStableAfter。
+func (r *SplitBrainResolver) StableAfter() time.Duration {
+	if r.options.SplitBrainStableAfter <= 0 {
+		return DefaultSplitBrainStableAfter
+	}
+	return r.options.SplitBrainStableAfter
+}
+
+// Reset 清空观察状态，在作出裁决或分区恢复后调用。
+func (r *SplitBrainResolver) Reset() {
+	r.signature = ""
+	r.lastChange = time.Time{}
+	r.unstableSince = time.Time{}
+}
+
+// Observe 观察当前视图并在稳定后给出裁决；selfID 为本节点 ID，自身始终视为可达。
+func (r *SplitBrainResolver) Observe(v *ClusterView, selfID string, now time.Time) SplitBrainResult {
+	if !r.Enabled() || v == nil {
+		return SplitBrainResult{}
+	}
+	reachable, unreachable := partitionMembers(v, selfID)
+	if len(unreachable) == 0 {
+		r.Reset()
+		return SplitBrainResult{}
+	}
+	result := SplitBrainResult{Reachable: reachable, Unreachable: unreachable}
+
+	signature := membersSignature(unreachable)
+	if r.unstableSince.IsZero() {
+		r.unstableSince = now
+	}
+	if signature != r.signature {
+		r.signature = signature
+		r.lastChange = now
+	}
+
+	stableAfter := r.StableAfter()
+	if now.Sub(r.lastChange) < stableAfter {
+		if d := r.options.SplitBrainDownAllWhenUnstable; d > 0 && now.Sub(r.unstableSince) >= stableAfter+d {
+			result.Decision = SplitBrainDecisionDownAll
+		}
+		return result
+	}
+
+	if r.keepReachableSide(reachable, unreachable) {
+		result.Decision = SplitBrainDecisionDownUnreachable
+	} else {
+		result.Decision = SplitBrainDecisionDownSelf
+	}
+	return result
+}
+
+// keepReachableSide 按配置策略判断本侧（reachable）是否保留。
+func (r *SplitBrainResolver) keepReachableSide(reachable, unreachable []*NodeState) bool {
+	return r.options.SplitBrainStrategy.KeepReachableSide(&r.options, splitBrainMembers(reachable), splitBrainMembers(unreachable))
+}
+
+// splitBrainMembers 将成员转换为策略裁决使用的 vivid.SplitBrainMember。
+func splitBrainMembers(members []*NodeState) []vivid.SplitBrainMember {
+	out := make([]vivid.SplitBrainMember, 0, len(members))
+	for _, m := range members {
+		out = append(out, vivid.SplitBrainMember{
+			NodeID:    m.ID,
+			Address:   m.Address,
+			Timestamp: m.Timestamp,
+			Labels:    maps.Clone(m.Labels),
+		})
+	}
+	return out
+}
+
+// partitionMembers 将视图成员划分为可达（Up/WeaklyUp/Joining 与自身）与不可达（Suspect/Unreachable），其余状态不参与裁决。
+func partitionMembers(v *ClusterView, selfID string) (reachable, unreachable []*NodeState) {
+	for id, m := range v.Members {
+		if m == nil {
+			continue
+		}
+		if id == selfID {
+			reachable = append(reachable, m)
+			continue
+		}
+		switch m.Status {
+		case MemberStatusUp, MemberStatusWeaklyUp, MemberStatusJoining:
+			reachable = append(reachable, m)
+		case MemberStatusSuspect, MemberStatusUnreachable:
+			unreachable = append(unreachable, m)
+		}
+	}
+	sortMembersByAddress(reachable)
+	sortMembersByAddress(unreachable)
+	return reachable, unreachable
+}
+
+func sortMembersByAddress(members []*NodeState) {
+	sort.Slice(members, func(i, j int) bool { return members[i].Address < members[j].Address })
+}
+
+func membersSignature(members []*NodeState) string {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.ID)
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+// memberAddressesOf 返回成员地址列表，用于事件与日志。
+func memberAddressesOf(members []*NodeState) []string {
+	out := make([]string, 0, len(members))
+	for _, m := range members {
+		out = append(out, m.Address)
+	}
+	return out
+}
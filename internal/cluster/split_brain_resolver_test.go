@@ -0,0 +1,192 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitBrainResolver_Disabled(t *testing.T) {
+	assertDisabledByDefault(t, NewSplitBrainResolver, func(r *SplitBrainResolver) {
+		v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001"), testMembers(MemberStatusSuspect, "127.0.0.1:8002"))
+		assert.Equal(t, SplitBrainDecisionNone, r.Observe(v, "127.0.0.1:8001", time.Now()).Decision)
+	})
+}
+
+func TestSplitBrainResolver_StableAfter(t *testing.T) {
+	r := NewSplitBrainResolver(*testClusterOptions(
+		vivid.WithClusterSplitBrainResolver(vivid.SplitBrainStrategyKeepMajority, time.Second),
+	))
+	v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001", "127.0.0.1:8002"), testMembers(MemberStatusSuspect, "127.0.0.1:8003"))
+	self := "127.0.0.1:8001"
+	now := time.Now()
+	assert.Equal(t, SplitBrainDecisionNone, r.Observe(v, self, now).Decision)
+	assert.Equal(t, SplitBrainDecisionNone, r.Observe(v, self, now.Add(500*time.Millisecond)).Decision)
+	result := r.Observe(v, self, now.Add(time.Second))
+	assert.Equal(t, SplitBrainDecisionDownUnreachable, result.Decision)
+	assert.Len(t, result.Reachable, 2)
+	assert.Len(t, result.Unreachable, 1)
+
+	// 恢复后状态清空，重新计时
+	v.Members["127.0.0.1:8003"].Status = MemberStatusUp
+	assert.Equal(t, SplitBrainDecisionNone, r.Observe(v, self, now.Add(2*time.Second)).Decision)
+	v.Members["127.0.0.1:8003"].Status = MemberStatusSuspect
+	assert.Equal(t, SplitBrainDecisionNone, r.Observe(v, self, now.Add(2*time.Second)).Decision)
+}
+
+func TestSplitBrainResolver_KeepMajority(t *testing.T) {
+	opts := *testClusterOptions(vivid.WithClusterSplitBrainResolver(vivid.SplitBrainStrategyKeepMajority, time.Millisecond))
+	now := time.Now()
+
+	v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8003"), testMembers(MemberStatusSuspect, "127.0.0.1:8001", "127.0.0.1:8002"))
+	self := "127.0.0.1:8003"
+	r := NewSplitBrainResolver(opts)
+	r.Observe(v, self, now)
+	assert.Equal(t, SplitBrainDecisionDownSelf, r.Observe(v, self, now.Add(time.Second)).Decision)
+
+	// 数量相等时保留包含最小地址的一侧
+	v = newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001"), testMembers(MemberStatusSuspect, "127.0.0.1:8002"))
+	self = "127.0.0.1:8001"
+	r = NewSplitBrainResolver(opts)
+	r.Observe(v, self, now)
+	assert.Equal(t, SplitBrainDecisionDownUnreachable, r.Observe(v, self, now.Add(time.Second)).Decision)
+
+	v = newTestView(testMembers(MemberStatusUp, "127.0.0.1:8002"), testMembers(MemberStatusSuspect, "127.0.0.1:8001"))
+	self = "127.0.0.1:8002"
+	r = NewSplitBrainResolver(opts)
+	r.Observe(v, self, now)
+	assert.Equal(t, SplitBrainDecisionDownSelf, r.Observe(v, self, now.Add(time.Second)).Decision)
+}
+
+func TestSplitBrainResolver_KeepOldest(t *testing.T) {
+	opts := *testClusterOptions(vivid.WithClusterSplitBrainResolver(vivid.SplitBrainStrategyKeepOldest, time.Millisecond))
+	now := time.Now()
+
+	// 可达侧为少数但包含最老成员
+	v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001"), testMembers(MemberStatusSuspect, "127.0.0.1:8002", "127.0.0.1:8003"))
+	self := "127.0.0.1:8001"
+	r := NewSplitBrainResolver(opts)
+	r.Observe(v, self, now)
+	assert.Equal(t, SplitBrainDecisionDownUnreachable, r.Observe(v, self, now.Add(time.Second)).Decision)
+
+	v = newTestView(testMembers(MemberStatusUp, "127.0.0.1:8002", "127.0.0.1:8003"), testMembers(MemberStatusSuspect, "127.0.0.1:8001"))
+	self = "127.0.0.1:8002"
+	v.Members["127.0.0.1:8001"].Timestamp = 0
+	r = NewSplitBrainResolver(opts)
+	r.Observe(v, self, now)
+	assert.Equal(t, SplitBrainDecisionDownSelf, r.Observe(v, self, now.Add(time.Second)).Decision)
+}
+
+func TestSplitBrainResolver_KeepReferee(t *testing.T) {
+	opts := *testClusterOptions(
+		vivid.WithClusterSplitBrainResolver(vivid.SplitBrainStrategyKeepReferee, time.Millisecond),
+		vivid.WithClusterSplitBrainReferee("127.0.0.1:8003"),
+	)
+	now := time.Now()
+
+	v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8003"), testMembers(MemberStatusSuspect, "127.0.0.1:8001", "127.0.0.1:8002"))
+	self := "127.0.0.1:8003"
+	r := NewSplitBrainResolver(opts)
+	r.Observe(v, self, now)
+	assert.Equal(t, SplitBrainDecisionDownUnreachable, r.Observe(v, self, now.Add(time.Second)).Decision)
+
+	v = newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001", "127.0.0.1:8002"), testMembers(MemberStatusSuspect, "127.0.0.1:8003"))
+	self = "127.0.0.1:8001"
+	r = NewSplitBrainResolver(opts)
+	r.Observe(v, self, now)
+	assert.Equal(t, SplitBrainDecisionDownSelf, r.Observe(v, self, now.Add(time.Second)).Decision)
+}
+
+func TestSplitBrainResolver_StaticQuorum(t *testing.T) {
+	opts := *testClusterOptions(
+		vivid.WithClusterSplitBrainResolver(vivid.SplitBrainStrategyStaticQuorum, time.Millisecond),
+		vivid.WithClusterSplitBrainStaticQuorumSize(2),
+	)
+	now := time.Now()
+
+	v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001", "127.0.0.1:8002"), testMembers(MemberStatusSuspect, "127.0.0.1:8003"))
+	self := "127.0.0.1:8001"
+	r := NewSplitBrainResolver(opts)
+	r.Observe(v, self, now)
+	assert.Equal(t, SplitBrainDecisionDownUnreachable, r.Observe(v, self, now.Add(time.Second)).Decision)
+
+	v = newTestView(testMembers(MemberStatusUp, "127.0.0.1:8003"), testMembers(MemberStatusSuspect, "127.0.0.1:8001", "127.0.0.1:8002"))
+	self = "127.0.0.1:8003"
+	r = NewSplitBrainResolver(opts)
+	r.Observe(v, self, now)
+	assert.Equal(t, SplitBrainDecisionDownSelf, r.Observe(v, self, now.Add(time.Second)).Decision)
+}
+
+// keepLabelStrategy 保留包含指定标签成员的一侧。
+type keepLabelStrategy struct {
+	key, value string
+}
+
+func (s keepLabelStrategy) KeepReachableSide(_ *vivid.ClusterOptions, reachable, _ []vivid.SplitBrainMember) bool {
+	for _, m := range reachable {
+		if m.Labels[s.key] == s.value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s keepLabelStrategy) String() string {
+	return "keep-label"
+}
+
+func TestSplitBrainResolver_CustomStrategy(t *testing.T) {
+	opts := *testClusterOptions(vivid.WithClusterSplitBrainResolver(keepLabelStrategy{key: "role", value: "primary"}, time.Millisecond))
+	now := time.Now()
+
+	// 可达侧为少数但包含 primary 成员
+	v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8003"), testMembers(MemberStatusSuspect, "127.0.0.1:8001", "127.0.0.1:8002"))
+	self := "127.0.0.1:8003"
+	v.Members["127.0.0.1:8003"].Labels["role"] = "primary"
+	r := NewSplitBrainResolver(opts)
+	assert.True(t, r.Enabled())
+	r.Observe(v, self, now)
+	assert.Equal(t, SplitBrainDecisionDownUnreachable, r.Observe(v, self, now.Add(time.Second)).Decision)
+
+	v = newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001", "127.0.0.1:8002"), testMembers(MemberStatusSuspect, "127.0.0.1:8003"))
+	self = "127.0.0.1:8001"
+	v.Members["127.0.0.1:8003"].Labels["role"] = "primary"
+	r = NewSplitBrainResolver(opts)
+	r.Observe(v, self, now)
+	assert.Equal(t, SplitBrainDecisionDownSelf, r.Observe(v, self, now.Add(time.Second)).Decision)
+}
+
+func TestSplitBrainResolver_DownAllWhenUnstable(t *testing.T) {
+	r := NewSplitBrainResolver(*testClusterOptions(
+		vivid.WithClusterSplitBrainResolver(vivid.SplitBrainStrategyKeepMajority, time.Second),
+		vivid.WithClusterSplitBrainDownAllWhenUnstable(time.Second),
+	))
+	v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001", "127.0.0.1:8002"), testMembers(MemberStatusSuspect, "127.0.0.1:8003", "127.0.0.1:8004"))
+	self := "127.0.0.1:8001"
+	now := time.Now()
+	flapping := v.Members["127.0.0.1:8004"]
+	for i := 0; i < 4; i++ {
+		if i%2 == 0 {
+			flapping.Status = MemberStatusUp
+		} else {
+			flapping.Status = MemberStatusSuspect
+		}
+		assert.Equal(t, SplitBrainDecisionNone, r.Observe(v, self, now.Add(time.Duration(i)*500*time.Millisecond)).Decision)
+	}
+	flapping.Status = MemberStatusUp
+	assert.Equal(t, SplitBrainDecisionDownAll, r.Observe(v, self, now.Add(2*time.Second)).Decision)
+}
+
+func TestFailureDetector_SplitBrainOnlySuspects(t *testing.T) {
+	opts := *testClusterOptions(vivid.WithClusterSplitBrainResolver(vivid.SplitBrainStrategyKeepMajority, time.Second))
+	v := newTestView(testMembers(MemberStatusUp, "127.0.0.1:8001", "127.0.0.1:8002"), testMembers(MemberStatusSuspect, "127.0.0.1:8003"))
+	past := time.Now().Add(-time.Hour).UnixNano()
+	for _, m := range v.Members {
+		m.LastSeen = past
+	}
+	toSuspect, toRemove := NewFailureDetector(opts).RunDetection(v, "127.0.0.1:8001", "", time.Now())
+	assert.Equal(t, []string{"127.0.0.1:8002"}, toSuspect)
+	assert.Empty(t, toRemove)
+}
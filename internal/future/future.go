@@ -14,17 +14,27 @@ var (
 	_ vivid.Mailbox               = (*Future[vivid.Message])(nil)
 )
 
-func NewFuture[T vivid.Message](liaison vivid.ActorLiaison, timeout time.Duration, closer func()) *Future[T] {
+// NewFuture 创建等待响应的 Future，timeout > 0 时由 clock 计时，超时后以 vivid.ErrorFutureTimeout 关闭；clock 为 nil 时使用真实时间。
+func NewFuture[T vivid.Message](liaison vivid.ActorLiaison, clock vivid.Clock, timeout time.Duration, closer func()) *Future[T] {
 	future := &Future[T]{
 		done:    make(chan struct{}),
 		liaison: liaison,
+		clock:   clock,
 		closer:  closer,
 	}
 
 	if timeout > 0 {
-		future.timer = time.AfterFunc(timeout, func() {
+		timeoutFn := func() {
 			future.Close(vivid.ErrorFutureTimeout)
-		})
+		}
+		// 超时回调可能在赋值前触发，timer 的读写均受 mu 保护
+		future.mu.Lock()
+		if clock != nil {
+			future.timer = clock.AfterFunc(timeout, timeoutFn)
+		} else {
+			future.timer = time.AfterFunc(timeout, timeoutFn)
+		}
+		future.mu.Unlock()
 	}
 
 	return future
@@ -41,14 +51,16 @@ func NewFutureFail[T vivid.Message](err error) *Future[T] {
 
 type Future[T vivid.Message] struct {
 	done       chan struct{}      // 用于通知 future 完成
-	timer      *time.Timer        // 超时定时器
+	timer      vivid.ClockTimer   // 超时定时器
 	closed     atomic.Bool        // 是否已关闭
 	err        error              // 完成时的错误
 	message    T                  // 完成时的消息
 	liaison    vivid.ActorLiaison // 关联的 ActorLiaison
+	clock      vivid.Clock        // 超时计时使用的时钟，nil 表示真实时间
 	closer     func()             // Future 关闭时的回调函数
-	mu         sync.Mutex         // 保护 forwarders 的并发读写
+	mu         sync.Mutex         // 保护 forwarders、callbacks 与 timer 的并发读写
 	forwarders vivid.ActorRefs    // 需要转发的 ActorRefs
+	callbacks  []func(T, error)   // 完成时同步调用的回调
 }
 
 func (f *Future[T]) Pause() {
@@ -83,6 +95,7 @@ func (f *Future[T]) PipeTo(forwarders vivid.ActorRefs) error {
 	f.mu.Lock()
 	if f.closed.Load() {
 		f.mu.Unlock()
+		<-f.done // closed 置位早于结果写入，等待结果就绪
 		f.tellForwarders(forwarders, f.message, f.err)
 		return nil
 	}
@@ -119,8 +132,11 @@ func (f *Future[T]) close(v any) {
 		f.err = fmt.Errorf("%w, expected %T, got %T", vivid.ErrorFutureMessageTypeMismatch, f.message, val)
 	}
 	close(f.done)
-	if f.timer != nil {
-		f.timer.Stop()
+	f.mu.Lock()
+	timer := f.timer
+	f.mu.Unlock()
+	if timer != nil {
+		timer.Stop()
 	}
 	if f.closer != nil {
 		f.closer()
@@ -129,8 +145,36 @@ func (f *Future[T]) close(v any) {
 	f.mu.Lock()
 	toSend := f.forwarders
 	f.forwarders = nil
+	callbacks := f.callbacks
+	f.callbacks = nil
 	f.mu.Unlock()
 	f.tellForwarders(toSend, f.message, f.err)
+	for _, callback := range callbacks {
+		callback(f.message, f.err)
+	}
+}
+
+// OnDone 注册完成回调，回调在完成 Future 的 goroutine 中同步执行，应保持轻量且不阻塞；Future 已完成时立即调用。
+func (f *Future[T]) OnDone(callback func(message T, err error)) {
+	f.mu.Lock()
+	if f.closed.Load() {
+		f.mu.Unlock()
+		<-f.done // closed 置位早于结果写入，等待结果就绪
+		callback(f.message, f.err)
+		return
+	}
+	f.callbacks = append(f.callbacks, callback)
+	f.mu.Unlock()
+}
+
+// Liaison 返回关联的 ActorLiaison，供基于该 Future 派生的 Future 沿用以支持 PipeTo；可能为 nil。
+func (f *Future[T]) Liaison() vivid.ActorLiaison {
+	return f.liaison
+}
+
+// Clock 返回创建时指定的时钟，供基于该 Future 派生的 Future 沿用以计时；可能为 nil。
+func (f *Future[T]) Clock() vivid.Clock {
+	return f.clock
 }
 
 func (f *Future[T]) Result() (T, error) {
@@ -0,0 +1,51 @@
+package future
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+// recordingLiaison 记录 Tell 投递的消息，其余方法不可用
+type recordingLiaison struct {
+	vivid.ActorLiaison
+	messages chan vivid.Message
+}
+
+func (l *recordingLiaison) Tell(_ vivid.ActorRef, message vivid.Message) {
+	l.messages <- message
+}
+
+func TestFuture_PipeToWaitsForResultWhileClosing(t *testing.T) {
+	liaison := &recordingLiaison{messages: make(chan vivid.Message, 1)}
+	f := NewFuture[vivid.Message](liaison, nil, 0, nil)
+
+	// 模拟 close 已将 closed 置位、但尚未写入结果的窗口
+	f.closed.Store(true)
+	go func() {
+		_ = f.PipeTo(vivid.ActorRefs{nil})
+	}()
+
+	select {
+	case message := <-liaison.messages:
+		t.Fatalf("expected PipeTo to wait for the result, got %v", message)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	f.message = "result"
+	close(f.done)
+
+	select {
+	case message := <-liaison.messages:
+		result, ok := message.(*vivid.PipeResult)
+		if !ok {
+			t.Fatalf("expected *vivid.PipeResult, got %T", message)
+		}
+		if result.Message != "result" || result.Error != nil {
+			t.Fatalf("expected the real result, got %v, %v", result.Message, result.Error)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected PipeTo to forward the result")
+	}
+}
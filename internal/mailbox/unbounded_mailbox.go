@@ -11,11 +11,13 @@ var (
 	_ vivid.Mailbox = &UnboundedMailbox{}
 )
 
-func NewUnboundedMailbox(initialSize int64, handler vivid.EnvelopHandler) *UnboundedMailbox {
+// NewUnboundedMailbox 创建无界邮箱，dispatcher 为 nil 时在独立的 goroutine 中处理消息。
+func NewUnboundedMailbox(initialSize int64, handler vivid.EnvelopHandler, dispatcher vivid.Dispatcher) *UnboundedMailbox {
 	return &UnboundedMailbox{
 		buffer:       queues.New(initialSize),
 		systemBuffer: queues.New(initialSize),
 		handler:      handler,
+		dispatcher:   dispatcher,
 	}
 }
 
@@ -23,6 +25,7 @@ type UnboundedMailbox struct {
 	buffer       *queues.RingQueue    // 普通消息队列
 	systemBuffer *queues.RingQueue    // 系统消息队列
 	handler      vivid.EnvelopHandler // 消息处理器
+	dispatcher   vivid.Dispatcher     // 邮箱调度器，为 nil 时使用独立的 goroutine
 	status       uint32               // 状态
 	paused       uint32               // 是否暂停普通消息处理
 	num          int32                // 用户消息数量
@@ -36,7 +39,7 @@ func (m *UnboundedMailbox) Pause() {
 func (m *UnboundedMailbox) Resume() {
 	if atomic.CompareAndSwapUint32(&m.paused, 1, 0) {
 		if atomic.CompareAndSwapUint32(&m.status, idle, processing) {
-			go m.process()
+			m.schedule()
 		}
 	}
 }
@@ -55,34 +58,60 @@ func (m *UnboundedMailbox) Enqueue(envelop vivid.Envelop) {
 	}
 
 	if atomic.CompareAndSwapUint32(&m.status, idle, processing) {
+		m.schedule()
+	}
+}
+
+// schedule 提交一次处理任务，调用方须已将状态置为 processing。
+func (m *UnboundedMailbox) schedule() {
+	if m.dispatcher == nil {
 		go m.process()
+		return
 	}
+	m.dispatcher.Dispatch(m.process)
 }
 
 func (m *UnboundedMailbox) process() {
+	throughput := 0
+	if m.dispatcher != nil {
+		throughput = m.dispatcher.Throughput()
+	}
+
 process:
-	m.processHandle()
+	m.processHandle(throughput)
 
 	atomic.StoreUint32(&m.status, idle)
 	user := atomic.LoadInt32(&m.num)
 	system := atomic.LoadInt32(&m.systemNum)
-	if user > 0 || system > 0 {
+	// 暂停时仅剩普通消息无需继续处理，由 Resume 重新调度，避免空转
+	if system > 0 || (user > 0 && atomic.LoadUint32(&m.paused) == 0) {
 		if atomic.CompareAndSwapUint32(&m.status, idle, processing) {
+			if m.dispatcher != nil {
+				// 让出调度器，使其他邮箱的任务得以按序执行
+				m.dispatcher.Dispatch(m.process)
+				return
+			}
 			goto process
 		}
 	}
 }
 
-func (m *UnboundedMailbox) processHandle() {
+// processHandle 处理邮箱中的消息，throughput > 0 时最多处理 throughput 条。
+func (m *UnboundedMailbox) processHandle(throughput int) {
 	var msg any
 	var ok bool
+	var handled int
 
 	for {
 		// 优先处理系统消息
 		for {
+			if throughput > 0 && handled >= throughput {
+				return
+			}
 			if msg, ok = m.systemBuffer.Pop(); ok {
 				atomic.AddInt32(&m.systemNum, -1)
 				m.handler.HandleEnvelop(msg.(vivid.Envelop))
+				handled++
 			} else {
 				break
 			}
@@ -94,9 +123,13 @@ func (m *UnboundedMailbox) processHandle() {
 		}
 
 		// 处理普通消息
+		if throughput > 0 && handled >= throughput {
+			return
+		}
 		if msg, ok = m.buffer.Pop(); ok {
 			atomic.AddInt32(&m.num, -1)
 			m.handler.HandleEnvelop(msg.(vivid.Envelop))
+			handled++
 		} else {
 			return
 		}
@@ -36,7 +36,7 @@ var (
 	_ vivid.Mailbox = &Mailbox{}
 )
 
-func newMailbox(ctx context.Context, advertiseAddress string, codec vivid.Codec, envelopHandler NetworkEnvelopHandler, actorLiaison vivid.ActorLiaison, remotingServerRef vivid.ActorRef, eventStream vivid.EventStream, options vivid.ActorSystemRemotingOptions) *Mailbox {
+func newMailbox(ctx context.Context, clock vivid.Clock, advertiseAddress string, codec vivid.Codec, envelopHandler NetworkEnvelopHandler, actorLiaison vivid.ActorLiaison, remotingServerRef vivid.ActorRef, eventStream vivid.EventStream, options vivid.ActorSystemRemotingOptions) *Mailbox {
 	return &Mailbox{
 		ctx:               ctx,
 		options:           options,
@@ -47,7 +47,7 @@ func newMailbox(ctx context.Context, advertiseAddress string, codec vivid.Codec,
 		remotingServerRef: remotingServerRef,
 		codec:             codec,
 		eventStream:       eventStream,
-		backoff:           utils.NewExponentialBackoffWithDefault(100*time.Millisecond, 3*time.Second),
+		backoff:           utils.NewExponentialBackoffWithDefault(100*time.Millisecond, 3*time.Second).WithSleeper(clock),
 	}
 }
 
@@ -79,6 +79,10 @@ func (m *Mailbox) IsPaused() bool {
 }
 
 func (m *Mailbox) Enqueue(envelop vivid.Envelop) {
+	if filter := m.options.FrameFilter; filter != nil && !filter(m.advertiseAddress, true) {
+		return
+	}
+
 	m.connectionLock.Lock()
 	defer m.connectionLock.Unlock()
 
@@ -126,12 +130,15 @@ func (m *Mailbox) getOrCreateConnection() (*tcpConnectionActor, error) {
 		if m.connection != nil {
 			return m.connection, nil
 		}
-		conn, err := net.Dial("tcp", m.advertiseAddress)
+		conn, err := m.dial()
 		if err != nil {
 			publishRemotingConnectionFailedEvent(m, m.advertiseAddress, m.advertiseAddress, err, m.backoff.GetAttempt())
 			return nil, err
 		}
-		tcpConn, err := newTCPConnectionActor(true, conn, m.advertiseAddress, m.codec, m.envelopHandler, withTCPConnectionActorReadFailedHandler(m.options.ConnectionReadFailedHandler))
+		tcpConn, err := newTCPConnectionActor(true, conn, m.advertiseAddress, m.codec, m.envelopHandler,
+			withTCPConnectionActorReadFailedHandler(m.options.ConnectionReadFailedHandler),
+			withTCPConnectionActorFrameFilter(m.options.FrameFilter),
+		)
 		if err != nil {
 			m.actorLiaison.Logger().Warn("handshake failed", log.String("advertise_address", m.advertiseAddress), log.Any("err", err))
 			return nil, vivid.ErrorRemotingHandshakeFailed.With(err)
@@ -157,6 +164,14 @@ func (m *Mailbox) getOrCreateConnection() (*tcpConnectionActor, error) {
 	return v.(*tcpConnectionActor), nil
 }
 
+// dial 建立到远程地址的出站连接，配置了 Transport 时由其负责拨号。
+func (m *Mailbox) dial() (net.Conn, error) {
+	if m.options.Transport != nil {
+		return m.options.Transport.Dial(m.advertiseAddress)
+	}
+	return net.Dial("tcp", m.advertiseAddress)
+}
+
 func (m *Mailbox) encodeEnvelopWithLength(envelop vivid.Envelop) ([]byte, error) {
 	data, err := serialize.EncodeEnvelopWithRemoting(m.codec, envelop)
 	if err != nil {
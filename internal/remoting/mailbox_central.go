@@ -8,9 +8,10 @@ import (
 	"github.com/kercylan98/vivid/pkg/log"
 )
 
-func newMailboxCentral(ctx context.Context, remotingServerRef vivid.ActorRef, actorLiaison vivid.ActorLiaison, codec vivid.Codec, eventStream vivid.EventStream, options vivid.ActorSystemRemotingOptions) *MailboxCentral {
+func newMailboxCentral(ctx context.Context, clock vivid.Clock, remotingServerRef vivid.ActorRef, actorLiaison vivid.ActorLiaison, codec vivid.Codec, eventStream vivid.EventStream, options vivid.ActorSystemRemotingOptions) *MailboxCentral {
 	return &MailboxCentral{
 		ctx:               ctx,
+		clock:             clock,
 		codec:             codec,
 		actorLiaison:      actorLiaison,
 		remotingServerRef: remotingServerRef,
@@ -22,6 +23,7 @@ func newMailboxCentral(ctx context.Context, remotingServerRef vivid.ActorRef, ac
 
 type MailboxCentral struct {
 	ctx               context.Context
+	clock             vivid.Clock
 	options           vivid.ActorSystemRemotingOptions
 	codec             vivid.Codec         // 编解码器
 	actorLiaison      vivid.ActorLiaison  // 演员联络员
@@ -52,7 +54,7 @@ func (rmc *MailboxCentral) GetOrCreate(advertiseAddr string, envelopHandler Netw
 
 	m, ok := rmc.mailboxes[advertiseAddr]
 	if !ok {
-		m = newMailbox(rmc.ctx, advertiseAddr, rmc.codec, envelopHandler, rmc.actorLiaison, rmc.remotingServerRef, rmc.eventStream, rmc.options)
+		m = newMailbox(rmc.ctx, rmc.clock, advertiseAddr, rmc.codec, envelopHandler, rmc.actorLiaison, rmc.remotingServerRef, rmc.eventStream, rmc.options)
 		rmc.mailboxes[advertiseAddr] = m
 	}
 
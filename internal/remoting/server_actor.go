@@ -22,16 +22,18 @@ var (
 type startAcceptor struct{}
 
 // NewServerActor 创建新的服务器
-func NewServerActor(ctx context.Context, bindAddr string, advertiseAddr string, codec vivid.Codec, envelopHandler NetworkEnvelopHandler, options vivid.ActorSystemRemotingOptions) *ServerActor {
+func NewServerActor(ctx context.Context, clock vivid.Clock, bindAddr string, advertiseAddr string, codec vivid.Codec, envelopHandler NetworkEnvelopHandler, options vivid.ActorSystemRemotingOptions) *ServerActor {
 	sa := &ServerActor{
 		ctx:               ctx,
+		clock:             clock,
 		options:           options,
 		bindAddr:          bindAddr,
+		listener:          options.Listener,
 		advertiseAddr:     advertiseAddr,
 		codec:             codec,
 		envelopHandler:    envelopHandler,
 		acceptConnections: make(map[string]*tcpConnectionActor),
-		backoff:           utils.NewExponentialBackoff(100*time.Millisecond, 10*time.Second, 2, true),
+		backoff:           utils.NewExponentialBackoff(100*time.Millisecond, 10*time.Second, 2, true).WithSleeper(clock),
 	}
 	sa.remotingMailboxCentralWG.Add(1)
 	return sa
@@ -40,13 +42,15 @@ func NewServerActor(ctx context.Context, bindAddr string, advertiseAddr string,
 // ServerActor 管理TCP服务器
 type ServerActor struct {
 	ctx                      context.Context
+	clock                    vivid.Clock                      // 时钟，用于退避重试定时
 	options                  vivid.ActorSystemRemotingOptions // 远程通信选项
 	bindAddr                 string                           // TCP服务器绑定的本地监听地址（如 "0.0.0.0:8080"），只绑定本地，不对外暴露
 	advertiseAddr            string                           // 对外宣称的服务地址（如 "public.ip:port"），用于服务注册和远程节点发现
 	acceptorRef              vivid.ActorRef                   // 当前正在负责被动接收和管理新 TCP 连接的 Acceptor Actor 的引用
 	acceptorListener         net.Listener                     // 必须持有的 listener，避免 Acceptor 阻塞在 listener.Accept() 时无法正常退出
+	listener                 net.Listener                     // 外部预先绑定的监听器，仅首次启动 Acceptor 时使用，此后按 bindAddr 重新监听
 	backoff                  *utils.ExponentialBackoff        // 指数退避器，用于监听端口失败时的重试延迟策略，防止过于频繁重试导致资源浪费
-	backoffTimer             *time.Timer                      // 指数退避重试定时器，用于重试启动服务器监听
+	backoffTimer             vivid.ClockTimer                 // 指数退避重试定时器，用于重试启动服务器监听
 	acceptConnections        map[string]*tcpConnectionActor   // 当前已建立并被服务器管理的连接集合，key为连接唯一标识
 	codec                    vivid.Codec                      // 消息编解码器，实现消息的序列化与反序列化
 	envelopHandler           NetworkEnvelopHandler            // 网络消息处理器，处理接收到的远程消息
@@ -81,8 +85,12 @@ func (s *ServerActor) onStartAcceptor(ctx vivid.ActorContext) {
 		err  error
 	)
 
-	addr, err = net.ResolveTCPAddr("tcp", s.bindAddr)
-	if err == nil {
+	if s.listener != nil {
+		s.acceptorListener, s.listener = s.listener, nil
+		if s.options.TLSConfig != nil {
+			s.acceptorListener = tls.NewListener(s.acceptorListener, s.options.TLSConfig.Clone())
+		}
+	} else if addr, err = net.ResolveTCPAddr("tcp", s.bindAddr); err == nil {
 		if s.options.TLSConfig != nil {
 			s.acceptorListener, err = tls.Listen("tcp", s.bindAddr, s.options.TLSConfig.Clone())
 		} else {
@@ -91,7 +99,7 @@ func (s *ServerActor) onStartAcceptor(ctx vivid.ActorContext) {
 		if err != nil {
 			delay := s.backoff.Next()
 			ctx.Logger().Warn("server listener listen failed, restart later", log.String("bind_addr", s.bindAddr), log.Duration("delay", delay), log.Any("err", err))
-			s.backoffTimer = time.AfterFunc(delay, func() {
+			s.backoffTimer = s.clock.AfterFunc(delay, func() {
 				ctx.TellSelf(startAcceptorMessage)
 			})
 			return
@@ -99,7 +107,7 @@ func (s *ServerActor) onStartAcceptor(ctx vivid.ActorContext) {
 	} else {
 		delay := s.backoff.Next()
 		ctx.Logger().Warn("server listener resolve address failed, restart later", log.String("bind_addr", s.bindAddr), log.Duration("delay", delay), log.Any("err", err))
-		s.backoffTimer = time.AfterFunc(delay, func() {
+		s.backoffTimer = s.clock.AfterFunc(delay, func() {
 			ctx.TellSelf(startAcceptorMessage)
 		})
 		return
@@ -130,7 +138,7 @@ func (s *ServerActor) onStartAcceptor(ctx vivid.ActorContext) {
 
 func (s *ServerActor) onLaunch(ctx vivid.ActorContext) {
 	// 可能存在 Actor 还未启动完成旧投递网络消息，因此需要使用 WaitGroup 等待初始化完成
-	s.remotingMailboxCentral = newMailboxCentral(s.ctx, ctx.Ref(), ctx, s.codec, ctx.EventStream(), s.options)
+	s.remotingMailboxCentral = newMailboxCentral(s.ctx, s.clock, ctx.Ref(), ctx, s.codec, ctx.EventStream(), s.options)
 	s.remotingMailboxCentralWG.Done()
 
 	// 投递 Acceptor 作为启动消息，实现重试启动
@@ -193,6 +201,10 @@ func (s *ServerActor) onKill(ctx vivid.ActorContext, _ *vivid.OnKill) {
 		}
 		s.acceptorListener = nil
 	}
+	if s.listener != nil {
+		_ = s.listener.Close()
+		s.listener = nil
+	}
 
 	// 关闭本机构建的客户端连接
 	s.remotingMailboxCentral.Close()
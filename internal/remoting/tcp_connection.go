@@ -24,6 +24,7 @@ func newTCPConnectionActor(client bool, conn net.Conn, advertiseAddr string, cod
 	}
 
 	c := &tcpConnectionActor{
+		options:        *opts,
 		client:         client,
 		conn:           conn,
 		advertiseAddr:  advertiseAddr,
@@ -41,14 +42,23 @@ func withTCPConnectionActorReadFailedHandler(handler vivid.ActorSystemRemotingCo
 	}
 }
 
+// withTCPConnectionActorFrameFilter 设置收到的消息帧过滤器，被过滤的帧直接丢弃。
+func withTCPConnectionActorFrameFilter(filter vivid.RemotingFrameFilter) tcpConnectionActorOption {
+	return func(options *tcpConnectionActorOptions) {
+		options.frameFilter = filter
+	}
+}
+
 type tcpConnectionActorOptions struct {
 	readFailedHandler vivid.ActorSystemRemotingConnectionReadFailedHandler
+	frameFilter       vivid.RemotingFrameFilter
 }
 
 // tcpConnectionActor TCP连接实现
 type tcpConnectionActor struct {
 	options        tcpConnectionActorOptions
 	conn           net.Conn
+	reader         *bufio.Reader // 连接级读缓冲，跨消息复用，避免丢弃已预读的后续帧
 	codec          vivid.Codec
 	envelopHandler NetworkEnvelopHandler
 	advertiseAddr  string
@@ -83,12 +93,13 @@ func (c *tcpConnectionActor) OnReceive(ctx vivid.ActorContext) {
 
 func (c *tcpConnectionActor) onLaunch(ctx vivid.ActorContext) {
 	// 启动 reader 循环
+	c.reader = bufio.NewReader(c.conn)
 	ctx.TellSelf(c.conn)
 }
 
 func (c *tcpConnectionActor) onReadConn(ctx vivid.ActorContext) (fatal bool, err error) {
 	// 消息读取
-	reader := bufio.NewReader(c.conn)
+	reader := c.reader
 	lengthBuf := make([]byte, 4)
 	if _, err = io.ReadFull(reader, lengthBuf); err != nil {
 		// 对等连接已关闭
@@ -159,6 +170,11 @@ func (c *tcpConnectionActor) onReadConn(ctx vivid.ActorContext) (fatal bool, err
 		)
 		return false, nil
 	} else {
+		// 被过滤的帧视为在网络中丢失，继续监听连接
+		if c.options.frameFilter != nil && !c.options.frameFilter(senderAddr, false) {
+			ctx.TellSelf(c.conn)
+			return false, nil
+		}
 		// 发布消息接收成功事件
 		messageType := "unknown"
 		if messageInstance != nil {
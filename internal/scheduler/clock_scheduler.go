@@ -0,0 +1,176 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/reugn/go-quartz/quartz"
+)
+
+var (
+	_ backend = (*clockScheduler)(nil)
+)
+
+func newClockScheduler(ctx context.Context, clock vivid.Clock) *clockScheduler {
+	return &clockScheduler{
+		ctx:   ctx,
+		clock: clock,
+		jobs:  make(map[string]*clockJob),
+	}
+}
+
+// clockScheduler 由 vivid.Clock 驱动的调度实现，每个任务以 Clock.AfterFunc 等待下一次触发时间。
+//
+// 与 Quartz 不同，触发时不会因任务过期而丢弃，虚拟时钟一次推进越过多个触发点时任务会依次触发。
+type clockScheduler struct {
+	ctx     context.Context
+	clock   vivid.Clock
+	lock    sync.Mutex
+	jobs    map[string]*clockJob // jobKey -> job
+	stopped bool
+}
+
+type clockJob struct {
+	detail    *quartz.JobDetail
+	trigger   quartz.Trigger
+	next      int64            // 下一次触发时间（纳秒）
+	timer     vivid.ClockTimer // 等待下一次触发的定时器，暂停时为 nil
+	suspended bool
+}
+
+func (s *clockScheduler) ScheduleJob(jobDetail *quartz.JobDetail, trigger quartz.Trigger) error {
+	if jobDetail == nil || jobDetail.JobKey() == nil || trigger == nil {
+		return fmt.Errorf("%w: invalid job", quartz.ErrIllegalArgument)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stopped {
+		return fmt.Errorf("%w: scheduler stopped", quartz.ErrIllegalState)
+	}
+
+	key := jobDetail.JobKey().String()
+	if existing, ok := s.jobs[key]; ok {
+		if !jobDetail.Options().Replace {
+			return fmt.Errorf("%w: %w", quartz.ErrIllegalState, quartz.ErrJobAlreadyExists)
+		}
+		s.remove(key, existing)
+	}
+
+	job := &clockJob{detail: jobDetail, trigger: trigger, suspended: jobDetail.Options().Suspended}
+	s.jobs[key] = job
+	if job.suspended {
+		return nil
+	}
+	if err := s.arm(key, job, s.clock.Now().UnixNano()); err != nil {
+		delete(s.jobs, key)
+		return err
+	}
+	return nil
+}
+
+// arm 计算 prev 之后的下一次触发时间并启动定时器，调用方须持有锁。
+func (s *clockScheduler) arm(key string, job *clockJob, prev int64) error {
+	next, err := job.trigger.NextFireTime(prev)
+	if err != nil {
+		return err
+	}
+	job.next = next
+	job.timer = s.clock.AfterFunc(time.Duration(next-s.clock.Now().UnixNano()), func() {
+		s.fire(key, job)
+	})
+	return nil
+}
+
+func (s *clockScheduler) fire(key string, job *clockJob) {
+	s.lock.Lock()
+	if s.stopped || s.jobs[key] != job || job.suspended {
+		s.lock.Unlock()
+		return
+	}
+	// 以本次触发时间为基准计算下一次触发，触发器过期时移除任务
+	if err := s.arm(key, job, job.next); err != nil {
+		delete(s.jobs, key)
+	}
+	s.lock.Unlock()
+
+	_ = job.detail.Job().Execute(s.ctx)
+}
+
+// remove 停止并移除任务，调用方须持有锁。
+func (s *clockScheduler) remove(key string, job *clockJob) {
+	if job.timer != nil {
+		job.timer.Stop()
+	}
+	delete(s.jobs, key)
+}
+
+func (s *clockScheduler) PauseJob(jobKey *quartz.JobKey) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	job, ok := s.jobs[jobKey.String()]
+	if !ok {
+		return fmt.Errorf("%w: %w", quartz.ErrIllegalState, quartz.ErrJobNotFound)
+	}
+	if job.suspended {
+		return fmt.Errorf("%w: %w", quartz.ErrIllegalState, quartz.ErrJobIsSuspended)
+	}
+	job.suspended = true
+	if job.timer != nil {
+		job.timer.Stop()
+		job.timer = nil
+	}
+	return nil
+}
+
+func (s *clockScheduler) ResumeJob(jobKey *quartz.JobKey) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	key := jobKey.String()
+	job, ok := s.jobs[key]
+	if !ok {
+		return fmt.Errorf("%w: %w", quartz.ErrIllegalState, quartz.ErrJobNotFound)
+	}
+	if !job.suspended {
+		return fmt.Errorf("%w: %w", quartz.ErrIllegalState, quartz.ErrJobIsActive)
+	}
+	job.suspended = false
+	if err := s.arm(key, job, s.clock.Now().UnixNano()); err != nil {
+		delete(s.jobs, key)
+		return err
+	}
+	return nil
+}
+
+func (s *clockScheduler) DeleteJob(jobKey *quartz.JobKey) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	key := jobKey.String()
+	job, ok := s.jobs[key]
+	if !ok {
+		return fmt.Errorf("%w: %w", quartz.ErrIllegalState, quartz.ErrJobNotFound)
+	}
+	s.remove(key, job)
+	return nil
+}
+
+func (s *clockScheduler) Clear() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for key, job := range s.jobs {
+		s.remove(key, job)
+	}
+	return nil
+}
+
+func (s *clockScheduler) Stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stopped = true
+	for key, job := range s.jobs {
+		s.remove(key, job)
+	}
+}
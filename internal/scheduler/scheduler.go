@@ -7,7 +7,23 @@ import (
 	"github.com/reugn/go-quartz/quartz"
 )
 
-func NewScheduler(ctx context.Context) *Scheduler {
+// backend 调度器实际使用的任务调度实现，quartz.Scheduler 与 clockScheduler 均满足该接口。
+type backend interface {
+	ScheduleJob(jobDetail *quartz.JobDetail, trigger quartz.Trigger) error
+	PauseJob(jobKey *quartz.JobKey) error
+	ResumeJob(jobKey *quartz.JobKey) error
+	DeleteJob(jobKey *quartz.JobKey) error
+	Clear() error
+	Stop()
+}
+
+// NewScheduler 创建调度器，clock 为 nil 时使用基于真实时间的 Quartz 调度器，否则由 clock 驱动任务触发。
+func NewScheduler(ctx context.Context, clock vivid.Clock) *Scheduler {
+	if clock != nil {
+		return &Scheduler{
+			scheduler: newClockScheduler(ctx, clock),
+		}
+	}
 	quartzScheduler, _ := quartz.NewStdScheduler(quartz.WithLogger(new(discordLogger)))
 	quartzScheduler.Start(ctx)
 	return &Scheduler{
@@ -16,7 +32,7 @@ func NewScheduler(ctx context.Context) *Scheduler {
 }
 
 type Scheduler struct {
-	scheduler quartz.Scheduler
+	scheduler backend
 }
 
 func (s *Scheduler) Schedule(jobDetail *quartz.JobDetail, trigger quartz.Trigger) error {
@@ -48,6 +48,26 @@ type ExponentialBackoff struct {
 	Factor         float64       // Factor 退避因子
 	Jitter         bool          // Jitter 是否启用抖动，避免雷群效应
 	currentAttempt int           // currentAttempt 当前尝试次数
+	sleeper        Sleeper       // sleeper Try 与 Forever 的等待实现，为 nil 时使用 time.Sleep
+}
+
+// Sleeper 退避等待的实现，vivid.Clock 满足该接口，用于在测试中以虚拟时钟驱动退避。
+type Sleeper interface {
+	Sleep(d time.Duration)
+}
+
+// WithSleeper 设置 Try 与 Forever 的等待实现并返回自身，sleeper 为 nil 时使用 time.Sleep
+func (eb *ExponentialBackoff) WithSleeper(sleeper Sleeper) *ExponentialBackoff {
+	eb.sleeper = sleeper
+	return eb
+}
+
+func (eb *ExponentialBackoff) sleep(d time.Duration) {
+	if eb.sleeper != nil {
+		eb.sleeper.Sleep(d)
+		return
+	}
+	time.Sleep(d)
 }
 
 // Try 尝试执行函数，如果失败则进行指数退避
@@ -71,7 +91,7 @@ func (eb *ExponentialBackoff) Try(limit int, fn func() (abort bool, err error))
 		if limit >= 0 && eb.currentAttempt >= limit {
 			return abort, fmt.Errorf("try failed after %d attempts", eb.currentAttempt)
 		}
-		time.Sleep(eb.Next())
+		eb.sleep(eb.Next())
 	}
 }
 
@@ -98,7 +118,7 @@ func (eb *ExponentialBackoff) Forever(fn func() (abort bool, err error), errorHa
 		if abort || err == nil {
 			return abort, err
 		}
-		time.Sleep(eb.Next())
+		eb.sleep(eb.Next())
 	}
 }
 
@@ -1,7 +1,14 @@
 package bootstrap
 
 import (
+	"context"
+	"os"
+	"sync/atomic"
+	"syscall"
 	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -10,3 +17,30 @@ func TestNewActorSystem(t *testing.T) {
 	system := NewActorSystem()
 	assert.NotNil(t, system)
 }
+
+func TestStopOnSignal(t *testing.T) {
+	system := NewActorSystem()
+	assert.NoError(t, system.Start())
+
+	var stopped atomic.Bool
+	assert.NoError(t, system.CoordinatedShutdown().AddTask(vivid.ShutdownPhaseBeforeServiceUnbind, "mark", func(ctx context.Context) error {
+		stopped.Store(true)
+		return nil
+	}))
+
+	done, cancel := StopOnSignal(system, syscall.SIGHUP)
+	defer cancel()
+	process, err := os.FindProcess(os.Getpid())
+	assert.NoError(t, err)
+	if err = process.Signal(syscall.SIGHUP); err != nil {
+		t.Skip("sending signals to self is not supported on this platform")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("actor system not stopped on signal")
+	}
+	assert.True(t, stopped.Load())
+	assert.ErrorIs(t, system.Stop(), vivid.ErrorActorSystemAlreadyStopped)
+}
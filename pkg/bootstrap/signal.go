@@ -0,0 +1,45 @@
+package bootstrap
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/pkg/log"
+)
+
+// StopOnSignal 在进程收到指定信号时调用 system.Stop()，从而按阶段执行协调关闭（CoordinatedShutdown）。
+//
+// 参数：
+//   - system: 收到信号后需要停止的 ActorSystem
+//   - signals: 触发停止的信号，未指定时为 SIGTERM 与 os.Interrupt
+//
+// 返回：
+//   - done：Stop 完成后关闭的通道，可用于在 main 中阻塞等待关闭结束
+//   - cancel：取消信号监听，取消后不会再触发 Stop；重复调用无副作用
+func StopOnSignal(system vivid.ActorSystem, signals ...os.Signal) (done <-chan struct{}, cancel func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGTERM, os.Interrupt}
+	}
+	notify := make(chan os.Signal, 1)
+	signal.Notify(notify, signals...)
+
+	stopped := make(chan struct{})
+	cancelled := make(chan struct{})
+	var once sync.Once
+	go func() {
+		defer signal.Stop(notify)
+		select {
+		case sig := <-notify:
+			system.Logger().Info("signal received, stopping actor system", log.String("signal", sig.String()))
+			_ = system.Stop()
+			close(stopped)
+		case <-cancelled:
+		}
+	}()
+	return stopped, func() {
+		once.Do(func() { close(cancelled) })
+	}
+}
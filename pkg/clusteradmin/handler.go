@@ -0,0 +1,237 @@
+// Package clusteradmin 提供可挂载到业务 HTTP 服务中的集群管理端点（成员、视图、优雅退出、强制下线），
+// 所有请求均以 AdminSecret 派生的 HTTP 令牌认证，输出 JSON。
+package clusteradmin
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+// tokenPayload HTTP 令牌的 HMAC payload，与集群管理消息的 AdminToken 使用不同的 payload，泄露 HTTP 令牌不会泄露 AdminToken。
+const tokenPayload = "cluster-admin-http"
+
+// defaultLeaveTimeout /leave 等待退出完成的最长时间，超时后返回 202 Accepted，退出在后台继续进行。
+const defaultLeaveTimeout = 10 * time.Second
+
+// Token 根据 AdminSecret 计算 HTTP 管理令牌，请求时以 "Authorization: Bearer <token>" 携带。
+//
+// 令牌由 secret 确定性派生，不含时间或随机成分，因此不会过期，也无法单独吊销；更换 AdminSecret 是使其失效的唯一方式。
+// 令牌应视同 secret 保管，并仅通过 TLS 传输。
+func Token(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	h := hmac.New(sha256.New, []byte(secret))
+	_, _ = h.Write([]byte(tokenPayload))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Handler 集群管理 HTTP 处理器，路由（相对挂载点）：
+//
+//	GET  /members    成员列表（NodeID、地址、状态、分代、标签）
+//	GET  /view       Leader、多数派状态、健康数与法定人数
+//	POST /leave      令成员优雅退出集群，参数 nodeId 或 address（查询参数或 JSON 请求体）；退出未在限定时间内完成时返回 202
+//	POST /down       强制下线成员，参数 nodeId 或 address（查询参数或 JSON 请求体）
+//	POST /broadcast  立即触发一轮视图广播
+//
+// 挂载到子路径时请配合 http.StripPrefix 使用。退出、强制下线与广播经由 ClusterContext 的 LeaveAddress、Down 与 ForceBroadcast 执行，
+// 集群管理消息的 AdminToken 由节点自身的 AdminSecret 计算，不经过 HTTP 传输。
+type Handler struct {
+	cluster      vivid.ClusterContext
+	token        string
+	leaveTimeout time.Duration
+	mux          *http.ServeMux
+}
+
+// NewHandler 创建集群管理处理器；clusterContext 通常为 system.Cluster()。
+// secret 用于派生 HTTP 令牌（参见 Token），通常与集群配置的 AdminSecret 相同；为空时返回 ErrorIllegalArgument，避免暴露未认证的管理入口。
+func NewHandler(clusterContext vivid.ClusterContext, secret string) (*Handler, error) {
+	if clusterContext == nil {
+		return nil, vivid.ErrorClusterDisabled
+	}
+	if secret == "" {
+		return nil, vivid.ErrorIllegalArgument.WithMessage("admin secret is empty")
+	}
+	h := &Handler{cluster: clusterContext, token: Token(secret), leaveTimeout: defaultLeaveTimeout, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /members", h.handleMembers)
+	h.mux.HandleFunc("GET /view", h.handleView)
+	h.mux.HandleFunc("POST /leave", h.handleLeave)
+	h.mux.HandleFunc("POST /down", h.handleDown)
+	h.mux.HandleFunc("POST /broadcast", h.handleBroadcast)
+	return h, nil
+}
+
+// ServeHTTP 校验管理令牌后分发请求。
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || !hmac.Equal([]byte(h.token), []byte(token)) {
+		writeError(w, vivid.ErrorClusterAdminAuthFailed)
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+// MemberResponse 单个成员的 JSON 表示。
+type MemberResponse struct {
+	NodeID     string            `json:"nodeId"`
+	Address    string            `json:"address"`
+	Status     string            `json:"status"`
+	Generation int               `json:"generation"`
+	Version    string            `json:"version"`
+	AppVersion string            `json:"appVersion,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+// ViewResponse 视图摘要的 JSON 表示。
+type ViewResponse struct {
+	Leader       string `json:"leader"`
+	InQuorum     bool   `json:"inQuorum"`
+	HealthyCount int    `json:"healthyCount"`
+	QuorumSize   int    `json:"quorumSize"`
+}
+
+// MemberRequest 退出与强制下线的请求体，NodeID 与 Address 二选一。
+type MemberRequest struct {
+	NodeID  string `json:"nodeId"`
+	Address string `json:"address"`
+}
+
+func (h *Handler) handleMembers(w http.ResponseWriter, r *http.Request) {
+	members, err := h.cluster.GetMembers()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	out := make([]MemberResponse, 0, len(members))
+	for _, m := range members {
+		out = append(out, MemberResponse{
+			NodeID:     m.NodeID,
+			Address:    m.Address,
+			Status:     m.Status,
+			Generation: m.Generation,
+			Version:    m.Version,
+			AppVersion: m.AppVersion,
+			Labels:     m.Labels,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"members": out})
+}
+
+func (h *Handler) handleView(w http.ResponseWriter, r *http.Request) {
+	view, err := h.cluster.GetView()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ViewResponse{
+		Leader:       view.LeaderAddr,
+		InQuorum:     view.InQuorum,
+		HealthyCount: view.HealthyCount,
+		QuorumSize:   view.QuorumSize,
+	})
+}
+
+func (h *Handler) handleLeave(w http.ResponseWriter, r *http.Request) {
+	address, err := h.memberAddress(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// 本节点退出会等待退出流程结束，放入独立 goroutine 并限时等待，避免长期占用请求
+	done := make(chan error, 1)
+	go func() {
+		done <- h.cluster.LeaveAddress(address)
+	}()
+	timer := time.NewTimer(h.leaveTimeout)
+	defer timer.Stop()
+	select {
+	case err = <-done:
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"left": address})
+	case <-timer.C:
+		writeJSON(w, http.StatusAccepted, map[string]any{"leaving": address})
+	case <-r.Context().Done():
+	}
+}
+
+func (h *Handler) handleDown(w http.ResponseWriter, r *http.Request) {
+	address, err := h.memberAddress(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := h.cluster.Down(address); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"down": address})
+}
+
+func (h *Handler) handleBroadcast(w http.ResponseWriter, r *http.Request) {
+	if err := h.cluster.ForceBroadcast(); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"broadcast": true})
+}
+
+// memberAddress 从查询参数或 JSON 请求体中读取 nodeId 或 address，nodeId 在成员列表中解析为地址。
+func (h *Handler) memberAddress(w http.ResponseWriter, r *http.Request) (string, error) {
+	req := MemberRequest{NodeID: r.URL.Query().Get("nodeId"), Address: r.URL.Query().Get("address")}
+	if req.NodeID == "" && req.Address == "" && r.Body != nil && r.ContentLength != 0 {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+			return "", vivid.ErrorIllegalArgument.With(err)
+		}
+	}
+	if req.Address != "" {
+		return req.Address, nil
+	}
+	if req.NodeID == "" {
+		return "", vivid.ErrorIllegalArgument.WithMessage("nodeId or address is required")
+	}
+	members, err := h.cluster.GetMembers()
+	if err != nil {
+		return "", err
+	}
+	for _, m := range members {
+		if m.NodeID == req.NodeID {
+			return m.Address, nil
+		}
+	}
+	return "", vivid.ErrorNotFound.WithMessage("member " + req.NodeID)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError 按错误类型映射 HTTP 状态码并输出 {"error": "..."}。
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, vivid.ErrorClusterAdminAuthFailed):
+		status = http.StatusUnauthorized
+	case errors.Is(err, vivid.ErrorClusterDisabled):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, vivid.ErrorNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, vivid.ErrorIllegalArgument):
+		status = http.StatusBadRequest
+	case errors.Is(err, vivid.ErrorFutureTimeout):
+		status = http.StatusGatewayTimeout
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,199 @@
+package clusteradmin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "admin-secret"
+
+type fakeCluster struct {
+	members   []vivid.ClusterMemberInfo
+	view      *vivid.ClusterView
+	left      []string
+	leaving   chan struct{} // 非 nil 时 LeaveAddress 阻塞至其关闭
+	downed    []string
+	broadcast int
+}
+
+func (f *fakeCluster) GetMembers() ([]vivid.ClusterMemberInfo, error) { return f.members, nil }
+func (f *fakeCluster) Leave()                                         {}
+func (f *fakeCluster) SingletonRef(name string) (vivid.ActorRef, error) {
+	return nil, vivid.ErrorNotFound
+}
+func (f *fakeCluster) GetView() (*vivid.ClusterView, error) { return f.view, nil }
+
+func (f *fakeCluster) Down(address string) error {
+	for _, m := range f.members {
+		if m.Address == address {
+			f.downed = append(f.downed, address)
+			return nil
+		}
+	}
+	return vivid.ErrorNotFound
+}
+
+func (f *fakeCluster) ForceBroadcast() error {
+	f.broadcast++
+	return nil
+}
+
+func (f *fakeCluster) LeaveAddress(address string) error {
+	if f.leaving != nil {
+		<-f.leaving
+	}
+	for _, m := range f.members {
+		if m.Address == address {
+			f.left = append(f.left, address)
+			return nil
+		}
+	}
+	return vivid.ErrorNotFound
+}
+
+func (f *fakeCluster) Join(seeds []string) error                  { return nil }
+func (f *fakeCluster) RegisterService(ref vivid.ActorRef) error   { return nil }
+func (f *fakeCluster) UnregisterService(ref vivid.ActorRef) error { return nil }
+
+func (f *fakeCluster) Register(key vivid.ServiceKeyID, ref vivid.ActorRef) error   { return nil }
+func (f *fakeCluster) Deregister(key vivid.ServiceKeyID, ref vivid.ActorRef) error { return nil }
+func (f *fakeCluster) Find(key vivid.ServiceKeyID) ([]vivid.ActorRef, error)       { return nil, nil }
+func (f *fakeCluster) Subscribe(key vivid.ServiceKeyID, sub vivid.ActorRef) error  { return nil }
+func (f *fakeCluster) Unsubscribe(key vivid.ServiceKeyID, sub vivid.ActorRef) error {
+	return nil
+}
+
+func newTestHandler(t *testing.T) (*Handler, *fakeCluster) {
+	t.Helper()
+	fc := &fakeCluster{
+		members: []vivid.ClusterMemberInfo{
+			{NodeID: "n1", Address: "127.0.0.1:7070", Status: "Up", Generation: 1, Labels: map[string]string{"role": "api"}},
+			{NodeID: "n2", Address: "127.0.0.1:7071", Status: "Suspect", Generation: 2},
+		},
+		view: &vivid.ClusterView{LeaderAddr: "127.0.0.1:7070", InQuorum: true, HealthyCount: 1, QuorumSize: 2},
+	}
+	h, err := NewHandler(fc, testSecret)
+	require.NoError(t, err)
+	return h, fc
+}
+
+func do(h http.Handler, method, target, body string, auth bool) *httptest.ResponseRecorder {
+	var req *http.Request
+	if body != "" {
+		req = httptest.NewRequest(method, target, strings.NewReader(body))
+	} else {
+		req = httptest.NewRequest(method, target, nil)
+	}
+	if auth {
+		req.Header.Set("Authorization", "Bearer "+Token(testSecret))
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewHandler_RejectsInvalidArguments(t *testing.T) {
+	_, err := NewHandler(nil, testSecret)
+	assert.ErrorIs(t, err, vivid.ErrorClusterDisabled)
+	_, err = NewHandler(&fakeCluster{}, "")
+	assert.ErrorIs(t, err, vivid.ErrorIllegalArgument)
+}
+
+func TestHandler_Unauthorized(t *testing.T) {
+	h, fc := newTestHandler(t)
+	assert.Equal(t, http.StatusUnauthorized, do(h, http.MethodGet, "/members", "", false).Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/broadcast", nil)
+	req.Header.Set("Authorization", "Bearer wrong")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Zero(t, fc.broadcast)
+}
+
+func TestToken_DiffersFromAdminToken(t *testing.T) {
+	assert.NotEqual(t, cluster.ComputeAdminToken(testSecret), Token(testSecret))
+	assert.Equal(t, Token(testSecret), Token(testSecret))
+	assert.Empty(t, Token(""))
+
+	h, _ := newTestHandler(t)
+	req := httptest.NewRequest(http.MethodGet, "/members", nil)
+	req.Header.Set("Authorization", "Bearer "+cluster.ComputeAdminToken(testSecret))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestHandler_MembersAndView(t *testing.T) {
+	h, _ := newTestHandler(t)
+
+	rec := do(h, http.MethodGet, "/members", "", true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var members struct {
+		Members []MemberResponse `json:"members"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &members))
+	require.Len(t, members.Members, 2)
+	assert.Equal(t, "n1", members.Members[0].NodeID)
+	assert.Equal(t, "api", members.Members[0].Labels["role"])
+	assert.Equal(t, "Suspect", members.Members[1].Status)
+
+	rec = do(h, http.MethodGet, "/view", "", true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var view ViewResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &view))
+	assert.Equal(t, ViewResponse{Leader: "127.0.0.1:7070", InQuorum: true, HealthyCount: 1, QuorumSize: 2}, view)
+}
+
+func TestHandler_Down(t *testing.T) {
+	h, fc := newTestHandler(t)
+
+	rec := do(h, http.MethodPost, "/down?nodeId=n2", "", true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"down":"127.0.0.1:7071"}`, rec.Body.String())
+	assert.Equal(t, http.StatusOK, do(h, http.MethodPost, "/down", `{"address":"127.0.0.1:7070"}`, true).Code)
+	assert.Equal(t, []string{"127.0.0.1:7071", "127.0.0.1:7070"}, fc.downed)
+
+	assert.Equal(t, http.StatusNotFound, do(h, http.MethodPost, "/down?address=127.0.0.1:9999", "", true).Code)
+	assert.Equal(t, http.StatusNotFound, do(h, http.MethodPost, "/down?nodeId=n9", "", true).Code)
+	assert.Equal(t, http.StatusBadRequest, do(h, http.MethodPost, "/down", "", true).Code)
+	assert.Equal(t, http.StatusBadRequest, do(h, http.MethodPost, "/down", "{", true).Code)
+}
+
+func TestHandler_LeaveAndBroadcast(t *testing.T) {
+	h, fc := newTestHandler(t)
+
+	assert.Equal(t, http.StatusOK, do(h, http.MethodPost, "/broadcast", "", true).Code)
+	assert.Equal(t, 1, fc.broadcast)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, do(h, http.MethodGet, "/leave", "", true).Code)
+	rec := do(h, http.MethodPost, "/leave?nodeId=n2", "", true)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"left":"127.0.0.1:7071"}`, rec.Body.String())
+	assert.Equal(t, http.StatusOK, do(h, http.MethodPost, "/leave", `{"address":"127.0.0.1:7070"}`, true).Code)
+	assert.Equal(t, []string{"127.0.0.1:7071", "127.0.0.1:7070"}, fc.left)
+
+	assert.Equal(t, http.StatusNotFound, do(h, http.MethodPost, "/leave?address=127.0.0.1:9999", "", true).Code)
+	assert.Equal(t, http.StatusNotFound, do(h, http.MethodPost, "/leave?nodeId=n9", "", true).Code)
+	assert.Equal(t, http.StatusBadRequest, do(h, http.MethodPost, "/leave", "", true).Code)
+}
+
+func TestHandler_LeaveTimeout(t *testing.T) {
+	h, fc := newTestHandler(t)
+	h.leaveTimeout = 50 * time.Millisecond
+	fc.leaving = make(chan struct{})
+	defer close(fc.leaving)
+
+	rec := do(h, http.MethodPost, "/leave?nodeId=n1", "", true)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	assert.JSONEq(t, `{"leaving":"127.0.0.1:7070"}`, rec.Body.String())
+}
@@ -0,0 +1,163 @@
+// Package clusterclient 提供集群外部进程访问集群的轻量客户端。
+// 客户端所在的 ActorSystem 仅需启用 Remoting，无需加入集群；请求经集群节点上的接待者（receptionist）
+// 转发到已通过 ClusterContext.RegisterService 注册的服务或集群单例，联系点不可达或未注册目标服务时自动切换到下一个。
+package clusterclient
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/cluster"
+)
+
+const (
+	// DefaultContactTimeout 默认等待接待者应答的时长（Ask 时在 RequestTimeout 之外额外等待）。
+	DefaultContactTimeout = 3 * time.Second
+)
+
+// Options 集群客户端配置。
+type Options struct {
+	// RequestTimeout Ask 时接待者等待集群内目标回复的超时；≤0 时使用 vivid.DefaultAskTimeout。
+	RequestTimeout time.Duration
+	// ContactTimeout 等待接待者应答的时长，超时视为联系点不可达并切换到下一个；≤0 时使用 DefaultContactTimeout。
+	// 远程投递在连接不可用时会按客户端 ActorSystem 的 Remoting 重连配置同步重试，切换前的实际等待还包含这部分耗时。
+	ContactTimeout time.Duration
+}
+
+// Option 是用于配置 Options 的函数类型。
+type Option = func(*Options)
+
+// WithRequestTimeout 返回一个 Option，用于设置 Ask 时等待集群内目标回复的超时。
+func WithRequestTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		o.RequestTimeout = d
+	}
+}
+
+// WithContactTimeout 返回一个 Option，用于设置等待接待者应答的时长。
+func WithContactTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		o.ContactTimeout = d
+	}
+}
+
+// Client 集群客户端，并发安全。
+// 请求始终发往当前联系点；接待者未在 ContactTimeout 内应答，或其所在节点未注册目标服务时，依次切换到下一个联系点重试。
+// 任一联系点报告服务未注册且其余均不可达时返回 ErrorClusterServiceNotRegistered（同属 ErrorNotFound），全部不可达时返回 ErrorClusterClientUnavailable。
+// 接待者已应答的其他错误（如目标自身回复的错误、目标回复超时）直接返回，不触发切换。
+// 切换发生在等待超时后，原联系点可能已收到请求，因此切换时的投递语义为至少一次。
+type Client struct {
+	system   vivid.ActorSystem
+	contacts []string
+	options  Options
+	mu       sync.Mutex
+	current  int
+}
+
+// New 创建集群客户端；system 须启用 Remoting，contactPoints 为集群节点地址（host:port），至少一个。
+func New(system vivid.ActorSystem, contactPoints []string, opts ...Option) (*Client, error) {
+	if system == nil {
+		return nil, vivid.ErrorIllegalArgument.WithMessage("actor system is nil")
+	}
+	contacts := make([]string, 0, len(contactPoints))
+	for _, addr := range contactPoints {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			contacts = append(contacts, addr)
+		}
+	}
+	if len(contacts) == 0 {
+		return nil, vivid.ErrorIllegalArgument.WithMessage("no contact points")
+	}
+	c := &Client{system: system, contacts: contacts}
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+	if c.options.RequestTimeout <= 0 {
+		c.options.RequestTimeout = vivid.DefaultAskTimeout
+	}
+	if c.options.ContactTimeout <= 0 {
+		c.options.ContactTimeout = DefaultContactTimeout
+	}
+	return c, nil
+}
+
+// Contact 返回当前使用的联系点地址。
+func (c *Client) Contact() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.contacts[c.current]
+}
+
+// Tell 向集群内路径为 path 的已注册服务投递消息，接待者确认投递后返回。
+func (c *Client) Tell(path string, message vivid.Message) error {
+	_, err := c.send(&cluster.ClusterClientRequest{Path: path, Message: message}, c.options.ContactTimeout)
+	return err
+}
+
+// Ask 向集群内路径为 path 的已注册服务发送消息并等待其回复。
+func (c *Client) Ask(path string, message vivid.Message) (vivid.Message, error) {
+	return c.send(&cluster.ClusterClientRequest{Path: path, Reply: true, Timeout: c.options.RequestTimeout, Message: message},
+		c.options.RequestTimeout+c.options.ContactTimeout)
+}
+
+// TellSingleton 向名为 name 的集群单例投递消息，接待者确认投递后返回。
+func (c *Client) TellSingleton(name string, message vivid.Message) error {
+	_, err := c.send(&cluster.ClusterClientRequest{Singleton: name, Message: message}, c.options.ContactTimeout)
+	return err
+}
+
+// AskSingleton 向名为 name 的集群单例发送消息并等待其回复。
+func (c *Client) AskSingleton(name string, message vivid.Message) (vivid.Message, error) {
+	return c.send(&cluster.ClusterClientRequest{Singleton: name, Reply: true, Timeout: c.options.RequestTimeout, Message: message},
+		c.options.RequestTimeout+c.options.ContactTimeout)
+}
+
+// send 从当前联系点开始依次尝试，直到某个接待者应答且已注册目标服务，或全部联系点失败。
+func (c *Client) send(request *cluster.ClusterClientRequest, timeout time.Duration) (vivid.Message, error) {
+	var lastErr, notRegistered error
+	for range c.contacts {
+		addr := c.Contact()
+		reply, err := c.askReceptionist(addr, request, timeout)
+		if err != nil {
+			lastErr = err
+			c.failover(addr)
+			continue
+		}
+		result, ok := reply.(*cluster.ClusterClientResponse)
+		if !ok {
+			return nil, vivid.ErrorFutureMessageTypeMismatch
+		}
+		if errors.Is(result.Error, vivid.ErrorClusterServiceNotRegistered) {
+			notRegistered = result.Error
+			c.failover(addr)
+			continue
+		}
+		return result.Message, result.Error
+	}
+	if notRegistered != nil {
+		// 跨节点传输后错误链丢失，重新挂到已注册实例上以保留 ErrorNotFound 归属
+		return nil, vivid.ErrorClusterServiceNotRegistered.WithMessage(request.Path)
+	}
+	return nil, vivid.ErrorClusterClientUnavailable.With(lastErr)
+}
+
+// askReceptionist 向 addr 上的接待者发起 Ask，最多等待 timeout。
+func (c *Client) askReceptionist(addr string, request *cluster.ClusterClientRequest, timeout time.Duration) (vivid.Message, error) {
+	ref, err := c.system.CreateRef(addr, "/"+cluster.ReceptionistActorName)
+	if err != nil {
+		return nil, err
+	}
+	return c.system.Ask(ref, request, timeout).Result()
+}
+
+// failover 在 addr 仍为当前联系点时切换到下一个，避免并发请求重复切换。
+func (c *Client) failover(addr string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.contacts[c.current] == addr {
+		c.current = (c.current + 1) % len(c.contacts)
+	}
+}
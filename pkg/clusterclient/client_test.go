@@ -0,0 +1,167 @@
+package clusterclient_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/messages"
+	"github.com/kercylan98/vivid/pkg/bootstrap"
+	"github.com/kercylan98/vivid/pkg/clusterclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	vivid.RegisterCustomMessage[*echoMessage]("ClusterClientEchoMessage",
+		func(message any, reader *messages.Reader, codec messages.Codec) error {
+			return reader.ReadInto(&message.(*echoMessage).Text)
+		},
+		func(message any, writer *messages.Writer, codec messages.Codec) error {
+			return writer.WriteFrom(message.(*echoMessage).Text)
+		},
+	)
+}
+
+type echoMessage struct {
+	Text string
+}
+
+func newEchoActor(received chan<- string) vivid.Actor {
+	return vivid.ActorFN(func(ctx vivid.ActorContext) {
+		if m, ok := ctx.Message().(*echoMessage); ok {
+			received <- m.Text
+			ctx.Reply(m)
+		}
+	})
+}
+
+func TestNew_RejectsInvalidArguments(t *testing.T) {
+	_, err := clusterclient.New(nil, []string{"127.0.0.1:7070"})
+	assert.ErrorIs(t, err, vivid.ErrorIllegalArgument)
+}
+
+func TestClient_SendThroughReceptionistWithFailover(t *testing.T) {
+	const nodeAddr, clientAddr, deadAddr = "127.0.0.1:17140", "127.0.0.1:17141", "127.0.0.1:17149"
+	received := make(chan string, 4)
+
+	node := bootstrap.NewActorSystem(
+		vivid.WithActorSystemRemoting(nodeAddr),
+		vivid.WithActorSystemRemotingOptions(
+			vivid.NewActorSystemRemotingOptions(),
+			vivid.WithActorSystemRemotingClusterOption(
+				vivid.WithClusterSingleton("echo-singleton", vivid.ActorProviderFN(func() vivid.Actor {
+					return newEchoActor(received)
+				})),
+			),
+		),
+	)
+	require.NoError(t, node.Start())
+	defer func() { assert.NoError(t, node.Stop()) }()
+
+	service, err := node.ActorOf(newEchoActor(received), vivid.WithActorName("echo"))
+	require.NoError(t, err)
+	require.NoError(t, node.Cluster().RegisterService(service))
+
+	// 客户端仅启用 Remoting，不加入集群；关闭重连使不可达联系点立即失败
+	clientSystem := bootstrap.NewActorSystem(
+		vivid.WithActorSystemRemoting(clientAddr),
+		vivid.WithActorSystemRemotingOptions(vivid.NewActorSystemRemotingOptions(), vivid.WithActorSystemRemotingReconnectLimit(0)),
+	)
+	require.NoError(t, clientSystem.Start())
+	defer func() { assert.NoError(t, clientSystem.Stop()) }()
+	assert.Nil(t, clientSystem.Cluster())
+
+	client, err := clusterclient.New(clientSystem, []string{deadAddr, nodeAddr},
+		clusterclient.WithContactTimeout(500*time.Millisecond),
+		clusterclient.WithRequestTimeout(2*time.Second),
+	)
+	require.NoError(t, err)
+
+	reply, err := client.Ask(service.GetPath(), &echoMessage{Text: "ask"})
+	require.NoError(t, err)
+	assert.Equal(t, "ask", reply.(*echoMessage).Text)
+	assert.Equal(t, nodeAddr, client.Contact())
+	assert.Equal(t, "ask", <-received)
+
+	require.NoError(t, client.Tell(service.GetPath(), &echoMessage{Text: "tell"}))
+	assert.Equal(t, "tell", <-received)
+
+	reply, err = client.AskSingleton("echo-singleton", &echoMessage{Text: "singleton"})
+	require.NoError(t, err)
+	assert.Equal(t, "singleton", reply.(*echoMessage).Text)
+	assert.Equal(t, "singleton", <-received)
+
+	// 第二次请求使用接待者缓存的单例代理
+	require.NoError(t, client.TellSingleton("echo-singleton", &echoMessage{Text: "cached"}))
+	assert.Equal(t, "cached", <-received)
+
+	_, err = client.Ask("/not-registered", &echoMessage{Text: "x"})
+	assert.ErrorIs(t, err, vivid.ErrorNotFound)
+
+	require.NoError(t, node.Cluster().UnregisterService(service))
+	assert.ErrorIs(t, client.Tell(service.GetPath(), &echoMessage{Text: "gone"}), vivid.ErrorNotFound)
+}
+
+func TestClient_AllContactsUnavailable(t *testing.T) {
+	clientSystem := bootstrap.NewActorSystem(
+		vivid.WithActorSystemRemoting("127.0.0.1:17142"),
+		vivid.WithActorSystemRemotingOptions(vivid.NewActorSystemRemotingOptions(), vivid.WithActorSystemRemotingReconnectLimit(0)),
+	)
+	require.NoError(t, clientSystem.Start())
+	defer func() { assert.NoError(t, clientSystem.Stop()) }()
+
+	client, err := clusterclient.New(clientSystem, []string{"127.0.0.1:17148", "127.0.0.1:17149"},
+		clusterclient.WithContactTimeout(300*time.Millisecond))
+	require.NoError(t, err)
+	assert.ErrorIs(t, client.Tell("/echo", &echoMessage{Text: "x"}), vivid.ErrorClusterClientUnavailable)
+}
+
+func TestClient_FailoverWhenServiceNotRegistered(t *testing.T) {
+	const emptyAddr, serviceAddr, clientAddr = "127.0.0.1:17143", "127.0.0.1:17144", "127.0.0.1:17145"
+	received := make(chan string, 2)
+
+	newNode := func(addr string) vivid.ActorSystem {
+		node := bootstrap.NewActorSystem(
+			vivid.WithActorSystemRemoting(addr),
+			vivid.WithActorSystemRemotingOptions(
+				vivid.NewActorSystemRemotingOptions(),
+				vivid.WithActorSystemRemotingClusterOption(),
+			),
+		)
+		require.NoError(t, node.Start())
+		return node
+	}
+	emptyNode := newNode(emptyAddr)
+	defer func() { assert.NoError(t, emptyNode.Stop()) }()
+	serviceNode := newNode(serviceAddr)
+	defer func() { assert.NoError(t, serviceNode.Stop()) }()
+
+	// 服务仅注册在第二个联系点所在节点
+	service, err := serviceNode.ActorOf(newEchoActor(received), vivid.WithActorName("echo"))
+	require.NoError(t, err)
+	require.NoError(t, serviceNode.Cluster().RegisterService(service))
+
+	clientSystem := bootstrap.NewActorSystem(
+		vivid.WithActorSystemRemoting(clientAddr),
+		vivid.WithActorSystemRemotingOptions(vivid.NewActorSystemRemotingOptions(), vivid.WithActorSystemRemotingReconnectLimit(0)),
+	)
+	require.NoError(t, clientSystem.Start())
+	defer func() { assert.NoError(t, clientSystem.Stop()) }()
+
+	client, err := clusterclient.New(clientSystem, []string{emptyAddr, serviceAddr},
+		clusterclient.WithContactTimeout(time.Second),
+		clusterclient.WithRequestTimeout(2*time.Second),
+	)
+	require.NoError(t, err)
+
+	reply, err := client.Ask(service.GetPath(), &echoMessage{Text: "ask"})
+	require.NoError(t, err)
+	assert.Equal(t, "ask", reply.(*echoMessage).Text)
+	assert.Equal(t, serviceAddr, client.Contact())
+	assert.Equal(t, "ask", <-received)
+
+	_, err = client.Ask("/not-registered", &echoMessage{Text: "x"})
+	assert.ErrorIs(t, err, vivid.ErrorClusterServiceNotRegistered)
+	assert.ErrorIs(t, err, vivid.ErrorNotFound)
+}
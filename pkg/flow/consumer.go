@@ -0,0 +1,77 @@
+package flow
+
+import (
+	"github.com/kercylan98/vivid"
+)
+
+const (
+	// DefaultDemand 消费方默认需求窗口。
+	DefaultDemand = 64
+)
+
+// ConsumerOptions 消费方配置。
+type ConsumerOptions struct {
+	// Demand 需求窗口，即邮箱中最多积压的来自生产方的消息数；≤0 时使用 DefaultDemand。
+	Demand int
+}
+
+// ConsumerOption 是用于配置 ConsumerOptions 的函数类型。
+type ConsumerOption = func(*ConsumerOptions)
+
+// WithDemand 返回一个 ConsumerOption，用于设置消费方的需求窗口。
+func WithDemand(n int) ConsumerOption {
+	return func(o *ConsumerOptions) {
+		o.Demand = n
+	}
+}
+
+// Consumer 工作拉取的消费方，嵌入消费方 Actor 使用，非并发安全，仅可在所属 Actor 的 OnReceive 中调用。
+//
+// 首次调用 Receive 时向生产方请求 Demand 条消息；之后每收到一条 *Item，当已请求未收到的消息数降到窗口一半及以下时
+// 补足窗口，因此邮箱中来自生产方的消息（不含正在处理的一条）不会超过 Demand 条。
+type Consumer struct {
+	producer    vivid.ActorRef
+	options     ConsumerOptions
+	started     bool
+	outstanding uint64 // 已请求但尚未收到的消息数
+}
+
+// NewConsumer 创建向 producer 拉取消息的消费方。
+func NewConsumer(producer vivid.ActorRef, opts ...ConsumerOption) *Consumer {
+	c := &Consumer{producer: producer}
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+	if c.options.Demand <= 0 {
+		c.options.Demand = DefaultDemand
+	}
+	return c
+}
+
+// Receive 处理当前消息并返回业务消息：*Item 解封为内层消息并按需补充需求，其他消息原样返回。
+// 应在 OnReceive 开头对每条消息调用，首次调用（通常为 OnLaunch）时发出初始需求。
+func (c *Consumer) Receive(ctx vivid.ActorContext) vivid.Message {
+	if !c.started {
+		c.started = true
+		c.request(ctx, uint64(c.options.Demand))
+	}
+	item, ok := ctx.Message().(*Item)
+	if !ok {
+		return ctx.Message()
+	}
+	if c.outstanding > 0 {
+		c.outstanding--
+	}
+	if c.outstanding <= uint64(c.options.Demand)/2 {
+		c.request(ctx, uint64(c.options.Demand)-c.outstanding)
+	}
+	return item.Message
+}
+
+func (c *Consumer) request(ctx vivid.ActorContext, n uint64) {
+	if n == 0 || c.producer == nil {
+		return
+	}
+	c.outstanding += n
+	ctx.Tell(c.producer, &Request{N: uint32(n)})
+}
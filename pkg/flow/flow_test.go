@@ -0,0 +1,163 @@
+package flow_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/messages"
+	"github.com/kercylan98/vivid/pkg/bootstrap"
+	"github.com/kercylan98/vivid/pkg/flow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	vivid.RegisterCustomMessage[*work]("FlowWorkMessage",
+		func(message any, reader *messages.Reader, codec messages.Codec) error {
+			return reader.ReadInto(&message.(*work).N)
+		},
+		func(message any, writer *messages.Writer, codec messages.Codec) error {
+			return writer.WriteFrom(message.(*work).N)
+		},
+	)
+}
+
+type work struct {
+	N int32
+}
+
+type offerRequest struct {
+	n int32
+}
+
+type stateRequest struct{}
+
+type producerState struct {
+	buffered int
+	demand   uint64
+}
+
+// newProducerActor 收到 offerRequest 时 Offer 一条 work 并回复错误，收到 stateRequest 时回复缓冲与需求。
+func newProducerActor(producer *flow.Producer) vivid.Actor {
+	return vivid.ActorFN(func(ctx vivid.ActorContext) {
+		if producer.Handle(ctx) {
+			return
+		}
+		switch m := ctx.Message().(type) {
+		case *offerRequest:
+			ctx.Reply(producer.Offer(ctx, &work{N: m.n}))
+		case *stateRequest:
+			ctx.Reply(producerState{buffered: producer.Buffered(), demand: producer.Demand()})
+		}
+	})
+}
+
+// newConsumerActor 将收到的 work 写入 received；gate 非 nil 时每条消息处理前等待放行，模拟慢消费。
+func newConsumerActor(producer vivid.ActorRef, demand int, gate <-chan struct{}, received chan<- int32) vivid.Actor {
+	consumer := flow.NewConsumer(producer, flow.WithDemand(demand))
+	return vivid.ActorFN(func(ctx vivid.ActorContext) {
+		if w, ok := consumer.Receive(ctx).(*work); ok {
+			if gate != nil {
+				<-gate
+			}
+			received <- w.N
+		}
+	})
+}
+
+func askState(t *testing.T, system vivid.ActorSystem, producer vivid.ActorRef) producerState {
+	t.Helper()
+	reply, err := system.Ask(producer, &stateRequest{}).Result()
+	require.NoError(t, err)
+	return reply.(producerState)
+}
+
+func TestProducer_SendsWithinDemand(t *testing.T) {
+	system := bootstrap.NewActorSystem()
+	require.NoError(t, system.Start())
+	defer func() { assert.NoError(t, system.Stop()) }()
+
+	producerRef, err := system.ActorOf(newProducerActor(flow.NewProducer(flow.WithBufferSize(4))))
+	require.NoError(t, err)
+
+	// 消费方启动前的消息全部进入缓冲区，超过容量时报错
+	for i := range int32(4) {
+		_, err = system.Ask(producerRef, &offerRequest{n: i}).Result()
+		require.NoError(t, err)
+	}
+	_, err = system.Ask(producerRef, &offerRequest{n: 4}).Result()
+	assert.ErrorIs(t, err, vivid.ErrorFlowBufferFull)
+
+	gate := make(chan struct{})
+	received := make(chan int32, 8)
+	_, err = system.ActorOf(newConsumerActor(producerRef, 2, gate, received))
+	require.NoError(t, err)
+
+	// 需求窗口为 2：消费方阻塞在第 1 条时补充 1 条需求，邮箱中至多 2 条，其余留在缓冲区
+	assert.Eventually(t, func() bool {
+		return askState(t, system, producerRef).buffered == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint64(0), askState(t, system, producerRef).demand)
+
+	for want := range int32(4) {
+		gate <- struct{}{}
+		assert.Equal(t, want, <-received)
+	}
+	assert.Eventually(t, func() bool {
+		state := askState(t, system, producerRef)
+		return state.buffered == 0 && state.demand == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestProducer_ConsumerKilled(t *testing.T) {
+	system := bootstrap.NewActorSystem()
+	require.NoError(t, system.Start())
+	defer func() { assert.NoError(t, system.Stop()) }()
+
+	producerRef, err := system.ActorOf(newProducerActor(flow.NewProducer()))
+	require.NoError(t, err)
+	consumerRef, err := system.ActorOf(newConsumerActor(producerRef, 8, nil, make(chan int32, 8)))
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return askState(t, system, producerRef).demand == 8
+	}, time.Second, 10*time.Millisecond)
+
+	system.Kill(consumerRef, false)
+	assert.Eventually(t, func() bool {
+		return askState(t, system, producerRef).demand == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestProducer_Remote(t *testing.T) {
+	nodeA := bootstrap.NewActorSystem(vivid.WithActorSystemRemoting("127.0.0.1:17170"))
+	nodeB := bootstrap.NewActorSystem(vivid.WithActorSystemRemoting("127.0.0.1:17171"))
+	require.NoError(t, nodeA.Start())
+	require.NoError(t, nodeB.Start())
+	defer func() {
+		assert.NoError(t, nodeB.Stop())
+		assert.NoError(t, nodeA.Stop())
+	}()
+
+	producerRef, err := nodeA.ActorOf(newProducerActor(flow.NewProducer()), vivid.WithActorName("producer"))
+	require.NoError(t, err)
+	remoteProducer, err := nodeB.CreateRef("127.0.0.1:17170", "/producer")
+	require.NoError(t, err)
+
+	received := make(chan int32, 16)
+	_, err = nodeB.ActorOf(newConsumerActor(remoteProducer, 4, nil, received))
+	require.NoError(t, err)
+
+	for i := range int32(10) {
+		_, err = nodeA.Ask(producerRef, &offerRequest{n: i}).Result()
+		require.NoError(t, err)
+	}
+	for want := range int32(10) {
+		select {
+		case n := <-received:
+			assert.Equal(t, want, n)
+		case <-time.After(3 * time.Second):
+			t.Fatalf("item %d not received", want)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Package flow 提供 Actor 之间基于需求信号的工作拉取（work-pulling）流控。
+//
+// 消费方通过 Consumer 向生产方请求 N 条消息，生产方通过 Producer 仅在已授予的需求内发送，
+// 超出需求的消息暂存于有界缓冲区，从而避免快速生产方撑爆消费方的无界邮箱。本地与远程 ActorRef 均可使用。
+package flow
+
+import (
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/messages"
+)
+
+func init() {
+	messages.RegisterInternalMessage[*Request]("flowRequest", requestReader, requestWriter)
+	messages.RegisterInternalMessage[*Item]("flowItem", itemReader, itemWriter)
+}
+
+// Request 消费方向生产方授予的需求，表示可再接收 N 条消息。
+type Request struct {
+	N uint32
+}
+
+// Item 生产方在需求内发送给消费方的消息封装。
+type Item struct {
+	Message vivid.Message
+}
+
+func requestReader(message any, reader *messages.Reader, codec messages.Codec) error {
+	return reader.ReadInto(&message.(*Request).N)
+}
+
+func requestWriter(message any, writer *messages.Writer, codec messages.Codec) error {
+	return writer.WriteFrom(message.(*Request).N)
+}
+
+func itemReader(message any, reader *messages.Reader, codec messages.Codec) error {
+	msg, err := reader.ReadMessage(codec)
+	if err != nil {
+		return err
+	}
+	message.(*Item).Message = msg
+	return nil
+}
+
+func itemWriter(message any, writer *messages.Writer, codec messages.Codec) error {
+	return writer.WriteMessage(message.(*Item).Message, codec)
+}
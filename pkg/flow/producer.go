@@ -0,0 +1,166 @@
+package flow
+
+import (
+	"github.com/kercylan98/vivid"
+)
+
+const (
+	// DefaultBufferSize 生产方默认缓冲区容量。
+	DefaultBufferSize = 1024
+)
+
+// ProducerOptions 生产方配置。
+type ProducerOptions struct {
+	// BufferSize 无可用需求时暂存消息的缓冲区容量；≤0 时使用 DefaultBufferSize。
+	BufferSize int
+}
+
+// ProducerOption 是用于配置 ProducerOptions 的函数类型。
+type ProducerOption = func(*ProducerOptions)
+
+// WithBufferSize 返回一个 ProducerOption，用于设置生产方缓冲区容量。
+func WithBufferSize(size int) ProducerOption {
+	return func(o *ProducerOptions) {
+		o.BufferSize = size
+	}
+}
+
+// Producer 工作拉取的生产方，嵌入生产方 Actor 使用，非并发安全，仅可在所属 Actor 的 OnReceive 中调用。
+//
+// 消费方以 *Request 授予需求后，Offer 的消息按轮询分发给仍有需求的消费方；所有消费方需求耗尽时暂存到缓冲区，
+// 收到新的需求后按先进先出补发。Actor 须在 OnReceive 开头调用 Handle。消费方终止后其剩余需求自动作废。
+type Producer struct {
+	options   ProducerOptions
+	consumers []*consumerDemand
+	next      int             // 轮询起点
+	buffer    []vivid.Message // 待发送消息
+}
+
+type consumerDemand struct {
+	ref    vivid.ActorRef
+	demand uint64
+}
+
+// NewProducer 创建工作拉取生产方。
+func NewProducer(opts ...ProducerOption) *Producer {
+	p := &Producer{}
+	for _, opt := range opts {
+		opt(&p.options)
+	}
+	if p.options.BufferSize <= 0 {
+		p.options.BufferSize = DefaultBufferSize
+	}
+	return p
+}
+
+// Handle 处理生产方相关的消息：消费方的 *Request 与已知消费方的 *vivid.OnKilled 返回 true，调用方无需再处理；其他消息返回 false。
+func (p *Producer) Handle(ctx vivid.ActorContext) bool {
+	switch m := ctx.Message().(type) {
+	case *Request:
+		p.grant(ctx, ctx.Sender(), uint64(m.N))
+		return true
+	case *vivid.OnKilled:
+		return p.remove(m.Ref)
+	}
+	return false
+}
+
+// Offer 发送 message：存在有需求的消费方时立即发送，否则放入缓冲区；缓冲区已满时返回 ErrorFlowBufferFull。
+func (p *Producer) Offer(ctx vivid.ActorContext, message vivid.Message) error {
+	if message == nil {
+		return vivid.ErrorIllegalArgument.WithMessage("message is nil")
+	}
+	if len(p.buffer) == 0 {
+		if consumer := p.nextConsumer(); consumer != nil {
+			p.send(ctx, consumer, message)
+			return nil
+		}
+	}
+	if len(p.buffer) >= p.options.BufferSize {
+		return vivid.ErrorFlowBufferFull
+	}
+	p.buffer = append(p.buffer, message)
+	return nil
+}
+
+// Buffered 返回缓冲区中的消息数。
+func (p *Producer) Buffered() int {
+	return len(p.buffer)
+}
+
+// Demand 返回全部消费方的剩余需求之和。
+func (p *Producer) Demand() uint64 {
+	var total uint64
+	for _, c := range p.consumers {
+		total += c.demand
+	}
+	return total
+}
+
+// grant 为 ref 增加需求并补发缓冲区中的消息，首次出现的消费方会被 Watch。
+func (p *Producer) grant(ctx vivid.ActorContext, ref vivid.ActorRef, n uint64) {
+	if ref == nil || n == 0 {
+		return
+	}
+	var consumer *consumerDemand
+	for _, c := range p.consumers {
+		if c.ref.Equals(ref) {
+			consumer = c
+			break
+		}
+	}
+	if consumer == nil {
+		consumer = &consumerDemand{ref: ref}
+		p.consumers = append(p.consumers, consumer)
+		ctx.Watch(ref)
+	}
+	consumer.demand += n
+	p.flush(ctx)
+}
+
+func (p *Producer) flush(ctx vivid.ActorContext) {
+	for len(p.buffer) > 0 {
+		consumer := p.nextConsumer()
+		if consumer == nil {
+			break
+		}
+		message := p.buffer[0]
+		p.buffer[0] = nil
+		p.buffer = p.buffer[1:]
+		p.send(ctx, consumer, message)
+	}
+	if len(p.buffer) == 0 {
+		p.buffer = nil
+	}
+}
+
+// nextConsumer 从轮询起点开始返回首个仍有需求的消费方，均无需求时返回 nil。
+func (p *Producer) nextConsumer() *consumerDemand {
+	for i := range p.consumers {
+		idx := (p.next + i) % len(p.consumers)
+		if c := p.consumers[idx]; c.demand > 0 {
+			p.next = (idx + 1) % len(p.consumers)
+			return c
+		}
+	}
+	return nil
+}
+
+func (p *Producer) send(ctx vivid.ActorContext, consumer *consumerDemand, message vivid.Message) {
+	consumer.demand--
+	ctx.Tell(consumer.ref, &Item{Message: message})
+}
+
+func (p *Producer) remove(ref vivid.ActorRef) bool {
+	if ref == nil {
+		return false
+	}
+	for i, c := range p.consumers {
+		if c.ref.Equals(ref) {
+			p.consumers = append(p.consumers[:i], p.consumers[i+1:]...)
+			p.next = 0
+			return true
+		}
+	}
+	return false
+}
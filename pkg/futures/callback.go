@@ -0,0 +1,35 @@
+package futures
+
+import (
+	"github.com/kercylan98/vivid"
+)
+
+// completion 携带完成回调的消息，投递给注册回调的 Actor 自身，仅本地使用。
+type completion struct {
+	callback func(ctx vivid.ActorContext)
+}
+
+// OnComplete 在 f 完成后以消息形式通知 ctx 所属的 Actor，并在其处理该消息时调用 fn，因此 fn 可安全访问 Actor 状态。
+//
+// Actor 须在 OnReceive 开头调用 Handle 以执行回调：回调消息为本包未导出的类型，未调用 Handle 时它会作为普通消息交给 OnReceive，
+// 通常落入忽略未知消息的分支，回调不会执行且不会有任何提示。Actor 在回调到达前终止时回调不会执行，消息成为死信。
+// fn 内应使用其参数 ctx（即处理回调消息时的 ActorContext），而非注册时捕获的 ctx。
+func OnComplete[T any](ctx vivid.ActorContext, f vivid.Future[T], fn func(ctx vivid.ActorContext, result T, err error)) {
+	self, system := ctx.Ref(), ctx.System()
+	go func() {
+		result, err := f.Result()
+		system.Tell(self, &completion{callback: func(ctx vivid.ActorContext) {
+			fn(ctx, result, err)
+		}})
+	}()
+}
+
+// Handle 若当前消息为 OnComplete 投递的回调则执行并返回 true，否则返回 false。应在 OnReceive 开头对每条消息调用。
+func Handle(ctx vivid.ActorContext) bool {
+	c, ok := ctx.Message().(*completion)
+	if !ok {
+		return false
+	}
+	c.callback(ctx)
+	return true
+}
@@ -0,0 +1,195 @@
+// Package futures 提供基于 vivid.Future 的泛型组合子：转换（Map、FlatMap、As）、组合（Sequence、FirstCompleted、Race）、
+// 错误处理（Recover、WithTimeout），以及在所属 Actor 内安全执行的完成回调（OnComplete）。
+//
+// 组合子均为非阻塞调用。Map、FlatMap、Recover 会执行调用方的函数，在源 Future 完成后由独立的 goroutine 完成；
+// Sequence、FirstCompleted、Race、WithTimeout 通过源 Future 的完成回调完成，不为每个源 Future 占用 goroutine。
+// 派生的 Future 沿用源 Future 的 ActorLiaison 与时钟，因此同样支持 PipeTo，超时也随 ActorSystem 的时钟计时。
+package futures
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/future"
+)
+
+// newFuture 创建派生 Future，沿用 sources 中首个可获取的 ActorLiaison 与时钟。
+func newFuture[R any](timeout time.Duration, sources ...any) *future.Future[R] {
+	var liaison vivid.ActorLiaison
+	var clock vivid.Clock
+	for _, source := range sources {
+		if l, ok := source.(interface{ Liaison() vivid.ActorLiaison }); ok && liaison == nil {
+			liaison = l.Liaison()
+		}
+		if c, ok := source.(interface{ Clock() vivid.Clock }); ok && clock == nil {
+			clock = c.Clock()
+		}
+	}
+	return future.NewFuture[R](liaison, clock, timeout, nil)
+}
+
+// onDone 在 f 完成后以其结果调用 callback。f 支持完成回调时直接注册，callback 在完成 f 的 goroutine 中同步执行；
+// 否则退化为等待 f 的 goroutine。
+func onDone[T any](f vivid.Future[T], callback func(result T, err error)) {
+	if d, ok := f.(interface{ OnDone(func(T, error)) }); ok {
+		d.OnDone(callback)
+		return
+	}
+	go func() {
+		callback(f.Result())
+	}()
+}
+
+// complete 以 result 或 err 完成 f。
+func complete[R any](f *future.Future[R], result R, err error) {
+	if err != nil {
+		f.Close(err)
+		return
+	}
+	f.EnqueueMessage(result)
+}
+
+// Map 在 f 成功后以 fn 转换结果；f 失败或 fn 返回错误时派生 Future 以该错误失败。
+func Map[T, R any](f vivid.Future[T], fn func(result T) (R, error)) vivid.Future[R] {
+	out := newFuture[R](0, f)
+	go func() {
+		result, err := f.Result()
+		if err != nil {
+			out.Close(err)
+			return
+		}
+		mapped, err := fn(result)
+		complete(out, mapped, err)
+	}()
+	return out
+}
+
+// As 将结果断言为 R，类型不符时以 vivid.ErrorFutureMessageTypeMismatch 失败。常用于将 Ask 返回的 Future[vivid.Message] 转为具体类型。
+func As[R any, T any](f vivid.Future[T]) vivid.Future[R] {
+	return Map(f, func(result T) (R, error) {
+		typed, ok := any(result).(R)
+		if !ok {
+			var zero R
+			return zero, fmt.Errorf("%w, expected %T, got %T", vivid.ErrorFutureMessageTypeMismatch, zero, result)
+		}
+		return typed, nil
+	})
+}
+
+// FlatMap 在 f 成功后以 fn 发起下一个异步操作，派生 Future 随其结果完成。
+func FlatMap[T, R any](f vivid.Future[T], fn func(result T) vivid.Future[R]) vivid.Future[R] {
+	out := newFuture[R](0, f)
+	go func() {
+		result, err := f.Result()
+		if err != nil {
+			out.Close(err)
+			return
+		}
+		next := fn(result)
+		if next == nil {
+			out.Close(vivid.ErrorFutureInvalid.WithMessage("flat map returned nil future"))
+			return
+		}
+		mapped, err := next.Result()
+		complete(out, mapped, err)
+	}()
+	return out
+}
+
+// Sequence 等待全部 Future 成功，按传入顺序返回结果；任一失败时立即以该错误失败。未传入 Future 时以空切片成功。
+func Sequence[T any](fs ...vivid.Future[T]) vivid.Future[[]T] {
+	out := newFuture[[]T](0, anys(fs)...)
+	if len(fs) == 0 {
+		out.EnqueueMessage([]T{})
+		return out
+	}
+	results := make([]T, len(fs))
+	var remaining atomic.Int64
+	remaining.Store(int64(len(fs)))
+	for i, f := range fs {
+		onDone(f, func(result T, err error) {
+			if err != nil {
+				out.Close(err)
+				return
+			}
+			results[i] = result
+			if remaining.Add(-1) == 0 {
+				out.EnqueueMessage(results)
+			}
+		})
+	}
+	return out
+}
+
+// FirstCompleted 以最先完成的 Future 的结果完成，无论其成功或失败。未传入 Future 时以 vivid.ErrorFutureInvalid 失败。
+func FirstCompleted[T any](fs ...vivid.Future[T]) vivid.Future[T] {
+	out := newFuture[T](0, anys(fs)...)
+	if len(fs) == 0 {
+		out.Close(vivid.ErrorFutureInvalid.WithMessage("no futures"))
+		return out
+	}
+	for _, f := range fs {
+		onDone(f, func(result T, err error) {
+			complete(out, result, err)
+		})
+	}
+	return out
+}
+
+// Race 以最先成功的 Future 的结果完成；全部失败时以合并的错误（errors.Join）失败。未传入 Future 时以 vivid.ErrorFutureInvalid 失败。
+func Race[T any](fs ...vivid.Future[T]) vivid.Future[T] {
+	out := newFuture[T](0, anys(fs)...)
+	if len(fs) == 0 {
+		out.Close(vivid.ErrorFutureInvalid.WithMessage("no futures"))
+		return out
+	}
+	errs := make([]error, len(fs))
+	var remaining atomic.Int64
+	remaining.Store(int64(len(fs)))
+	for i, f := range fs {
+		onDone(f, func(result T, err error) {
+			if err == nil {
+				out.EnqueueMessage(result)
+				return
+			}
+			errs[i] = err
+			if remaining.Add(-1) == 0 {
+				out.Close(errors.Join(errs...))
+			}
+		})
+	}
+	return out
+}
+
+// Recover 在 f 失败时以 fn 根据错误给出替代结果；fn 返回错误时派生 Future 以该错误失败。f 成功时原样返回结果。
+func Recover[T any](f vivid.Future[T], fn func(err error) (T, error)) vivid.Future[T] {
+	out := newFuture[T](0, f)
+	go func() {
+		result, err := f.Result()
+		if err != nil {
+			result, err = fn(err)
+		}
+		complete(out, result, err)
+	}()
+	return out
+}
+
+// WithTimeout 为 f 附加超时：timeout 内未完成时派生 Future 以 vivid.ErrorFutureTimeout 失败。不会关闭 f，timeout ≤0 时不限时。
+func WithTimeout[T any](f vivid.Future[T], timeout time.Duration) vivid.Future[T] {
+	out := newFuture[T](timeout, f)
+	onDone(f, func(result T, err error) {
+		complete(out, result, err)
+	})
+	return out
+}
+
+func anys[T any](fs []vivid.Future[T]) []any {
+	sources := make([]any, len(fs))
+	for i, f := range fs {
+		sources[i] = f
+	}
+	return sources
+}
@@ -0,0 +1,180 @@
+package futures_test
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/future"
+	"github.com/kercylan98/vivid/pkg/futures"
+	"github.com/kercylan98/vivid/pkg/vividtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errBroken = errors.New("broken")
+
+func pending[T any]() *future.Future[T] {
+	return future.NewFuture[T](nil, nil, 0, nil)
+}
+
+func completed[T any](v T) *future.Future[T] {
+	f := pending[T]()
+	f.EnqueueMessage(v)
+	return f
+}
+
+func TestMapFlatMapRecover(t *testing.T) {
+	mapped := futures.Map[int, string](completed(42), func(v int) (string, error) {
+		return strconv.Itoa(v), nil
+	})
+	result, err := mapped.Result()
+	require.NoError(t, err)
+	assert.Equal(t, "42", result)
+
+	_, err = futures.Map[int, string](future.NewFutureFail[int](errBroken), func(v int) (string, error) {
+		t.Fatal("map must not run on failure")
+		return "", nil
+	}).Result()
+	assert.ErrorIs(t, err, errBroken)
+
+	flat := futures.FlatMap[int, int](completed(1), func(v int) vivid.Future[int] {
+		return completed(v + 1)
+	})
+	n, err := flat.Result()
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	recovered := futures.Recover[int](future.NewFutureFail[int](errBroken), func(err error) (int, error) {
+		return -1, nil
+	})
+	n, err = recovered.Result()
+	require.NoError(t, err)
+	assert.Equal(t, -1, n)
+
+	_, err = futures.As[string, vivid.Message](completed[vivid.Message](42)).Result()
+	assert.ErrorIs(t, err, vivid.ErrorFutureMessageTypeMismatch)
+}
+
+func TestSequenceFirstCompletedRace(t *testing.T) {
+	a, b := pending[int](), pending[int]()
+	all := futures.Sequence[int](a, b)
+	b.EnqueueMessage(2)
+	a.EnqueueMessage(1)
+	results, err := all.Result()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, results)
+
+	_, err = futures.Sequence[int](completed(1), future.NewFutureFail[int](errBroken)).Result()
+	assert.ErrorIs(t, err, errBroken)
+
+	slow := pending[int]()
+	_, err = futures.FirstCompleted[int](slow, future.NewFutureFail[int](errBroken)).Result()
+	assert.ErrorIs(t, err, errBroken)
+
+	n, err := futures.Race[int](future.NewFutureFail[int](errBroken), completed(7)).Result()
+	require.NoError(t, err)
+	assert.Equal(t, 7, n)
+
+	errOther := errors.New("other")
+	_, err = futures.Race[int](future.NewFutureFail[int](errBroken), future.NewFutureFail[int](errOther)).Result()
+	assert.ErrorIs(t, err, errBroken)
+	assert.ErrorIs(t, err, errOther)
+}
+
+func TestWithTimeout(t *testing.T) {
+	source := pending[int]()
+	_, err := futures.WithTimeout[int](source, 20*time.Millisecond).Result()
+	assert.ErrorIs(t, err, vivid.ErrorFutureTimeout)
+
+	source.EnqueueMessage(1)
+	n, err := futures.WithTimeout[int](source, time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestWithTimeout_UsesSourceClock(t *testing.T) {
+	clock := vividtest.NewVirtualClock(time.Unix(0, 0))
+
+	source := future.NewFuture[int](nil, clock, 0, nil)
+	timed := futures.WithTimeout[int](source, 20*time.Millisecond)
+	time.Sleep(60 * time.Millisecond) // 真实时间流逝不应触发超时
+	source.EnqueueMessage(1)
+	n, err := timed.Result()
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	timed = futures.WithTimeout[int](future.NewFuture[int](nil, clock, 0, nil), time.Second)
+	clock.Advance(time.Second)
+	_, err = timed.Result()
+	assert.ErrorIs(t, err, vivid.ErrorFutureTimeout)
+}
+
+func TestCombinators_NoGoroutinePerPendingSource(t *testing.T) {
+	const n = 200
+	before := runtime.NumGoroutine()
+	sources := make([]vivid.Future[int], n)
+	for i := range sources {
+		sources[i] = pending[int]()
+		futures.WithTimeout(sources[i], time.Hour)
+	}
+	futures.Race(sources...)
+	futures.Sequence(sources...)
+	futures.FirstCompleted(sources...)
+	assert.Less(t, runtime.NumGoroutine()-before, n/2)
+
+	for _, f := range sources {
+		f.Close(errBroken)
+	}
+}
+
+func TestOnComplete(t *testing.T) {
+	system := vividtest.NewActorSystem(t)
+	probe := vividtest.NewTestProbe(t, system)
+
+	echo, err := system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+		if n, ok := ctx.Message().(int); ok {
+			ctx.Reply(n * 2)
+		}
+	}))
+	require.NoError(t, err)
+
+	var state int
+	_, err = system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+		if futures.Handle(ctx) {
+			return
+		}
+		if _, ok := ctx.Message().(*vivid.OnLaunch); ok {
+			reply := futures.As[int](ctx.Ask(echo, 21))
+			futures.OnComplete(ctx, reply, func(ctx vivid.ActorContext, n int, err error) {
+				state = n // 回调在 Actor 内执行，可直接修改状态
+				ctx.Tell(probe.Ref(), state)
+			})
+		}
+	}))
+	require.NoError(t, err)
+	probe.ExpectMessage(42)
+}
+
+func TestOnComplete_WithoutHandle(t *testing.T) {
+	system := vividtest.NewActorSystem(t)
+	probe := vividtest.NewTestProbe(t, system)
+
+	_, err := system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+		switch ctx.Message().(type) {
+		case *vivid.OnLaunch:
+			futures.OnComplete(ctx, completed(7), func(ctx vivid.ActorContext, n int, err error) {
+				ctx.Tell(probe.Ref(), n)
+			})
+		default:
+			// 未调用 Handle，回调消息作为普通消息到达且回调不会执行
+			ctx.Tell(probe.Ref(), "unhandled")
+		}
+	}))
+	require.NoError(t, err)
+	probe.ExpectMessage("unhandled")
+	probe.ExpectNoMessage(50 * time.Millisecond)
+}
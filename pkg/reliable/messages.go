@@ -0,0 +1,65 @@
+// Package reliable 提供基于确认与重投的至少一次（at-least-once）可靠投递。
+//
+// 发送方 Actor 使用 Sender 为消息分配序号并保存未确认消息，按间隔重投直到收到确认；
+// 接收方 Actor 使用 Receiver 确认收到的消息并按序号去重。本地与远程 ActorRef 均可使用。
+package reliable
+
+import (
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/messages"
+)
+
+func init() {
+	messages.RegisterInternalMessage[*Envelope]("reliableEnvelope", envelopeReader, envelopeWriter)
+	messages.RegisterInternalMessage[*Ack]("reliableAck", ackReader, ackWriter)
+}
+
+// Envelope 可靠投递的消息封装，由 Sender 发出、Receiver 解封。
+type Envelope struct {
+	ProducerID string        // 发送方标识，接收方按其分别去重
+	Seq        uint64        // 消息序号，同一发送方内单调递增
+	Watermark  uint64        // 发送方已确认的连续最大序号，接收方据此清理去重记录
+	Message    vivid.Message // 业务消息
+}
+
+// Ack 接收方对 Envelope 的确认，回复给 Envelope 的发送者。
+type Ack struct {
+	ProducerID string
+	Seq        uint64
+}
+
+// redeliverTick 触发 Sender 重投的定时消息，仅本地使用。
+type redeliverTick struct {
+	producerID string
+}
+
+func envelopeReader(message any, reader *messages.Reader, codec messages.Codec) error {
+	m := message.(*Envelope)
+	if err := reader.ReadInto(&m.ProducerID, &m.Seq, &m.Watermark); err != nil {
+		return err
+	}
+	msg, err := reader.ReadMessage(codec)
+	if err != nil {
+		return err
+	}
+	m.Message = msg
+	return nil
+}
+
+func envelopeWriter(message any, writer *messages.Writer, codec messages.Codec) error {
+	m := message.(*Envelope)
+	if err := writer.WriteFrom(m.ProducerID, m.Seq, m.Watermark); err != nil {
+		return err
+	}
+	return writer.WriteMessage(m.Message, codec)
+}
+
+func ackReader(message any, reader *messages.Reader, codec messages.Codec) error {
+	m := message.(*Ack)
+	return reader.ReadInto(&m.ProducerID, &m.Seq)
+}
+
+func ackWriter(message any, writer *messages.Writer, codec messages.Codec) error {
+	m := message.(*Ack)
+	return writer.WriteFrom(m.ProducerID, m.Seq)
+}
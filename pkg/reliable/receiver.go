@@ -0,0 +1,80 @@
+package reliable
+
+import (
+	"github.com/kercylan98/vivid"
+)
+
+// Receiver 可靠投递接收方，嵌入接收方 Actor 使用，非并发安全，仅可在所属 Actor 的 OnReceive 中调用。
+// 按发送方标识分别记录已处理的序号，确认每条收到的 Envelope（包括重复的），仅首次收到时交由业务处理，且在处理完成后才确认。
+type Receiver struct {
+	producers map[string]*producerState
+}
+
+// producerState 单个发送方的去重记录：序号不大于 watermark 的消息均已处理，seen 记录其上已处理的序号。
+type producerState struct {
+	watermark uint64
+	seen      map[uint64]struct{}
+}
+
+// NewReceiver 创建可靠投递接收方。
+func NewReceiver() *Receiver {
+	return &Receiver{producers: make(map[string]*producerState)}
+}
+
+// Receive 处理当前消息，应在 OnReceive 开头对每条消息调用。
+// 当前消息为 *Envelope 时返回 true：首次收到的内层业务消息交由 handler 处理，handler 正常返回后才记录序号并向发送方回复 *Ack；
+// 重复投递直接确认而不调用 handler。其他消息返回 false，由调用方继续处理。
+//
+// handler 发生 panic 时既不确认也不记录序号，发送方会重投该消息，因此业务处理至少执行一次。
+func (r *Receiver) Receive(ctx vivid.ActorContext, handler func(message vivid.Message)) bool {
+	envelope, isEnvelope := ctx.Message().(*Envelope)
+	if !isEnvelope {
+		return false
+	}
+	if !r.processed(envelope) {
+		handler(envelope.Message)
+		r.record(envelope)
+	}
+	ctx.Reply(&Ack{ProducerID: envelope.ProducerID, Seq: envelope.Seq})
+	return true
+}
+
+// processed 按 envelope 携带的 Watermark 推进去重记录，返回其序号是否已处理。
+func (r *Receiver) processed(envelope *Envelope) bool {
+	state := r.state(envelope.ProducerID)
+	if envelope.Watermark > state.watermark {
+		state.watermark = envelope.Watermark
+		for seq := range state.seen {
+			if seq <= state.watermark {
+				delete(state.seen, seq)
+			}
+		}
+	}
+	if envelope.Seq <= state.watermark {
+		return true
+	}
+	_, ok := state.seen[envelope.Seq]
+	return ok
+}
+
+// record 将 envelope 的序号记为已处理。
+func (r *Receiver) record(envelope *Envelope) {
+	state := r.state(envelope.ProducerID)
+	state.seen[envelope.Seq] = struct{}{}
+	for {
+		if _, ok := state.seen[state.watermark+1]; !ok {
+			break
+		}
+		state.watermark++
+		delete(state.seen, state.watermark)
+	}
+}
+
+func (r *Receiver) state(producerID string) *producerState {
+	state := r.producers[producerID]
+	if state == nil {
+		state = &producerState{seen: make(map[uint64]struct{})}
+		r.producers[producerID] = state
+	}
+	return state
+}
@@ -0,0 +1,275 @@
+package reliable_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/messages"
+	"github.com/kercylan98/vivid/pkg/bootstrap"
+	"github.com/kercylan98/vivid/pkg/reliable"
+	"github.com/kercylan98/vivid/pkg/vividtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	vivid.RegisterCustomMessage[*textMessage]("ReliableTextMessage",
+		func(message any, reader *messages.Reader, codec messages.Codec) error {
+			return reader.ReadInto(&message.(*textMessage).Text)
+		},
+		func(message any, writer *messages.Writer, codec messages.Codec) error {
+			return writer.WriteFrom(message.(*textMessage).Text)
+		},
+	)
+}
+
+type textMessage struct {
+	Text string
+}
+
+type unconfirmedRequest struct{}
+
+type deliverRequest struct {
+	destination vivid.ActorRef
+	text        string
+}
+
+// newSenderActor 收到 deliverRequest 时经 sender 可靠投递，收到 unconfirmedRequest 时回复未确认消息数。
+func newSenderActor(sender *reliable.Sender) vivid.Actor {
+	return vivid.ActorFN(func(ctx vivid.ActorContext) {
+		if sender.Handle(ctx) {
+			return
+		}
+		switch m := ctx.Message().(type) {
+		case *deliverRequest:
+			_, err := sender.Deliver(ctx, m.destination, &textMessage{Text: m.text})
+			ctx.Reply(err)
+		case *unconfirmedRequest:
+			ctx.Reply(sender.Unconfirmed())
+		}
+	})
+}
+
+// newReceiverActor 丢弃前 drop 条 Envelope 以模拟消息丢失，之后将去重后的文本写入 received。
+func newReceiverActor(drop int, received chan<- string) vivid.Actor {
+	receiver := reliable.NewReceiver()
+	return vivid.ActorFN(func(ctx vivid.ActorContext) {
+		if _, ok := ctx.Message().(*reliable.Envelope); ok && drop > 0 {
+			drop--
+			return
+		}
+		receiver.Receive(ctx, func(message vivid.Message) {
+			if m, isText := message.(*textMessage); isText {
+				received <- m.Text
+			}
+		})
+	})
+}
+
+func TestSender_RedeliverUntilConfirmed(t *testing.T) {
+	system := bootstrap.NewActorSystem()
+	require.NoError(t, system.Start())
+	defer func() { assert.NoError(t, system.Stop()) }()
+
+	received := make(chan string, 4)
+	receiverRef, err := system.ActorOf(newReceiverActor(2, received))
+	require.NoError(t, err)
+
+	sender := reliable.NewSender(reliable.WithRedeliverInterval(50 * time.Millisecond))
+	senderRef, err := system.ActorOf(newSenderActor(sender))
+	require.NoError(t, err)
+
+	_, err = system.Ask(senderRef, &deliverRequest{destination: receiverRef, text: "hello"}).Result()
+	require.NoError(t, err)
+
+	select {
+	case text := <-received:
+		assert.Equal(t, "hello", text)
+	case <-time.After(2 * time.Second):
+		t.Fatal("message not redelivered")
+	}
+	assert.Eventually(t, func() bool {
+		n, err := system.Ask(senderRef, &unconfirmedRequest{}).Result()
+		return err == nil && n == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSender_RedeliverUsesSystemClock(t *testing.T) {
+	clock := vividtest.NewVirtualClock(time.Unix(0, 0))
+	system := bootstrap.NewActorSystem(vivid.WithActorSystemClock(clock))
+	require.NoError(t, system.Start())
+	defer func() { assert.NoError(t, system.Stop()) }()
+
+	received := make(chan string, 4)
+	receiverRef, err := system.ActorOf(newReceiverActor(1, received))
+	require.NoError(t, err)
+
+	sender := reliable.NewSender(reliable.WithRedeliverInterval(time.Hour))
+	senderRef, err := system.ActorOf(newSenderActor(sender))
+	require.NoError(t, err)
+
+	_, err = system.Ask(senderRef, &deliverRequest{destination: receiverRef, text: "hello"}).Result()
+	require.NoError(t, err)
+
+	// 仅推进虚拟时钟，重投间隔按系统时钟计算
+	clock.Advance(time.Hour)
+	select {
+	case text := <-received:
+		assert.Equal(t, "hello", text)
+	case <-time.After(2 * time.Second):
+		t.Fatal("message not redelivered")
+	}
+}
+
+func TestReceiver_ConfirmAfterHandler(t *testing.T) {
+	system := bootstrap.NewActorSystem(vivid.WithActorSystemSupervisionStrategy(vivid.OneForOneStrategy(
+		vivid.SupervisionStrategyDecisionMakerFN(func(ctx vivid.SupervisionContext) (vivid.SupervisionDecision, string) {
+			return vivid.SupervisionDecisionRestart, "restart receiver"
+		}),
+	)))
+	require.NoError(t, system.Start())
+	defer func() { assert.NoError(t, system.Stop()) }()
+
+	// 首次处理时崩溃，确认不应发出，消息须经重投再次交由 handler
+	received := make(chan string, 4)
+	receiver := reliable.NewReceiver()
+	crashed := false
+	receiverRef, err := system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+		receiver.Receive(ctx, func(message vivid.Message) {
+			if !crashed {
+				crashed = true
+				panic("crash while handling")
+			}
+			received <- message.(*textMessage).Text
+		})
+	}))
+	require.NoError(t, err)
+
+	sender := reliable.NewSender(reliable.WithRedeliverInterval(50 * time.Millisecond))
+	senderRef, err := system.ActorOf(newSenderActor(sender))
+	require.NoError(t, err)
+
+	_, err = system.Ask(senderRef, &deliverRequest{destination: receiverRef, text: "hello"}).Result()
+	require.NoError(t, err)
+
+	select {
+	case text := <-received:
+		assert.Equal(t, "hello", text)
+	case <-time.After(2 * time.Second):
+		t.Fatal("message lost after handler crash")
+	}
+	assert.Eventually(t, func() bool {
+		n, err := system.Ask(senderRef, &unconfirmedRequest{}).Result()
+		return err == nil && n == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestReceiver_Deduplicate(t *testing.T) {
+	system := bootstrap.NewActorSystem()
+	require.NoError(t, system.Start())
+	defer func() { assert.NoError(t, system.Stop()) }()
+
+	received := make(chan string, 4)
+	receiverRef, err := system.ActorOf(newReceiverActor(0, received))
+	require.NoError(t, err)
+
+	system.Tell(receiverRef, &reliable.Envelope{ProducerID: "p", Seq: 2, Message: &textMessage{Text: "2"}})
+	system.Tell(receiverRef, &reliable.Envelope{ProducerID: "p", Seq: 2, Message: &textMessage{Text: "2"}})
+	system.Tell(receiverRef, &reliable.Envelope{ProducerID: "p", Seq: 1, Message: &textMessage{Text: "1"}})
+	// 序号不大于 Watermark 的消息视为已处理
+	system.Tell(receiverRef, &reliable.Envelope{ProducerID: "q", Seq: 3, Watermark: 3, Message: &textMessage{Text: "q3"}})
+	system.Tell(receiverRef, &reliable.Envelope{ProducerID: "q", Seq: 4, Watermark: 3, Message: &textMessage{Text: "q4"}})
+
+	var texts []string
+	for range 3 {
+		select {
+		case text := <-received:
+			texts = append(texts, text)
+		case <-time.After(time.Second):
+			t.Fatal("message not received")
+		}
+	}
+	assert.Equal(t, []string{"2", "1", "q4"}, texts)
+	select {
+	case text := <-received:
+		t.Fatalf("unexpected duplicate %s", text)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSender_RecoverFromStore(t *testing.T) {
+	system := bootstrap.NewActorSystem()
+	require.NoError(t, system.Start())
+	defer func() { assert.NoError(t, system.Stop()) }()
+
+	received := make(chan string, 4)
+	receiverRef, err := system.ActorOf(newReceiverActor(0, received))
+	require.NoError(t, err)
+
+	store := reliable.NewMemoryStore()
+	require.NoError(t, store.Save(reliable.Delivery{Seq: 7, Destination: receiverRef, Message: &textMessage{Text: "recovered"}}))
+
+	sender := reliable.NewSender(reliable.WithStore(store), reliable.WithRedeliverInterval(50*time.Millisecond))
+	senderRef, err := system.ActorOf(newSenderActor(sender))
+	require.NoError(t, err)
+	assert.Equal(t, "recovered", <-received)
+
+	_, err = system.Ask(senderRef, &deliverRequest{destination: receiverRef, text: "next"}).Result()
+	require.NoError(t, err)
+	assert.Equal(t, "next", <-received)
+	assert.Eventually(t, func() bool {
+		deliveries, lastSeq, err := store.Load()
+		return err == nil && len(deliveries) == 0 && lastSeq == 8
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSender_BufferFull(t *testing.T) {
+	system := bootstrap.NewActorSystem()
+	require.NoError(t, system.Start())
+	defer func() { assert.NoError(t, system.Stop()) }()
+
+	blackhole, err := system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {}))
+	require.NoError(t, err)
+	senderRef, err := system.ActorOf(newSenderActor(reliable.NewSender(reliable.WithMaxUnconfirmed(1))))
+	require.NoError(t, err)
+
+	_, err = system.Ask(senderRef, &deliverRequest{destination: blackhole, text: "1"}).Result()
+	require.NoError(t, err)
+	_, err = system.Ask(senderRef, &deliverRequest{destination: blackhole, text: "2"}).Result()
+	assert.ErrorIs(t, err, vivid.ErrorReliableDeliveryBufferFull)
+}
+
+func TestSender_Remote(t *testing.T) {
+	nodeA := bootstrap.NewActorSystem(vivid.WithActorSystemRemoting("127.0.0.1:17160"))
+	nodeB := bootstrap.NewActorSystem(vivid.WithActorSystemRemoting("127.0.0.1:17161"))
+	require.NoError(t, nodeA.Start())
+	require.NoError(t, nodeB.Start())
+	defer func() {
+		assert.NoError(t, nodeA.Stop())
+		assert.NoError(t, nodeB.Stop())
+	}()
+
+	received := make(chan string, 4)
+	_, err := nodeB.ActorOf(newReceiverActor(1, received), vivid.WithActorName("receiver"))
+	require.NoError(t, err)
+	remoteRef, err := nodeA.CreateRef("127.0.0.1:17161", "/receiver")
+	require.NoError(t, err)
+
+	sender := reliable.NewSender(reliable.WithRedeliverInterval(100 * time.Millisecond))
+	senderRef, err := nodeA.ActorOf(newSenderActor(sender))
+	require.NoError(t, err)
+	_, err = nodeA.Ask(senderRef, &deliverRequest{destination: remoteRef, text: "remote"}).Result()
+	require.NoError(t, err)
+
+	select {
+	case text := <-received:
+		assert.Equal(t, "remote", text)
+	case <-time.After(3 * time.Second):
+		t.Fatal("remote message not delivered")
+	}
+	assert.Eventually(t, func() bool {
+		n, err := nodeA.Ask(senderRef, &unconfirmedRequest{}).Result()
+		return err == nil && n == 0
+	}, 2*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,229 @@
+package reliable
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/pkg/log"
+)
+
+const (
+	// DefaultRedeliverInterval 默认重投间隔。
+	DefaultRedeliverInterval = 2 * time.Second
+	// DefaultMaxUnconfirmed 默认未确认消息数上限。
+	DefaultMaxUnconfirmed = 10000
+)
+
+// Options 可靠投递发送方配置。
+type Options struct {
+	// RedeliverInterval 重投间隔，消息发出后超过该时长未确认即重投；≤0 时使用 DefaultRedeliverInterval。
+	RedeliverInterval time.Duration
+	// MaxUnconfirmed 未确认消息数上限，达到上限后 Deliver 返回 ErrorReliableDeliveryBufferFull；≤0 时使用 DefaultMaxUnconfirmed。
+	MaxUnconfirmed int
+	// Store 未确认消息存储；为 nil 时使用 NewMemoryStore。
+	Store Store
+	// ProducerID 发送方标识，接收方按其去重；为空时随机生成。使用持久化 Store 时应指定固定值。
+	ProducerID string
+}
+
+// Option 是用于配置 Options 的函数类型。
+type Option = func(*Options)
+
+// WithRedeliverInterval 返回一个 Option，用于设置重投间隔。
+func WithRedeliverInterval(d time.Duration) Option {
+	return func(o *Options) {
+		o.RedeliverInterval = d
+	}
+}
+
+// WithMaxUnconfirmed 返回一个 Option，用于设置未确认消息数上限。
+func WithMaxUnconfirmed(n int) Option {
+	return func(o *Options) {
+		o.MaxUnconfirmed = n
+	}
+}
+
+// WithStore 返回一个 Option，用于设置未确认消息存储。
+func WithStore(store Store) Option {
+	return func(o *Options) {
+		o.Store = store
+	}
+}
+
+// WithProducerID 返回一个 Option，用于设置固定的发送方标识。
+func WithProducerID(id string) Option {
+	return func(o *Options) {
+		o.ProducerID = id
+	}
+}
+
+// Sender 可靠投递发送方，嵌入发送方 Actor 使用，非并发安全，仅可在所属 Actor 的 OnReceive 中调用。
+//
+// Deliver 为消息分配序号、写入 Store 并发出；未在 RedeliverInterval 内收到确认的消息由定时器重投，
+// 直到接收方以 Receiver 确认。Actor 须在 OnReceive 开头调用 Handle，以处理确认与重投定时消息。
+//
+// 重投可能导致接收方多次收到同一消息，由 Receiver 去重；远程目标不可达时，重投的发送会按 Remoting 的重连策略阻塞。
+type Sender struct {
+	options   Options
+	reference string               // 重投定时器的调度引用
+	loaded    bool                 // 是否已从 Store 恢复
+	lastSeq   uint64               // 已分配的最大序号
+	pending   map[uint64]Delivery  // 未确认投递
+	sentAt    map[uint64]time.Time // 最近一次发出时间
+}
+
+// NewSender 创建可靠投递发送方。
+func NewSender(opts ...Option) *Sender {
+	s := &Sender{
+		pending: make(map[uint64]Delivery),
+		sentAt:  make(map[uint64]time.Time),
+	}
+	for _, opt := range opts {
+		opt(&s.options)
+	}
+	if s.options.RedeliverInterval <= 0 {
+		s.options.RedeliverInterval = DefaultRedeliverInterval
+	}
+	if s.options.MaxUnconfirmed <= 0 {
+		s.options.MaxUnconfirmed = DefaultMaxUnconfirmed
+	}
+	if s.options.Store == nil {
+		s.options.Store = NewMemoryStore()
+	}
+	if s.options.ProducerID == "" {
+		s.options.ProducerID = uuid.NewString()
+	}
+	s.reference = "reliable-redeliver:" + s.options.ProducerID
+	return s
+}
+
+// ProducerID 返回发送方标识。
+func (s *Sender) ProducerID() string {
+	return s.options.ProducerID
+}
+
+// Unconfirmed 返回未确认消息数。
+func (s *Sender) Unconfirmed() int {
+	return len(s.pending)
+}
+
+// Deliver 向 destination 可靠投递 message，返回分配的序号。
+func (s *Sender) Deliver(ctx vivid.ActorContext, destination vivid.ActorRef, message vivid.Message) (uint64, error) {
+	if destination == nil {
+		return 0, vivid.ErrorRefEmpty
+	}
+	if message == nil {
+		return 0, vivid.ErrorIllegalArgument.WithMessage("message is nil")
+	}
+	if err := s.start(ctx); err != nil {
+		return 0, err
+	}
+	if len(s.pending) >= s.options.MaxUnconfirmed {
+		return 0, vivid.ErrorReliableDeliveryBufferFull
+	}
+	delivery := Delivery{Seq: s.lastSeq + 1, Destination: destination, Message: message}
+	if err := s.options.Store.Save(delivery); err != nil {
+		return 0, err
+	}
+	s.lastSeq = delivery.Seq
+	s.pending[delivery.Seq] = delivery
+	s.send(ctx, delivery)
+	return delivery.Seq, nil
+}
+
+// Handle 处理发送方相关的消息：确认与重投定时消息返回 true，调用方无需再处理；其他消息返回 false。
+// 首次调用时从 Store 恢复未确认消息并启动重投定时器，建议在 OnReceive 开头对每条消息调用。
+func (s *Sender) Handle(ctx vivid.ActorContext) bool {
+	if err := s.start(ctx); err != nil {
+		ctx.Logger().Warn("reliable sender: recover failed", log.String("producer", s.options.ProducerID), log.Any("err", err))
+	}
+	switch m := ctx.Message().(type) {
+	case *Ack:
+		if m.ProducerID != s.options.ProducerID {
+			return false
+		}
+		s.confirm(ctx, m.Seq)
+		return true
+	case *redeliverTick:
+		if m.producerID != s.options.ProducerID {
+			return false
+		}
+		s.redeliver(ctx)
+		return true
+	}
+	return false
+}
+
+// start 首次调用时从 Store 恢复，并确保重投定时器运行（Actor 重启会清空其调度任务）。
+func (s *Sender) start(ctx vivid.ActorContext) error {
+	if !s.loaded {
+		deliveries, lastSeq, err := s.options.Store.Load()
+		if err != nil {
+			return err
+		}
+		s.loaded = true
+		s.lastSeq = max(s.lastSeq, lastSeq)
+		for _, d := range deliveries {
+			s.pending[d.Seq] = d
+			s.lastSeq = max(s.lastSeq, d.Seq)
+		}
+	}
+	if !ctx.Scheduler().Exists(s.reference) {
+		return ctx.Scheduler().Loop(ctx.Ref(), s.options.RedeliverInterval, &redeliverTick{producerID: s.options.ProducerID},
+			vivid.WithSchedulerReference(s.reference))
+	}
+	return nil
+}
+
+func (s *Sender) confirm(ctx vivid.ActorContext, seq uint64) {
+	if _, ok := s.pending[seq]; !ok {
+		return
+	}
+	if err := s.options.Store.Delete(seq); err != nil {
+		ctx.Logger().Warn("reliable sender: delete confirmed delivery failed", log.String("producer", s.options.ProducerID), log.Any("err", err))
+	}
+	delete(s.pending, seq)
+	delete(s.sentAt, seq)
+}
+
+// redeliver 按序号顺序重投超过 RedeliverInterval 未确认的消息。
+func (s *Sender) redeliver(ctx vivid.ActorContext) {
+	if len(s.pending) == 0 {
+		return
+	}
+	seqs := make([]uint64, 0, len(s.pending))
+	for seq := range s.pending {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	deadline := ctx.Clock().Now().Add(-s.options.RedeliverInterval)
+	for _, seq := range seqs {
+		if sentAt, ok := s.sentAt[seq]; ok && sentAt.After(deadline) {
+			continue
+		}
+		s.send(ctx, s.pending[seq])
+	}
+}
+
+func (s *Sender) send(ctx vivid.ActorContext, delivery Delivery) {
+	s.sentAt[delivery.Seq] = ctx.Clock().Now()
+	ctx.Tell(delivery.Destination, &Envelope{
+		ProducerID: s.options.ProducerID,
+		Seq:        delivery.Seq,
+		Watermark:  s.watermark(),
+		Message:    delivery.Message,
+	})
+}
+
+// watermark 返回已确认的连续最大序号：最小未确认序号减一，无未确认消息时为已分配的最大序号。
+func (s *Sender) watermark() uint64 {
+	watermark := s.lastSeq
+	for seq := range s.pending {
+		if seq-1 < watermark {
+			watermark = seq - 1
+		}
+	}
+	return watermark
+}
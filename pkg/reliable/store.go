@@ -0,0 +1,64 @@
+package reliable
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/kercylan98/vivid"
+)
+
+// Delivery 一条未确认的投递。
+type Delivery struct {
+	Seq         uint64
+	Destination vivid.ActorRef
+	Message     vivid.Message
+}
+
+// Store 保存 Sender 的未确认投递，可替换为持久化实现以在发送方重启后继续重投。
+// 持久化实现需自行序列化 Destination（可使用 ActorRef.String() 与 ActorSystem.ParseRef）与 Message；
+// 同时应配合 WithProducerID 使用固定的发送方标识，否则重启后接收方无法识别重投的旧消息。
+type Store interface {
+	// Save 保存一条新投递。
+	Save(delivery Delivery) error
+	// Delete 删除已确认的投递，不存在时不报错。
+	Delete(seq uint64) error
+	// Load 按 Seq 升序返回全部未确认投递，以及曾保存过的最大序号（已确认的也计入）。
+	Load() (deliveries []Delivery, lastSeq uint64, err error)
+}
+
+// NewMemoryStore 创建内存 Store，并发安全；进程退出后数据丢失。
+func NewMemoryStore() Store {
+	return &memoryStore{deliveries: make(map[uint64]Delivery)}
+}
+
+type memoryStore struct {
+	lock       sync.Mutex
+	deliveries map[uint64]Delivery
+	lastSeq    uint64
+}
+
+func (s *memoryStore) Save(delivery Delivery) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.deliveries[delivery.Seq] = delivery
+	s.lastSeq = max(s.lastSeq, delivery.Seq)
+	return nil
+}
+
+func (s *memoryStore) Delete(seq uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.deliveries, seq)
+	return nil
+}
+
+func (s *memoryStore) Load() ([]Delivery, uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	deliveries := make([]Delivery, 0, len(s.deliveries))
+	for _, d := range s.deliveries {
+		deliveries = append(deliveries, d)
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].Seq < deliveries[j].Seq })
+	return deliveries, s.lastSeq, nil
+}
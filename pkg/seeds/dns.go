@@ -0,0 +1,74 @@
+package seeds
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kercylan98/vivid"
+)
+
+// DNSLookup 为 DNS 源所需的查询能力，*net.Resolver 即满足该接口；测试中可替换为本地实现。
+type DNSLookup interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// NewDNSASource 返回按 A/AAAA 记录解析 host 的种子来源，每个地址与 port 组成 host:port；lookup 为 nil 时使用 net.DefaultResolver。
+func NewDNSASource(lookup DNSLookup, host string, port int) Source {
+	if lookup == nil {
+		lookup = net.DefaultResolver
+	}
+	return SourceFN(func(ctx context.Context) (Seeds, error) {
+		addrs, err := lookup.LookupHost(ctx, host)
+		if err != nil {
+			return Seeds{}, err
+		}
+		if len(addrs) == 0 {
+			return Seeds{}, vivid.ErrorNotFound.WithMessage("no A records for " + host)
+		}
+		seeds := make([]string, 0, len(addrs))
+		for _, addr := range addrs {
+			seeds = append(seeds, net.JoinHostPort(addr, strconv.Itoa(port)))
+		}
+		sort.Strings(seeds)
+		return Seeds{Seeds: seeds}, nil
+	})
+}
+
+// NewDNSSRVSource 返回按 SRV 记录（_service._proto.name）解析的种子来源，目标主机与记录端口组成 host:port；lookup 为 nil 时使用 net.DefaultResolver。
+func NewDNSSRVSource(lookup DNSLookup, service, proto, name string) Source {
+	if lookup == nil {
+		lookup = net.DefaultResolver
+	}
+	return SourceFN(func(ctx context.Context) (Seeds, error) {
+		_, records, err := lookup.LookupSRV(ctx, service, proto, name)
+		if err != nil {
+			return Seeds{}, err
+		}
+		if len(records) == 0 {
+			return Seeds{}, vivid.ErrorNotFound.WithMessage("no SRV records for " + name)
+		}
+		seeds := make([]string, 0, len(records))
+		for _, rec := range records {
+			if rec == nil {
+				continue
+			}
+			seeds = append(seeds, net.JoinHostPort(strings.TrimSuffix(rec.Target, "."), strconv.Itoa(int(rec.Port))))
+		}
+		sort.Strings(seeds)
+		return Seeds{Seeds: seeds}, nil
+	})
+}
+
+// NewDNSAResolver 创建按 A/AAAA 记录周期解析种子的 Resolver。
+func NewDNSAResolver(lookup DNSLookup, host string, port int, opts ...Option) (*Resolver, error) {
+	return NewResolver(NewDNSASource(lookup, host, port), opts...)
+}
+
+// NewDNSSRVResolver 创建按 SRV 记录周期解析种子的 Resolver。
+func NewDNSSRVResolver(lookup DNSLookup, service, proto, name string, opts ...Option) (*Resolver, error) {
+	return NewResolver(NewDNSSRVSource(lookup, service, proto, name), opts...)
+}
@@ -0,0 +1,61 @@
+package seeds
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileSource 监视种子文件：仅当修改时间或大小变化时重新解析，否则返回上次结果。
+type fileSource struct {
+	path    string
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	last    Seeds
+}
+
+// NewFileSource 返回从 JSON 或 YAML 文件读取种子的来源，按扩展名 .yaml/.yml 选择 YAML，其余按 JSON 解析。
+// 文件格式见 Seeds；文件未变化时不重复解析，文件被删除或格式错误时返回错误。
+func NewFileSource(path string) Source {
+	return &fileSource{path: path}
+}
+
+// NewFileResolver 创建监视种子文件并周期刷新的 Resolver。
+func NewFileResolver(path string, opts ...Option) (*Resolver, error) {
+	return NewResolver(NewFileSource(path), opts...)
+}
+
+func (f *fileSource) Fetch(ctx context.Context) (Seeds, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return Seeds{}, err
+	}
+	if !f.modTime.IsZero() && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.last, nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return Seeds{}, err
+	}
+	var seeds Seeds
+	switch strings.ToLower(filepath.Ext(f.path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &seeds)
+	default:
+		err = json.Unmarshal(data, &seeds)
+	}
+	if err != nil {
+		return Seeds{}, err
+	}
+	f.modTime, f.size, f.last = info.ModTime(), info.Size(), seeds
+	return seeds, nil
+}
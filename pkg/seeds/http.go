@@ -0,0 +1,46 @@
+package seeds
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/kercylan98/vivid"
+)
+
+// maxHTTPBodySize HTTP 端点响应体上限，防止异常响应耗尽内存。
+const maxHTTPBodySize = 1 << 20
+
+// NewHTTPSource 返回通过 GET url 获取种子的来源，响应体为 JSON 格式的 Seeds；client 为 nil 时使用 http.DefaultClient。
+// 非 2xx 状态码视为失败。
+func NewHTTPSource(client *http.Client, url string) Source {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return SourceFN(func(ctx context.Context) (Seeds, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return Seeds{}, err
+		}
+		req.Header.Set("Accept", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			return Seeds{}, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return Seeds{}, vivid.ErrorIllegalArgument.WithMessage("unexpected status " + resp.Status)
+		}
+		var seeds Seeds
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxHTTPBodySize)).Decode(&seeds); err != nil {
+			return Seeds{}, err
+		}
+		return seeds, nil
+	})
+}
+
+// NewHTTPResolver 创建周期从 HTTP 端点拉取种子的 Resolver。
+func NewHTTPResolver(client *http.Client, url string, opts ...Option) (*Resolver, error) {
+	return NewResolver(NewHTTPSource(client, url), opts...)
+}
@@ -0,0 +1,175 @@
+// Package seeds 提供内置的集群种子发现实现（DNS A/SRV、JSON/YAML 文件、HTTP 端点），
+// 均实现 vivid.SeedsResolver，可通过 vivid.WithClusterSeedsResolver 接入集群。
+package seeds
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+const (
+	// DefaultRefreshInterval 默认刷新间隔。
+	DefaultRefreshInterval = 30 * time.Second
+	// DefaultFetchTimeout 默认单次拉取超时。
+	DefaultFetchTimeout = 5 * time.Second
+)
+
+// Seeds 一次拉取得到的种子集合，字段与 ClusterOptions 的 Seeds/SeedsByDC 含义一致。
+// 同时作为文件与 HTTP 端点的 JSON/YAML 文档格式：{"seeds": [...], "seedsByDC": {"dc1": [...]}}。
+type Seeds struct {
+	Seeds     []string            `json:"seeds" yaml:"seeds"`
+	SeedsByDC map[string][]string `json:"seedsByDC" yaml:"seedsByDC"`
+}
+
+// Source 种子来源，由 Resolver 周期调用 Fetch 获取最新种子。
+type Source interface {
+	Fetch(ctx context.Context) (Seeds, error)
+}
+
+// SourceFN 是对 Source 的函数式适配器。
+type SourceFN func(ctx context.Context) (Seeds, error)
+
+// Fetch 调用函数本身。
+func (fn SourceFN) Fetch(ctx context.Context) (Seeds, error) {
+	return fn(ctx)
+}
+
+// Options 种子解析器配置。
+type Options struct {
+	// RefreshInterval 刷新间隔；≤0 时使用 DefaultRefreshInterval。
+	RefreshInterval time.Duration
+	// FetchTimeout 单次拉取超时；≤0 时使用 DefaultFetchTimeout。
+	FetchTimeout time.Duration
+	// OnError 周期刷新失败时的回调；失败时保留上一次成功的结果。
+	OnError func(err error)
+}
+
+// Option 是用于配置 Options 的函数类型。
+type Option = func(*Options)
+
+// WithRefreshInterval 返回一个 Option，用于设置刷新间隔。
+func WithRefreshInterval(d time.Duration) Option {
+	return func(o *Options) {
+		o.RefreshInterval = d
+	}
+}
+
+// WithFetchTimeout 返回一个 Option，用于设置单次拉取超时。
+func WithFetchTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		o.FetchTimeout = d
+	}
+}
+
+// WithOnError 返回一个 Option，用于设置周期刷新失败时的回调。
+func WithOnError(fn func(err error)) Option {
+	return func(o *Options) {
+		o.OnError = fn
+	}
+}
+
+var _ vivid.SeedsResolver = (*Resolver)(nil)
+
+// Resolver 周期从 Source 拉取种子并缓存，实现 vivid.SeedsResolver。
+// 刷新失败时保留上一次成功的结果并通过 OnError 通知，避免临时故障导致节点以空种子自举为独立集群。
+type Resolver struct {
+	source  Source
+	options Options
+	mu      sync.RWMutex
+	current Seeds
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewResolver 创建解析器并同步完成首次拉取；首次拉取失败时返回错误且不启动刷新。
+// 使用完毕后应调用 Close 停止后台刷新。
+func NewResolver(source Source, opts ...Option) (*Resolver, error) {
+	if source == nil {
+		return nil, vivid.ErrorIllegalArgument.WithMessage("seeds source is nil")
+	}
+	r := &Resolver{source: source}
+	for _, opt := range opts {
+		opt(&r.options)
+	}
+	if r.options.RefreshInterval <= 0 {
+		r.options.RefreshInterval = DefaultRefreshInterval
+	}
+	if r.options.FetchTimeout <= 0 {
+		r.options.FetchTimeout = DefaultFetchTimeout
+	}
+	if err := r.Refresh(context.Background()); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	go r.loop(ctx)
+	return r, nil
+}
+
+// Refresh 立即拉取一次种子；成功时替换缓存，失败时保留原缓存并返回错误。
+func (r *Resolver) Refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, r.options.FetchTimeout)
+	defer cancel()
+	seeds, err := r.source.Fetch(ctx)
+	if err != nil {
+		return vivid.ErrorClusterSeedsResolveFailed.With(err)
+	}
+	r.mu.Lock()
+	r.current = seeds.clone()
+	r.mu.Unlock()
+	return nil
+}
+
+// GetSeeds 返回最近一次成功拉取的种子地址列表（副本）。
+func (r *Resolver) GetSeeds() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string(nil), r.current.Seeds...)
+}
+
+// GetSeedsByDC 返回最近一次成功拉取的按 DC 分组种子（副本）。
+func (r *Resolver) GetSeedsByDC() map[string][]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current.clone().SeedsByDC
+}
+
+// Close 停止后台刷新并等待其退出，幂等。
+func (r *Resolver) Close() {
+	if r.cancel == nil {
+		return
+	}
+	r.cancel()
+	<-r.done
+}
+
+func (r *Resolver) loop(ctx context.Context) {
+	defer close(r.done)
+	ticker := time.NewTicker(r.options.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil && ctx.Err() == nil && r.options.OnError != nil {
+				r.options.OnError(err)
+			}
+		}
+	}
+}
+
+func (s Seeds) clone() Seeds {
+	out := Seeds{Seeds: append([]string(nil), s.Seeds...)}
+	if s.SeedsByDC != nil {
+		out.SeedsByDC = make(map[string][]string, len(s.SeedsByDC))
+		for dc, addrs := range s.SeedsByDC {
+			out.SeedsByDC[dc] = append([]string(nil), addrs...)
+		}
+	}
+	return out
+}
@@ -0,0 +1,148 @@
+package seeds
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDNS struct {
+	hosts map[string][]string
+	srv   map[string][]*net.SRV
+}
+
+func (f *fakeDNS) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if addrs, ok := f.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func (f *fakeDNS) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	if records, ok := f.srv[name]; ok {
+		return "_" + service + "._" + proto + "." + name, records, nil
+	}
+	return "", nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func TestDNSAResolver(t *testing.T) {
+	dns := &fakeDNS{hosts: map[string][]string{"seeds.local": {"10.0.0.2", "10.0.0.1"}}}
+	r, err := NewDNSAResolver(dns, "seeds.local", 7070)
+	require.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, []string{"10.0.0.1:7070", "10.0.0.2:7070"}, r.GetSeeds())
+	assert.Empty(t, r.GetSeedsByDC())
+
+	_, err = NewDNSAResolver(dns, "missing.local", 7070)
+	assert.ErrorIs(t, err, vivid.ErrorClusterSeedsResolveFailed)
+}
+
+func TestDNSSRVResolver(t *testing.T) {
+	dns := &fakeDNS{srv: map[string][]*net.SRV{"cluster.local": {
+		{Target: "node-b.cluster.local.", Port: 7071},
+		{Target: "node-a.cluster.local.", Port: 7070},
+	}}}
+	r, err := NewDNSSRVResolver(dns, "vivid", "tcp", "cluster.local")
+	require.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, []string{"node-a.cluster.local:7070", "node-b.cluster.local:7071"}, r.GetSeeds())
+}
+
+func TestFileResolver_JSONAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seeds.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"seeds":["127.0.0.1:7070"],"seedsByDC":{"dc1":["127.0.0.1:7071"]}}`), 0o644))
+	r, err := NewFileResolver(path, WithRefreshInterval(10*time.Millisecond))
+	require.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, []string{"127.0.0.1:7070"}, r.GetSeeds())
+	assert.Equal(t, map[string][]string{"dc1": {"127.0.0.1:7071"}}, r.GetSeedsByDC())
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"seeds":["127.0.0.1:7072","127.0.0.1:7073"]}`), 0o644))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+	assert.Eventually(t, func() bool { return len(r.GetSeeds()) == 2 }, time.Second, 10*time.Millisecond)
+}
+
+func TestFileResolver_YAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seeds.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("seeds:\n  - 127.0.0.1:7070\nseedsByDC:\n  dc1:\n    - 127.0.0.1:7071\n"), 0o644))
+	r, err := NewFileResolver(path)
+	require.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, []string{"127.0.0.1:7070"}, r.GetSeeds())
+	assert.Equal(t, []string{"127.0.0.1:7071"}, r.GetSeedsByDC()["dc1"])
+}
+
+func TestFileResolver_KeepLastOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seeds.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"seeds":["127.0.0.1:7070"]}`), 0o644))
+	errs := make(chan error, 16)
+	r, err := NewFileResolver(path, WithRefreshInterval(10*time.Millisecond), WithOnError(func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}))
+	require.NoError(t, err)
+	defer r.Close()
+
+	require.NoError(t, os.Remove(path))
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, vivid.ErrorClusterSeedsResolveFailed)
+	case <-time.After(time.Second):
+		t.Fatal("expected refresh error")
+	}
+	assert.Equal(t, []string{"127.0.0.1:7070"}, r.GetSeeds())
+}
+
+func TestHTTPResolver(t *testing.T) {
+	var fail atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if fail.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"seeds":["127.0.0.1:7070"],"seedsByDC":{"dc1":["127.0.0.1:7071"]}}`))
+	}))
+	defer server.Close()
+
+	r, err := NewHTTPResolver(server.Client(), server.URL)
+	require.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, []string{"127.0.0.1:7070"}, r.GetSeeds())
+	assert.Equal(t, []string{"127.0.0.1:7071"}, r.GetSeedsByDC()["dc1"])
+
+	fail.Store(true)
+	assert.ErrorIs(t, r.Refresh(context.Background()), vivid.ErrorClusterSeedsResolveFailed)
+	assert.Equal(t, []string{"127.0.0.1:7070"}, r.GetSeeds())
+}
+
+func TestResolver_ReturnsCopies(t *testing.T) {
+	r, err := NewResolver(SourceFN(func(ctx context.Context) (Seeds, error) {
+		return Seeds{Seeds: []string{"127.0.0.1:7070"}, SeedsByDC: map[string][]string{"dc1": {"127.0.0.1:7071"}}}, nil
+	}))
+	require.NoError(t, err)
+	defer r.Close()
+	r.GetSeeds()[0] = "mutated"
+	r.GetSeedsByDC()["dc1"][0] = "mutated"
+	assert.Equal(t, []string{"127.0.0.1:7070"}, r.GetSeeds())
+	assert.Equal(t, []string{"127.0.0.1:7071"}, r.GetSeedsByDC()["dc1"])
+}
+
+func TestResolver_NilSourceAndInitialError(t *testing.T) {
+	_, err := NewResolver(nil)
+	assert.ErrorIs(t, err, vivid.ErrorIllegalArgument)
+	_, err = NewResolver(SourceFN(func(ctx context.Context) (Seeds, error) { return Seeds{}, errors.New("boom") }))
+	assert.ErrorIs(t, err, vivid.ErrorClusterSeedsResolveFailed)
+}
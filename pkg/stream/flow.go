@@ -0,0 +1,67 @@
+package stream
+
+import (
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+// Flow 可复用的处理阶段序列，每个算子在物化时运行为一个独立的阶段 Actor。
+//
+// Flow 为值类型，每次追加算子都会返回新的 Flow，原 Flow 不受影响，可安全地在多条流之间共享。
+type Flow struct {
+	operators []func() operator
+}
+
+// NewFlow 创建不包含任何算子的 Flow。
+func NewFlow() Flow {
+	return Flow{}
+}
+
+// Via 返回依次经过 f 与 other 的 Flow。
+func (f Flow) Via(other Flow) Flow {
+	return f.append(other.operators...)
+}
+
+// Map 对每个元素应用 fn 并输出其结果。
+func (f Flow) Map(fn func(vivid.Message) vivid.Message) Flow {
+	return f.append(func() operator { return &mapOperator{fn: fn} })
+}
+
+// Filter 仅输出 predicate 返回 true 的元素。
+func (f Flow) Filter(predicate func(vivid.Message) bool) Flow {
+	return f.append(func() operator { return &filterOperator{predicate: predicate} })
+}
+
+// Grouped 每凑满 size 个元素输出一个 []vivid.Message，上游完成时输出不足 size 的残留分组；size ≤0 时按 1 处理。
+func (f Flow) Grouped(size int) Flow {
+	size = max(size, 1)
+	return f.append(func() operator { return &groupedOperator{size: size} })
+}
+
+// Throttle 限制每个 per 周期最多向下游输出 elements 个元素，超出部分在阶段内等待并向上游施加背压；elements ≤0 时按 1 处理。
+func (f Flow) Throttle(elements int, per time.Duration) Flow {
+	elements = max(elements, 1)
+	return f.append(func() operator { return &throttleOperator{elements: elements, per: per} })
+}
+
+// MapAsync 通过 ActorContext.Entrust 并发执行 fn，同时至多 parallelism 个任务，结果按输入顺序输出。
+// timeout 为单个任务的超时时间（0 表示不限制）；任一任务返回错误、panic 或超时都会使整个流失败。parallelism ≤0 时按 1 处理。
+func (f Flow) MapAsync(parallelism int, timeout time.Duration, fn func(vivid.Message) (vivid.Message, error)) Flow {
+	parallelism = max(parallelism, 1)
+	return f.append(func() operator {
+		return &mapAsyncOperator{
+			parallelism: parallelism,
+			timeout:     timeout,
+			fn:          fn,
+			results:     make(map[uint64]vivid.Message),
+		}
+	})
+}
+
+func (f Flow) append(operators ...func() operator) Flow {
+	merged := make([]func() operator, 0, len(f.operators)+len(operators))
+	merged = append(merged, f.operators...)
+	merged = append(merged, operators...)
+	return Flow{operators: merged}
+}
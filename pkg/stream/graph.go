@@ -0,0 +1,159 @@
+package stream
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+const (
+	// DefaultWindow 每个阶段默认的需求窗口，即向每个上游最多请求的未到达元素数。
+	DefaultWindow = 16
+)
+
+// RunOptions 流运行配置。
+type RunOptions struct {
+	// Window 每个阶段的需求窗口；≤0 时使用 DefaultWindow。
+	Window int
+}
+
+// RunOption 是用于配置 RunOptions 的函数类型。
+type RunOption = func(*RunOptions)
+
+// WithWindow 返回一个 RunOption，用于设置每个阶段的需求窗口。
+func WithWindow(n int) RunOption {
+	return func(o *RunOptions) {
+		o.Window = n
+	}
+}
+
+// Spawner 可创建 Actor 的对象，vivid.ActorSystem 与 vivid.ActorContext 均满足该接口。
+type Spawner interface {
+	ActorOf(actor vivid.Actor, options ...vivid.ActorOption) (vivid.ActorRef, error)
+}
+
+// Graph 已连接 Source 与 Sink 的可运行流。
+type Graph struct {
+	source Source
+	sink   Sink
+}
+
+// Run 在 spawner 下创建流的根 Actor，并由其物化全部阶段 Actor，返回跟踪流结束的 Completion。
+//
+// 各阶段为根 Actor 的子 Actor；Sink 完成或失败后根 Actor 随即终止，所有阶段一并回收。
+// 根 Actor 在流结束前被终止（如 ActorSystem 停止）时，Completion 以 ErrorStreamAborted 结束。
+func (g Graph) Run(spawner Spawner, opts ...RunOption) (*Completion, error) {
+	if g.source.materialize == nil || g.sink.newOperator == nil {
+		return nil, vivid.ErrorIllegalArgument.WithMessage("stream source or sink is empty")
+	}
+	options := RunOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.Window <= 0 {
+		options.Window = DefaultWindow
+	}
+	completion := &Completion{done: make(chan struct{})}
+	if _, err := spawner.ActorOf(&graphActor{graph: g, options: options, completion: completion}); err != nil {
+		return nil, err
+	}
+	return completion, nil
+}
+
+// graphActor 流的根 Actor，启动时物化全部阶段。
+type graphActor struct {
+	graph      Graph
+	options    RunOptions
+	completion *Completion
+}
+
+func (a *graphActor) OnReceive(ctx vivid.ActorContext) {
+	switch ctx.Message().(type) {
+	case *vivid.OnLaunch:
+		m := &materializer{ctx: ctx, window: a.options.Window}
+		o, err := a.graph.source.materialize(m)
+		if err == nil {
+			s := newStage(a.graph.sink.newOperator(), m.window, o)
+			s.completion = a.completion
+			_, err = m.spawn(s)
+		}
+		if err != nil {
+			a.completion.finish(err, nil)
+			ctx.Kill(ctx.Ref(), false)
+		}
+	case *vivid.OnKill:
+		a.completion.finish(vivid.ErrorStreamAborted, nil)
+	}
+}
+
+// materializer 在根 Actor 下创建阶段 Actor。
+type materializer struct {
+	ctx    vivid.ActorContext
+	window int
+}
+
+func (m *materializer) spawn(s *stage) (outlet, error) {
+	ref, err := m.ctx.ActorOf(s)
+	if err != nil {
+		return outlet{}, err
+	}
+	return outlet{ref: ref}, nil
+}
+
+// Completion 跟踪一次流运行的结束，并发安全。
+type Completion struct {
+	done     chan struct{}
+	once     sync.Once
+	err      error
+	elements []vivid.Message // Collect 收集的元素
+}
+
+// Done 返回流结束（完成、失败或中止）时关闭的通道。
+func (c *Completion) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err 返回流失败的原因；流未结束或正常完成时返回 nil。
+func (c *Completion) Err() error {
+	select {
+	case <-c.done:
+		return c.err
+	default:
+		return nil
+	}
+}
+
+// Wait 阻塞至流结束并返回 Err；timeout >0 且超时未结束时返回 ErrorFutureTimeout。
+func (c *Completion) Wait(timeout time.Duration) error {
+	if timeout <= 0 {
+		<-c.done
+		return c.err
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-c.done:
+		return c.err
+	case <-timer.C:
+		return vivid.ErrorFutureTimeout
+	}
+}
+
+// Elements 返回 Collect 收集的元素；流未结束或 Sink 不是 Collect 时返回 nil。
+func (c *Completion) Elements() []vivid.Message {
+	select {
+	case <-c.done:
+		return c.elements
+	default:
+		return nil
+	}
+}
+
+func (c *Completion) finish(err error, elements []vivid.Message) {
+	c.once.Do(func() {
+		c.err = err
+		c.elements = elements
+		close(c.done)
+	})
+}
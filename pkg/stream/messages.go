@@ -0,0 +1,34 @@
+// Package stream 提供基于 Actor 的响应式流：Source、Flow、Sink 三类阶段各自运行为独立的 Actor，
+// 阶段之间使用 pkg/flow 的需求信号协议传递元素，下游未授予需求时上游不会发送，从而实现端到端的背压。
+//
+// 典型用法：
+//
+//	completion, err := stream.FromSlice(1, 2, 3).
+//		Map(func(m vivid.Message) vivid.Message { return m.(int) * 2 }).
+//		To(stream.Collect()).
+//		Run(system)
+//	err = completion.Wait(time.Second)
+//	elements := completion.Elements()
+package stream
+
+import (
+	"github.com/kercylan98/vivid"
+)
+
+// complete 上游阶段已发送全部元素。
+type complete struct{}
+
+// failure 上游阶段失败，下游收到后以 err 终止整个流。
+type failure struct {
+	err error
+}
+
+// asyncResult MapAsync 任务的结果，seq 用于按输入顺序输出。
+type asyncResult struct {
+	seq     uint64
+	message vivid.Message
+	err     error
+}
+
+// throttleTick Throttle 阶段令牌补充的定时消息。
+type throttleTick struct{}
@@ -0,0 +1,155 @@
+package stream
+
+import (
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+type mapOperator struct {
+	passOperator
+	fn func(vivid.Message) vivid.Message
+}
+
+func (o *mapOperator) push(ctx vivid.ActorContext, s *stage, element vivid.Message) {
+	s.emit(o.fn(element))
+}
+
+type filterOperator struct {
+	passOperator
+	predicate func(vivid.Message) bool
+}
+
+func (o *filterOperator) push(ctx vivid.ActorContext, s *stage, element vivid.Message) {
+	if o.predicate(element) {
+		s.emit(element)
+	}
+}
+
+// groupedOperator 每凑满 size 个元素输出一个 []vivid.Message，上游完成时输出不足 size 的残留分组。
+type groupedOperator struct {
+	passOperator
+	size  int
+	group []vivid.Message
+}
+
+func (o *groupedOperator) push(ctx vivid.ActorContext, s *stage, element vivid.Message) {
+	o.group = append(o.group, element)
+	if len(o.group) >= o.size {
+		s.emit(o.group)
+		o.group = nil
+	}
+}
+
+func (o *groupedOperator) finish(ctx vivid.ActorContext, s *stage) {
+	if len(o.group) > 0 {
+		s.emit(o.group)
+		o.group = nil
+	}
+}
+
+// throttleReference Throttle 阶段令牌补充定时任务的调度引用。
+const throttleReference = "stream-throttle"
+
+// throttleOperator 每个 per 周期最多向下游输出 elements 个元素。令牌耗尽时以 per 为周期定时唤醒阶段继续输出，
+// 输出缓冲清空后取消定时任务；使用周期任务而非一次性任务，避免单次唤醒丢失导致阶段停滞。
+type throttleOperator struct {
+	passOperator
+	elements int
+	per      time.Duration
+	tokens   int
+	refilled time.Time
+}
+
+func (o *throttleOperator) allow(ctx vivid.ActorContext) bool {
+	now := ctx.Clock().Now()
+	if now.Sub(o.refilled) >= o.per {
+		o.tokens = o.elements
+		o.refilled = now
+	}
+	if o.tokens > 0 {
+		o.tokens--
+		return true
+	}
+	if !ctx.Scheduler().Exists(throttleReference) {
+		_ = ctx.Scheduler().Loop(ctx.Ref(), o.per, &throttleTick{}, vivid.WithSchedulerReference(throttleReference))
+	}
+	return false
+}
+
+func (o *throttleOperator) handle(ctx vivid.ActorContext, s *stage, message vivid.Message) {
+	if _, ok := message.(*throttleTick); ok && len(s.buffer) == 0 {
+		_ = ctx.Scheduler().Cancel(throttleReference)
+	}
+}
+
+// mapAsyncOperator 通过 Entrust 并发执行至多 parallelism 个任务，并按输入顺序输出结果。
+type mapAsyncOperator struct {
+	passOperator
+	parallelism int
+	timeout     time.Duration
+	fn          func(vivid.Message) (vivid.Message, error)
+	queue       []vivid.Message // 等待执行的元素
+	running     int
+	next        uint64                   // 下一个任务的序号
+	emitted     uint64                   // 下一个应输出的序号
+	results     map[uint64]vivid.Message // 已完成但尚未轮到输出的结果
+}
+
+func (o *mapAsyncOperator) push(ctx vivid.ActorContext, s *stage, element vivid.Message) {
+	o.queue = append(o.queue, element)
+	o.launch(ctx)
+}
+
+func (o *mapAsyncOperator) handle(ctx vivid.ActorContext, s *stage, message vivid.Message) {
+	pipeResult, ok := message.(*vivid.PipeResult)
+	if !ok {
+		return
+	}
+	if pipeResult.Error != nil {
+		s.fail(pipeResult.Error)
+		return
+	}
+	result, ok := pipeResult.Message.(*asyncResult)
+	if !ok {
+		return
+	}
+	if result.err != nil {
+		s.fail(result.err)
+		return
+	}
+	o.running--
+	o.results[result.seq] = result.message
+	for {
+		msg, exists := o.results[o.emitted]
+		if !exists {
+			break
+		}
+		delete(o.results, o.emitted)
+		o.emitted++
+		s.emit(msg)
+	}
+	o.launch(ctx)
+}
+
+func (o *mapAsyncOperator) busy() int {
+	return len(o.queue) + int(o.next-o.emitted)
+}
+
+func (o *mapAsyncOperator) launch(ctx vivid.ActorContext) {
+	for o.running < o.parallelism && len(o.queue) > 0 {
+		element := o.queue[0]
+		o.queue[0] = nil
+		o.queue = o.queue[1:]
+		seq, fn := o.next, o.fn
+		o.next++
+		o.running++
+		_ = ctx.Entrust(o.timeout, vivid.EntrustTaskFN(func() (vivid.Message, error) {
+			message, err := fn(element)
+			return &asyncResult{seq: seq, message: message, err: err}, nil
+		})).PipeTo(vivid.ActorRefs{ctx.Ref()})
+	}
+	if len(o.queue) == 0 {
+		o.queue = nil
+	}
+}
@@ -0,0 +1,59 @@
+package stream
+
+import (
+	"github.com/kercylan98/vivid"
+)
+
+// Sink 流的终点，以固定窗口向上游拉取元素并逐个消费。
+type Sink struct {
+	newOperator func() operator
+}
+
+// ForEach 对每个元素调用 fn，fn 在 Sink 阶段 Actor 中串行执行。
+func ForEach(fn func(vivid.Message)) Sink {
+	return Sink{newOperator: func() operator {
+		return &forEachOperator{fn: fn}
+	}}
+}
+
+// Collect 收集全部元素，流完成后可通过 Completion.Elements 获取。
+func Collect() Sink {
+	return Sink{newOperator: func() operator {
+		return &collectOperator{}
+	}}
+}
+
+// ToActorRef 将每个元素 Tell 给 ref。Tell 不等待 ref 处理，因此背压只作用到 Sink 为止，
+// ref 处理较慢时可在流中使用 Throttle 限速。
+func ToActorRef(ref vivid.ActorRef) Sink {
+	return Sink{newOperator: func() operator {
+		return &tellOperator{ref: ref}
+	}}
+}
+
+type forEachOperator struct {
+	passOperator
+	fn func(vivid.Message)
+}
+
+func (o *forEachOperator) push(ctx vivid.ActorContext, s *stage, element vivid.Message) {
+	o.fn(element)
+}
+
+type collectOperator struct {
+	passOperator
+	elements []vivid.Message
+}
+
+func (o *collectOperator) push(ctx vivid.ActorContext, s *stage, element vivid.Message) {
+	o.elements = append(o.elements, element)
+}
+
+type tellOperator struct {
+	passOperator
+	ref vivid.ActorRef
+}
+
+func (o *tellOperator) push(ctx vivid.ActorContext, s *stage, element vivid.Message) {
+	ctx.Tell(o.ref, element)
+}
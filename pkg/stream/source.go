@@ -0,0 +1,98 @@
+package stream
+
+import (
+	"slices"
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+// Source 流的源头，可经 Via 及各算子方法追加处理阶段，最终通过 To 连接 Sink 得到可运行的 Graph。
+//
+// Source 为值类型，仅描述流的结构；每次 Graph.Run 都会重新物化全部阶段 Actor。
+type Source struct {
+	materialize func(m *materializer) (outlet, error)
+}
+
+// FromSlice 创建依次输出 elements 的 Source，全部输出后完成。
+func FromSlice(elements ...vivid.Message) Source {
+	elements = slices.Clone(elements)
+	return Source{materialize: func(m *materializer) (outlet, error) {
+		s := newStage(passOperator{}, m.window)
+		s.buffer = slices.Clone(elements)
+		return m.spawn(s)
+	}}
+}
+
+// FromActorRef 以 ref 作为 Source：ref 须嵌入 flow.Producer，流按需求向其发送 *flow.Request 拉取元素，
+// 其 Offer 的消息即为流中的元素。ref 终止后 Source 完成。
+func FromActorRef(ref vivid.ActorRef) Source {
+	return Source{materialize: func(m *materializer) (outlet, error) {
+		if ref == nil {
+			return outlet{}, vivid.ErrorIllegalArgument.WithMessage("source ref is nil")
+		}
+		return outlet{ref: ref, external: true}, nil
+	}}
+}
+
+// Merge 将多个 Source 的元素合并为一个 Source，元素按到达顺序交错输出，全部 Source 完成后完成。
+func Merge(sources ...Source) Source {
+	sources = slices.Clone(sources)
+	return Source{materialize: func(m *materializer) (outlet, error) {
+		outlets := make([]outlet, 0, len(sources))
+		for _, source := range sources {
+			o, err := source.materialize(m)
+			if err != nil {
+				return outlet{}, err
+			}
+			outlets = append(outlets, o)
+		}
+		return m.spawn(newStage(passOperator{}, m.window, outlets...))
+	}}
+}
+
+// Via 返回经过 flow 处理后的 Source。
+func (s Source) Via(flow Flow) Source {
+	return Source{materialize: func(m *materializer) (outlet, error) {
+		o, err := s.materialize(m)
+		if err != nil {
+			return outlet{}, err
+		}
+		for _, newOperator := range flow.operators {
+			if o, err = m.spawn(newStage(newOperator(), m.window, o)); err != nil {
+				return outlet{}, err
+			}
+		}
+		return o, nil
+	}}
+}
+
+// Map 等价于 s.Via(NewFlow().Map(fn))。
+func (s Source) Map(fn func(vivid.Message) vivid.Message) Source {
+	return s.Via(NewFlow().Map(fn))
+}
+
+// Filter 等价于 s.Via(NewFlow().Filter(predicate))。
+func (s Source) Filter(predicate func(vivid.Message) bool) Source {
+	return s.Via(NewFlow().Filter(predicate))
+}
+
+// Grouped 等价于 s.Via(NewFlow().Grouped(size))。
+func (s Source) Grouped(size int) Source {
+	return s.Via(NewFlow().Grouped(size))
+}
+
+// Throttle 等价于 s.Via(NewFlow().Throttle(elements, per))。
+func (s Source) Throttle(elements int, per time.Duration) Source {
+	return s.Via(NewFlow().Throttle(elements, per))
+}
+
+// MapAsync 等价于 s.Via(NewFlow().MapAsync(parallelism, timeout, fn))。
+func (s Source) MapAsync(parallelism int, timeout time.Duration, fn func(vivid.Message) (vivid.Message, error)) Source {
+	return s.Via(NewFlow().MapAsync(parallelism, timeout, fn))
+}
+
+// To 连接 sink，返回可运行的 Graph。
+func (s Source) To(sink Sink) Graph {
+	return Graph{source: s, sink: sink}
+}
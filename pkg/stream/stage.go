@@ -0,0 +1,232 @@
+package stream
+
+import (
+	"fmt"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/pkg/flow"
+)
+
+// operator 阶段内的处理逻辑，仅在阶段 Actor 的 OnReceive 中调用。
+type operator interface {
+	// push 处理一条上游元素，通过 stage.emit 向下游输出。
+	push(ctx vivid.ActorContext, s *stage, element vivid.Message)
+	// finish 在全部上游完成后调用一次，用于输出残留元素。
+	finish(ctx vivid.ActorContext, s *stage)
+	// handle 处理阶段协议以外的消息（如异步结果、定时消息）。
+	handle(ctx vivid.ActorContext, s *stage, message vivid.Message)
+	// busy 返回算子内部尚未输出的元素数，大于 0 时阶段不会完成。
+	busy() int
+}
+
+// gate 可选接口，由限速类算子实现，返回 false 时暂停向下游输出。
+type gate interface {
+	allow(ctx vivid.ActorContext) bool
+}
+
+// passOperator 原样输出元素的算子，其他算子嵌入以复用默认实现。
+type passOperator struct{}
+
+func (passOperator) push(ctx vivid.ActorContext, s *stage, element vivid.Message) {
+	s.emit(element)
+}
+
+func (passOperator) finish(ctx vivid.ActorContext, s *stage) {}
+
+func (passOperator) handle(ctx vivid.ActorContext, s *stage, message vivid.Message) {}
+
+func (passOperator) busy() int {
+	return 0
+}
+
+// outlet 已物化阶段的输出端；external 表示由外部 Actor 提供（FromActorRef），需 Watch 其终止。
+type outlet struct {
+	ref      vivid.ActorRef
+	external bool
+}
+
+type upstream struct {
+	outlet
+	pending uint64 // 已请求但尚未收到的元素数
+	done    bool
+}
+
+// stage 流阶段 Actor，向上游以 *flow.Request 拉取元素，在下游授予的需求内以 *flow.Item 输出。
+//
+// 每个上游已请求未收到的元素数降到窗口一半及以下时补足窗口；输出缓冲与算子内部积压达到窗口时停止拉取，
+// 背压由此逐级传导至源头。completion 非 nil 时为 Sink 阶段，完成或失败时结束 Completion 并终止整个流。
+type stage struct {
+	op         operator
+	window     int
+	upstreams  []*upstream
+	downstream vivid.ActorRef
+	demand     uint64          // 下游剩余需求
+	buffer     []vivid.Message // 待输出元素
+	finishing  bool            // 全部上游已完成且已调用 op.finish
+	terminated bool
+	err        error
+	completion *Completion
+}
+
+func newStage(op operator, window int, outlets ...outlet) *stage {
+	s := &stage{op: op, window: window}
+	for _, o := range outlets {
+		s.upstreams = append(s.upstreams, &upstream{outlet: o})
+	}
+	return s
+}
+
+func (s *stage) OnReceive(ctx vivid.ActorContext) {
+	if s.terminated {
+		return
+	}
+	switch m := ctx.Message().(type) {
+	case *vivid.OnLaunch:
+		for _, u := range s.upstreams {
+			if u.external {
+				ctx.Watch(u.ref)
+			}
+		}
+	case *flow.Request:
+		if s.downstream == nil {
+			s.downstream = ctx.Sender()
+		}
+		s.demand += uint64(m.N)
+	case *flow.Item:
+		u := s.upstream(ctx.Sender())
+		if u == nil || u.done || s.err != nil {
+			return
+		}
+		if u.pending > 0 {
+			u.pending--
+		}
+		s.invoke(func() { s.op.push(ctx, s, m.Message) })
+	case *complete:
+		if u := s.upstream(ctx.Sender()); u != nil {
+			u.done = true
+		}
+	case *failure:
+		s.fail(m.err)
+	case *vivid.OnKilled:
+		// 外部上游终止后视为完成
+		if u := s.upstream(m.Ref); u != nil && u.external {
+			u.done = true
+		}
+	default:
+		s.invoke(func() { s.op.handle(ctx, s, m) })
+	}
+	s.advance(ctx)
+}
+
+// emit 将元素放入输出缓冲，由 advance 在需求内发送。
+func (s *stage) emit(element vivid.Message) {
+	s.buffer = append(s.buffer, element)
+}
+
+// fail 以 err 终止阶段，丢弃尚未输出的元素；仅首个错误生效。
+func (s *stage) fail(err error) {
+	if s.err == nil {
+		s.err = err
+		s.buffer = nil
+	}
+}
+
+func (s *stage) upstream(ref vivid.ActorRef) *upstream {
+	if ref == nil {
+		return nil
+	}
+	for _, u := range s.upstreams {
+		if u.ref.Equals(ref) {
+			return u
+		}
+	}
+	return nil
+}
+
+// invoke 调用算子并将 panic 转换为阶段失败，避免监督重启丢失流状态。
+func (s *stage) invoke(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.fail(vivid.ErrorStreamStageFailed.With(fmt.Errorf("%v", r)))
+		}
+	}()
+	fn()
+}
+
+func (s *stage) advance(ctx vivid.ActorContext) {
+	if s.err == nil && !s.finishing && s.upstreamsDone() {
+		s.finishing = true
+		s.invoke(func() { s.op.finish(ctx, s) })
+	}
+	if s.err == nil {
+		s.drain(ctx)
+		s.pull(ctx)
+	}
+	s.tryTerminate(ctx)
+}
+
+func (s *stage) upstreamsDone() bool {
+	for _, u := range s.upstreams {
+		if !u.done {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *stage) drain(ctx vivid.ActorContext) {
+	for len(s.buffer) > 0 && s.demand > 0 && s.downstream != nil {
+		if g, ok := s.op.(gate); ok && !g.allow(ctx) {
+			break
+		}
+		element := s.buffer[0]
+		s.buffer[0] = nil
+		s.buffer = s.buffer[1:]
+		s.demand--
+		ctx.Tell(s.downstream, &flow.Item{Message: element})
+	}
+	if len(s.buffer) == 0 {
+		s.buffer = nil
+	}
+}
+
+func (s *stage) pull(ctx vivid.ActorContext) {
+	if len(s.buffer)+s.op.busy() >= s.window {
+		return
+	}
+	window := uint64(s.window)
+	for _, u := range s.upstreams {
+		if u.done || u.pending > window/2 {
+			continue
+		}
+		n := window - u.pending
+		u.pending += n
+		ctx.Tell(u.ref, &flow.Request{N: uint32(n)})
+	}
+}
+
+// tryTerminate 在失败或全部元素输出后通知下游；下游尚未发出首次需求时延后到收到需求为止。
+func (s *stage) tryTerminate(ctx vivid.ActorContext) {
+	if s.err == nil && (!s.finishing || len(s.buffer) > 0 || s.op.busy() > 0) {
+		return
+	}
+	if s.completion != nil {
+		s.terminated = true
+		var elements []vivid.Message
+		if collector, ok := s.op.(*collectOperator); ok {
+			elements = collector.elements
+		}
+		s.completion.finish(s.err, elements)
+		ctx.Kill(ctx.Parent(), false)
+		return
+	}
+	if s.downstream == nil {
+		return
+	}
+	s.terminated = true
+	if s.err != nil {
+		ctx.Tell(s.downstream, &failure{err: s.err})
+	} else {
+		ctx.Tell(s.downstream, &complete{})
+	}
+}
@@ -0,0 +1,232 @@
+package stream_test
+
+import (
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/pkg/bootstrap"
+	"github.com/kercylan98/vivid/pkg/flow"
+	"github.com/kercylan98/vivid/pkg/stream"
+	"github.com/kercylan98/vivid/pkg/vividtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSystem(t *testing.T) vivid.ActorSystem {
+	t.Helper()
+	system := bootstrap.NewActorSystem()
+	require.NoError(t, system.Start())
+	t.Cleanup(func() { assert.NoError(t, system.Stop()) })
+	return system
+}
+
+func ints(n int) []vivid.Message {
+	elements := make([]vivid.Message, n)
+	for i := range elements {
+		elements[i] = i + 1
+	}
+	return elements
+}
+
+func TestGraph_Operators(t *testing.T) {
+	system := newSystem(t)
+
+	completion, err := stream.FromSlice(ints(10)...).
+		Map(func(m vivid.Message) vivid.Message { return m.(int) * 2 }).
+		Filter(func(m vivid.Message) bool { return m.(int)%4 == 0 }).
+		Grouped(2).
+		To(stream.Collect()).
+		Run(system)
+	require.NoError(t, err)
+	require.NoError(t, completion.Wait(time.Second))
+	assert.Equal(t, []vivid.Message{
+		[]vivid.Message{4, 8},
+		[]vivid.Message{12, 16},
+		[]vivid.Message{20},
+	}, completion.Elements())
+}
+
+func TestGraph_MapAsync(t *testing.T) {
+	system := newSystem(t)
+
+	var running, peak atomic.Int32
+	completion, err := stream.FromSlice(ints(20)...).
+		MapAsync(4, time.Second, func(m vivid.Message) (vivid.Message, error) {
+			peak.Store(max(peak.Load(), running.Add(1)))
+			defer running.Add(-1)
+			// 越靠前的元素耗时越长，验证输出仍按输入顺序
+			time.Sleep(time.Duration(20-m.(int)) * time.Millisecond)
+			return m.(int) * 10, nil
+		}).
+		To(stream.Collect()).
+		Run(system)
+	require.NoError(t, err)
+	require.NoError(t, completion.Wait(3*time.Second))
+
+	want := make([]vivid.Message, 20)
+	for i := range want {
+		want[i] = (i + 1) * 10
+	}
+	assert.Equal(t, want, completion.Elements())
+	assert.LessOrEqual(t, peak.Load(), int32(4))
+
+	failed := errors.New("failed")
+	completion, err = stream.FromSlice(ints(5)...).
+		MapAsync(2, time.Second, func(m vivid.Message) (vivid.Message, error) {
+			if m.(int) == 3 {
+				return nil, failed
+			}
+			return m, nil
+		}).
+		To(stream.Collect()).
+		Run(system)
+	require.NoError(t, err)
+	assert.ErrorIs(t, completion.Wait(time.Second), failed)
+}
+
+func TestGraph_Merge(t *testing.T) {
+	system := newSystem(t)
+
+	completion, err := stream.Merge(stream.FromSlice(1, 2, 3), stream.FromSlice(4, 5), stream.FromSlice()).
+		To(stream.Collect()).
+		Run(system)
+	require.NoError(t, err)
+	require.NoError(t, completion.Wait(time.Second))
+
+	elements := completion.Elements()
+	slices.SortFunc(elements, func(a, b vivid.Message) int { return a.(int) - b.(int) })
+	assert.Equal(t, []vivid.Message{1, 2, 3, 4, 5}, elements)
+}
+
+func TestGraph_Throttle(t *testing.T) {
+	system := newSystem(t)
+
+	start := time.Now()
+	completion, err := stream.FromSlice(ints(5)...).
+		Throttle(2, 100*time.Millisecond).
+		To(stream.Collect()).
+		Run(system)
+	require.NoError(t, err)
+	require.NoError(t, completion.Wait(2*time.Second))
+	assert.Equal(t, ints(5), completion.Elements())
+	// 2 + 2 + 1：至少跨越两个周期
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestGraph_ThrottleUsesSystemClock(t *testing.T) {
+	clock := vividtest.NewVirtualClock(time.Unix(0, 0))
+	system := bootstrap.NewActorSystem(vivid.WithActorSystemClock(clock))
+	require.NoError(t, system.Start())
+	t.Cleanup(func() { assert.NoError(t, system.Stop()) })
+
+	completion, err := stream.FromSlice(ints(5)...).
+		Throttle(2, time.Hour).
+		To(stream.Collect()).
+		Run(system)
+	require.NoError(t, err)
+
+	// 仅推进虚拟时钟，令牌按系统时钟补充：2 + 2 + 1 至少需要跨越两个周期
+	periods := 0
+	for done := false; !done && periods < 10; {
+		select {
+		case <-completion.Done():
+			done = true
+		case <-time.After(50 * time.Millisecond):
+			clock.Advance(time.Hour)
+			periods++
+		}
+	}
+	require.NoError(t, completion.Wait(2*time.Second))
+	assert.Equal(t, ints(5), completion.Elements())
+	assert.GreaterOrEqual(t, periods, 2)
+}
+
+func TestGraph_Backpressure(t *testing.T) {
+	system := newSystem(t)
+
+	var mapped atomic.Int32
+	gate := make(chan struct{})
+	completion, err := stream.FromSlice(ints(1000)...).
+		Map(func(m vivid.Message) vivid.Message {
+			mapped.Add(1)
+			return m
+		}).
+		To(stream.ForEach(func(vivid.Message) { <-gate })).
+		Run(system, stream.WithWindow(4))
+	require.NoError(t, err)
+
+	// Sink 阻塞时，Map 阶段处理的元素数受各阶段窗口限制，不会读完整个源
+	time.Sleep(100 * time.Millisecond)
+	assert.LessOrEqual(t, mapped.Load(), int32(16))
+
+	close(gate)
+	require.NoError(t, completion.Wait(2*time.Second))
+	assert.Equal(t, int32(1000), mapped.Load())
+}
+
+func TestGraph_StageFailed(t *testing.T) {
+	system := newSystem(t)
+
+	completion, err := stream.FromSlice(ints(3)...).
+		Map(func(m vivid.Message) vivid.Message {
+			if m.(int) == 2 {
+				panic("boom")
+			}
+			return m
+		}).
+		To(stream.Collect()).
+		Run(system)
+	require.NoError(t, err)
+	assert.ErrorIs(t, completion.Wait(time.Second), vivid.ErrorStreamStageFailed)
+}
+
+func TestGraph_ActorRef(t *testing.T) {
+	system := newSystem(t)
+
+	type offer struct{ n int }
+	producer := flow.NewProducer()
+	sourceRef, err := system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+		if producer.Handle(ctx) {
+			return
+		}
+		if m, ok := ctx.Message().(*offer); ok {
+			ctx.Reply(producer.Offer(ctx, m.n))
+		}
+	}))
+	require.NoError(t, err)
+
+	received := make(chan int, 8)
+	sinkRef, err := system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+		if n, ok := ctx.Message().(int); ok {
+			received <- n
+		}
+	}))
+	require.NoError(t, err)
+
+	completion, err := stream.FromActorRef(sourceRef).
+		Map(func(m vivid.Message) vivid.Message { return m.(int) + 100 }).
+		To(stream.ToActorRef(sinkRef)).
+		Run(system)
+	require.NoError(t, err)
+
+	for i := range 3 {
+		_, err = system.Ask(sourceRef, &offer{n: i}).Result()
+		require.NoError(t, err)
+	}
+	for want := range 3 {
+		select {
+		case n := <-received:
+			assert.Equal(t, want+100, n)
+		case <-time.After(time.Second):
+			t.Fatalf("element %d not received", want)
+		}
+	}
+
+	// 源 Actor 终止后流完成
+	system.Kill(sourceRef, false)
+	assert.NoError(t, completion.Wait(time.Second))
+}
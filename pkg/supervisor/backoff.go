@@ -0,0 +1,334 @@
+// Package supervisor 提供可复用的监督者 Actor。
+//
+// BackoffSupervisor 包装一个子 Actor，在其故障或停止后按指数退避重新创建，并将收到的消息转发给当前子 Actor，
+// 适用于持有数据库、消息中间件等外部连接、需要在连接中断后延迟重连的 Actor。
+package supervisor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/mailbox"
+	"github.com/kercylan98/vivid/internal/utils"
+	"github.com/kercylan98/vivid/pkg/log"
+	"github.com/kercylan98/vivid/pkg/ves"
+)
+
+var _ vivid.SupervisorActor = (*BackoffSupervisor)(nil)
+
+const (
+	// DefaultMinBackoff 默认首次重启延迟。
+	DefaultMinBackoff = 100 * time.Millisecond
+	// DefaultMaxBackoff 默认最大重启延迟。
+	DefaultMaxBackoff = 30 * time.Second
+	// DefaultFactor 默认退避因子。
+	DefaultFactor = 2.0
+	// DefaultChildName 默认子 Actor 名称。
+	DefaultChildName = "child"
+)
+
+// BackoffMode 决定子 Actor 在何种情况下被重新创建。
+type BackoffMode int8
+
+const (
+	// BackoffOnFailure 仅在子 Actor 故障时退避重启；子 Actor 主动停止时监督者随之停止。
+	BackoffOnFailure BackoffMode = iota
+	// BackoffOnStop 子 Actor 因任何原因终止（包括故障）时均退避重启。
+	BackoffOnStop
+)
+
+// String 返回退避模式的字符串表示。
+func (mode BackoffMode) String() string {
+	switch mode {
+	case BackoffOnFailure:
+		return "on-failure"
+	case BackoffOnStop:
+		return "on-stop"
+	default:
+		return fmt.Sprintf("unknown(%d)", mode)
+	}
+}
+
+// Options 退避监督者配置。
+type Options struct {
+	// Mode 重启模式，默认 BackoffOnFailure。
+	Mode BackoffMode
+	// MinBackoff 首次重启延迟；≤0 时使用 DefaultMinBackoff。
+	MinBackoff time.Duration
+	// MaxBackoff 最大重启延迟；≤0 时使用 DefaultMaxBackoff，小于 MinBackoff 时取 MinBackoff。
+	MaxBackoff time.Duration
+	// Factor 退避因子，每次重启延迟乘以该值；<1 时使用 DefaultFactor。
+	Factor float64
+	// Jitter 是否为重启延迟附加 ±25% 的随机抖动，避免大量监督者同时重连。
+	Jitter bool
+	// ResetAfter 子 Actor 连续运行超过该时长后终止时，退避延迟与重启计数从头计算；≤0 表示从不重置。
+	ResetAfter time.Duration
+	// MaxRetries 未被重置前允许的最大连续重启次数，超出后监督者停止；≤0 表示不限制。
+	MaxRetries int
+	// BufferSize 子 Actor 不可用期间缓冲的消息数上限，子 Actor 重新创建后按序转发；超出的消息投递至死信，0 表示不缓冲。
+	BufferSize int
+	// ChildName 子 Actor 名称；为空时使用 DefaultChildName。
+	ChildName string
+	// ChildOptions 创建子 Actor 时附加的配置项，其中的名称会被 ChildName 覆盖。
+	ChildOptions []vivid.ActorOption
+}
+
+// Option 是用于配置 Options 的函数类型。
+type Option = func(*Options)
+
+// WithMode 返回一个 Option，用于设置重启模式。
+func WithMode(mode BackoffMode) Option {
+	return func(o *Options) {
+		o.Mode = mode
+	}
+}
+
+// WithBackoff 返回一个 Option，用于设置首次与最大重启延迟。
+func WithBackoff(minBackoff, maxBackoff time.Duration) Option {
+	return func(o *Options) {
+		o.MinBackoff = minBackoff
+		o.MaxBackoff = maxBackoff
+	}
+}
+
+// WithFactor 返回一个 Option，用于设置退避因子。
+func WithFactor(factor float64) Option {
+	return func(o *Options) {
+		o.Factor = factor
+	}
+}
+
+// WithJitter 返回一个 Option，用于设置是否启用随机抖动。
+func WithJitter(jitter bool) Option {
+	return func(o *Options) {
+		o.Jitter = jitter
+	}
+}
+
+// WithResetAfter 返回一个 Option，用于设置子 Actor 稳定运行多久后重置退避。
+func WithResetAfter(d time.Duration) Option {
+	return func(o *Options) {
+		o.ResetAfter = d
+	}
+}
+
+// WithMaxRetries 返回一个 Option，用于设置最大连续重启次数。
+func WithMaxRetries(n int) Option {
+	return func(o *Options) {
+		o.MaxRetries = n
+	}
+}
+
+// WithBufferSize 返回一个 Option，用于设置子 Actor 不可用期间的消息缓冲上限。
+func WithBufferSize(n int) Option {
+	return func(o *Options) {
+		o.BufferSize = n
+	}
+}
+
+// WithChildName 返回一个 Option，用于设置子 Actor 名称。
+func WithChildName(name string) Option {
+	return func(o *Options) {
+		o.ChildName = name
+	}
+}
+
+// WithChildOptions 返回一个 Option，用于追加创建子 Actor 时的配置项。
+func WithChildOptions(options ...vivid.ActorOption) Option {
+	return func(o *Options) {
+		o.ChildOptions = append(o.ChildOptions, options...)
+	}
+}
+
+// GetChild 查询当前子 Actor，监督者以 *CurrentChild 回复。
+type GetChild struct{}
+
+// CurrentChild 对 GetChild 的回复。
+type CurrentChild struct {
+	Ref      vivid.ActorRef // 当前子 Actor，子 Actor 不可用时为 nil
+	Restarts int            // 自上次重置以来的重启次数
+}
+
+// restartChild 退避结束后重新创建子 Actor 的定时消息，仅本地使用。
+type restartChild struct {
+	generation uint64
+}
+
+// BackoffSupervisor 以指数退避重启子 Actor 的监督者。
+//
+// 监督者自行提供监督策略（vivid.SupervisorActor），子 Actor 故障时将其停止并在退避延迟后由 ActorProvider 创建新实例，
+// 因此不应再通过 WithActorSupervisionStrategy 为其指定策略。除 GetChild 外的用户消息连同原始 sender 转发给当前子 Actor，
+// 子 Actor 的 Sender 与 Reply 指向原始 sender，因此可经监督者 Ask 子 Actor；子 Actor 发给监督者的消息转发给监督者的父 Actor；子 Actor 不可用期间的消息按 BufferSize 缓冲或投递至死信。
+type BackoffSupervisor struct {
+	provider   vivid.ActorProvider
+	options    Options
+	strategy   vivid.SupervisionStrategy
+	backoff    *utils.ExponentialBackoff
+	child      vivid.ActorRef
+	startedAt  time.Time
+	failed     bool   // 当前子 Actor 是否因故障被停止
+	stopping   bool   // 监督者自身是否处于停止流程
+	generation uint64 // 用于忽略过期的 restartChild
+	buffer     []bufferedMessage
+}
+
+// bufferedMessage 子 Actor 不可用期间缓冲的消息。
+type bufferedMessage struct {
+	sender  vivid.ActorRef
+	message vivid.Message
+}
+
+// NewBackoffSupervisor 创建包装 provider 所提供子 Actor 的退避监督者。
+func NewBackoffSupervisor(provider vivid.ActorProvider, opts ...Option) *BackoffSupervisor {
+	s := &BackoffSupervisor{provider: provider}
+	for _, opt := range opts {
+		opt(&s.options)
+	}
+	if s.options.MinBackoff <= 0 {
+		s.options.MinBackoff = DefaultMinBackoff
+	}
+	if s.options.MaxBackoff <= 0 {
+		s.options.MaxBackoff = DefaultMaxBackoff
+	}
+	if s.options.MaxBackoff < s.options.MinBackoff {
+		s.options.MaxBackoff = s.options.MinBackoff
+	}
+	if s.options.Factor < 1 {
+		s.options.Factor = DefaultFactor
+	}
+	if s.options.ChildName == "" {
+		s.options.ChildName = DefaultChildName
+	}
+	s.backoff = utils.NewExponentialBackoff(s.options.MinBackoff, s.options.MaxBackoff, s.options.Factor, s.options.Jitter)
+	s.strategy = vivid.OneForOneStrategy(vivid.SupervisionStrategyDecisionMakerFN(s.makeDecision))
+	return s
+}
+
+// SupervisionStrategy 实现 vivid.SupervisorActor 接口。
+func (s *BackoffSupervisor) SupervisionStrategy() vivid.SupervisionStrategy {
+	return s.strategy
+}
+
+// makeDecision 子 Actor 故障时将其停止，由 OnKilled 触发退避重启；监督过程在监督者的消息处理中执行，可直接修改状态。
+func (s *BackoffSupervisor) makeDecision(ctx vivid.SupervisionContext) (vivid.SupervisionDecision, string) {
+	for _, child := range ctx.Child() {
+		if s.child != nil && child.Equals(s.child) {
+			s.failed = true
+		}
+	}
+	return vivid.SupervisionDecisionStop, "backoff supervisor: child failed"
+}
+
+func (s *BackoffSupervisor) OnReceive(ctx vivid.ActorContext) {
+	switch m := ctx.Message().(type) {
+	case *vivid.OnLaunch:
+		s.stopping = false
+		s.backoff.Reset()
+		s.spawn(ctx)
+	case *vivid.OnKill:
+		s.stopping = true
+	case *vivid.OnKilled:
+		s.onChildKilled(ctx, m)
+	case *restartChild:
+		if m.generation == s.generation && s.child == nil && !s.stopping {
+			s.spawn(ctx)
+		}
+	case *GetChild:
+		ctx.Reply(&CurrentChild{Ref: s.child, Restarts: s.backoff.GetAttempt()})
+	default:
+		s.forward(ctx, m)
+	}
+}
+
+func (s *BackoffSupervisor) spawn(ctx vivid.ActorContext) {
+	options := append(append([]vivid.ActorOption{}, s.options.ChildOptions...),
+		vivid.WithActorName(s.options.ChildName),
+		vivid.WithActorProvider(&childProvider{provider: s.provider}),
+	)
+	child, err := ctx.ActorOf(&childActor{actor: s.provider.Provide()}, options...)
+	if err != nil {
+		ctx.Logger().Warn("backoff supervisor: spawn child failed", log.String("path", ctx.Ref().GetPath()), log.Any("err", err))
+		s.scheduleRestart(ctx)
+		return
+	}
+	s.child = child
+	s.failed = false
+	s.startedAt = ctx.Clock().Now()
+
+	buffer := s.buffer
+	s.buffer = nil
+	for _, m := range buffer {
+		ctx.Tell(s.child, &forwardedMessage{sender: m.sender, message: m.message})
+	}
+}
+
+func (s *BackoffSupervisor) onChildKilled(ctx vivid.ActorContext, m *vivid.OnKilled) {
+	if s.child == nil || !m.Ref.Equals(s.child) {
+		return
+	}
+	failed := s.failed
+	s.child = nil
+	s.failed = false
+	if s.stopping {
+		return
+	}
+	if !failed && s.options.Mode == BackoffOnFailure {
+		ctx.Logger().Debug("backoff supervisor: child stopped", log.String("path", ctx.Ref().GetPath()))
+		s.stop(ctx, "backoff supervisor: child stopped")
+		return
+	}
+	if s.options.ResetAfter > 0 && ctx.Clock().Now().Sub(s.startedAt) >= s.options.ResetAfter {
+		s.backoff.Reset()
+	}
+	s.scheduleRestart(ctx)
+}
+
+func (s *BackoffSupervisor) scheduleRestart(ctx vivid.ActorContext) {
+	if s.options.MaxRetries > 0 && s.backoff.GetAttempt() >= s.options.MaxRetries {
+		ctx.Logger().Warn("backoff supervisor: max retries exceeded", log.String("path", ctx.Ref().GetPath()), log.Int("max_retries", s.options.MaxRetries))
+		s.stop(ctx, "backoff supervisor: max retries exceeded")
+		return
+	}
+	s.generation++
+	delay := s.backoff.Next()
+	ctx.Logger().Debug("backoff supervisor: schedule restart", log.String("path", ctx.Ref().GetPath()), log.Duration("delay", delay), log.Int("attempt", s.backoff.GetAttempt()))
+	if err := ctx.Scheduler().Once(ctx.Ref(), delay, &restartChild{generation: s.generation}); err != nil {
+		ctx.Logger().Warn("backoff supervisor: schedule restart failed", log.String("path", ctx.Ref().GetPath()), log.Any("err", err))
+		s.stop(ctx, "backoff supervisor: schedule restart failed")
+	}
+}
+
+func (s *BackoffSupervisor) stop(ctx vivid.ActorContext, reason string) {
+	s.stopping = true
+	for _, m := range s.buffer {
+		s.deadLetter(ctx, m.sender, m.message)
+	}
+	s.buffer = nil
+	ctx.Kill(ctx.Ref(), false, reason)
+}
+
+func (s *BackoffSupervisor) forward(ctx vivid.ActorContext, message vivid.Message) {
+	sender := ctx.Sender()
+	if s.child != nil && sender != nil && sender.Equals(s.child) {
+		ctx.Tell(ctx.Parent(), message)
+		return
+	}
+	if s.child != nil {
+		ctx.Tell(s.child, &forwardedMessage{sender: sender, message: message})
+		return
+	}
+	if len(s.buffer) < s.options.BufferSize {
+		s.buffer = append(s.buffer, bufferedMessage{sender: sender, message: message})
+		return
+	}
+	s.deadLetter(ctx, sender, message)
+}
+
+func (s *BackoffSupervisor) deadLetter(ctx vivid.ActorContext, sender vivid.ActorRef, message vivid.Message) {
+	ctx.Logger().Warn("backoff supervisor: child unavailable, message dropped", log.String("path", ctx.Ref().GetPath()), log.String("message_type", fmt.Sprintf("%T", message)))
+	ctx.EventStream().Publish(ctx, ves.DeathLetterEvent{
+		Envelope: mailbox.NewEnvelop(false, sender, ctx.Ref(), message),
+		Time:     ctx.Clock().Now(),
+	})
+}
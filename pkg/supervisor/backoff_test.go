@@ -0,0 +1,205 @@
+package supervisor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/pkg/supervisor"
+	"github.com/kercylan98/vivid/pkg/ves"
+	"github.com/kercylan98/vivid/pkg/vividtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type launched struct{}
+
+type crash struct{}
+
+type quit struct{}
+
+type echo struct{ text string }
+
+// newChildProvider 提供的子 Actor 启动时向 probe 报告 launched，收到 crash 时故障，收到 quit 时自行停止，收到 echo 时原样回复，其余消息原样转发给 probe。
+func newChildProvider(probe *vividtest.TestProbe) vivid.ActorProvider {
+	return vivid.ActorProviderFN(func() vivid.Actor {
+		return vivid.ActorFN(func(ctx vivid.ActorContext) {
+			switch ctx.Message().(type) {
+			case *vivid.OnLaunch:
+				ctx.Tell(probe.Ref(), &launched{})
+			case *crash:
+				panic("connection lost")
+			case *quit:
+				ctx.Kill(ctx.Ref(), false)
+			case *echo:
+				ctx.Reply(ctx.Message())
+			case *vivid.OnKill, *vivid.OnKilled:
+			default:
+				ctx.Tell(probe.Ref(), ctx.Message())
+			}
+		})
+	})
+}
+
+func currentChild(t *testing.T, system vivid.ActorSystem, ref vivid.ActorRef) *supervisor.CurrentChild {
+	t.Helper()
+	reply, err := system.Ask(ref, &supervisor.GetChild{}).Result()
+	require.NoError(t, err)
+	return reply.(*supervisor.CurrentChild)
+}
+
+func TestBackoffSupervisor_RestartOnFailure(t *testing.T) {
+	system := vividtest.NewActorSystem(t)
+	probe := vividtest.NewTestProbe(t, system)
+
+	ref, err := system.ActorOf(supervisor.NewBackoffSupervisor(newChildProvider(probe),
+		supervisor.WithBackoff(50*time.Millisecond, time.Second),
+	))
+	require.NoError(t, err)
+	vividtest.ExpectMessageType[*launched](probe)
+
+	start := time.Now()
+	system.Tell(ref, &crash{})
+	vividtest.ExpectMessageType[*launched](probe)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	start = time.Now()
+	system.Tell(ref, &crash{})
+	vividtest.ExpectMessageType[*launched](probe)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond, "second restart should back off exponentially")
+	assert.Equal(t, 2, currentChild(t, system, ref).Restarts)
+
+	system.Tell(ref, "ping")
+	probe.ExpectMessage("ping")
+
+	// BackoffOnFailure 模式下子 Actor 主动停止时监督者随之停止
+	probe.Watch(ref)
+	system.Tell(ref, &quit{})
+	probe.ExpectTerminated(ref)
+}
+
+func TestBackoffSupervisor_RestartOnStopWithBuffer(t *testing.T) {
+	system := vividtest.NewActorSystem(t)
+	probe := vividtest.NewTestProbe(t, system)
+
+	ref, err := system.ActorOf(supervisor.NewBackoffSupervisor(newChildProvider(probe),
+		supervisor.WithMode(supervisor.BackoffOnStop),
+		supervisor.WithBackoff(200*time.Millisecond, time.Second),
+		supervisor.WithBufferSize(2),
+	))
+	require.NoError(t, err)
+	vividtest.ExpectMessageType[*launched](probe)
+
+	deathLetters := vividtest.NewTestProbe(t, system)
+	_, err = system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+		switch m := ctx.Message().(type) {
+		case *vivid.OnLaunch:
+			ctx.EventStream().Subscribe(ctx, ves.DeathLetterEvent{})
+		case ves.DeathLetterEvent:
+			ctx.Tell(deathLetters.Ref(), m)
+		}
+	}))
+	require.NoError(t, err)
+
+	system.Tell(ref, &quit{})
+	assert.Eventually(t, func() bool {
+		return currentChild(t, system, ref).Ref == nil
+	}, time.Second, 10*time.Millisecond)
+	system.Tell(ref, "first")
+	system.Tell(ref, "second")
+	system.Tell(ref, "overflow")
+
+	event := vividtest.ExpectMessageType[ves.DeathLetterEvent](deathLetters)
+	assert.Equal(t, "overflow", event.Envelope.Message())
+
+	vividtest.ExpectMessageType[*launched](probe)
+	probe.ExpectMessage("first")
+	probe.ExpectMessage("second")
+	probe.ExpectNoMessage(50 * time.Millisecond)
+}
+
+func TestBackoffSupervisor_MaxRetriesAndReset(t *testing.T) {
+	system := vividtest.NewActorSystem(t)
+	probe := vividtest.NewTestProbe(t, system)
+
+	ref, err := system.ActorOf(supervisor.NewBackoffSupervisor(newChildProvider(probe),
+		supervisor.WithBackoff(10*time.Millisecond, 10*time.Millisecond),
+		supervisor.WithResetAfter(200*time.Millisecond),
+		supervisor.WithMaxRetries(1),
+	))
+	require.NoError(t, err)
+	vividtest.ExpectMessageType[*launched](probe)
+
+	system.Tell(ref, &crash{})
+	vividtest.ExpectMessageType[*launched](probe)
+	assert.Equal(t, 1, currentChild(t, system, ref).Restarts)
+
+	// 稳定运行超过 ResetAfter 后故障，重启计数被重置，不触发 MaxRetries
+	time.Sleep(250 * time.Millisecond)
+	system.Tell(ref, &crash{})
+	vividtest.ExpectMessageType[*launched](probe)
+	assert.Equal(t, 1, currentChild(t, system, ref).Restarts)
+
+	probe.Watch(ref)
+	system.Tell(ref, &crash{})
+	probe.ExpectTerminated(ref)
+}
+
+func TestBackoffSupervisor_ResetAfterUsesSystemClock(t *testing.T) {
+	clock := vividtest.NewVirtualClock(time.Unix(0, 0))
+	system := vividtest.NewActorSystem(t, vivid.WithActorSystemClock(clock))
+	probe := vividtest.NewTestProbe(t, system)
+
+	ref, err := system.ActorOf(supervisor.NewBackoffSupervisor(newChildProvider(probe),
+		supervisor.WithBackoff(10*time.Millisecond, 10*time.Millisecond),
+		supervisor.WithResetAfter(time.Hour),
+		supervisor.WithMaxRetries(1),
+	))
+	require.NoError(t, err)
+	vividtest.ExpectMessageType[*launched](probe)
+
+	// 退避定时器由虚拟时钟驱动，等待其注册后推进
+	crashAndRestart := func() {
+		system.Tell(ref, &crash{})
+		assert.Eventually(t, func() bool {
+			return clock.Pending() > 0
+		}, time.Second, time.Millisecond)
+		clock.Advance(10 * time.Millisecond)
+		vividtest.ExpectMessageType[*launched](probe)
+	}
+	crashAndRestart()
+	assert.Equal(t, 1, currentChild(t, system, ref).Restarts)
+
+	// 仅推进虚拟时钟，稳定运行时长按系统时钟计算，重启计数被重置
+	clock.Advance(time.Hour)
+	crashAndRestart()
+	assert.Equal(t, 1, currentChild(t, system, ref).Restarts)
+}
+
+func TestBackoffSupervisor_AskRoundTrip(t *testing.T) {
+	system := vividtest.NewActorSystem(t)
+	probe := vividtest.NewTestProbe(t, system)
+
+	ref, err := system.ActorOf(supervisor.NewBackoffSupervisor(newChildProvider(probe),
+		supervisor.WithMode(supervisor.BackoffOnStop),
+		supervisor.WithBackoff(200*time.Millisecond, time.Second),
+		supervisor.WithBufferSize(1),
+	))
+	require.NoError(t, err)
+	vividtest.ExpectMessageType[*launched](probe)
+
+	reply, err := system.Ask(ref, &echo{text: "live"}, time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, &echo{text: "live"}, reply)
+
+	// 子 Actor 不可用期间缓冲的 Ask 在重启后仍回复原始 sender
+	system.Tell(ref, &quit{})
+	assert.Eventually(t, func() bool {
+		return currentChild(t, system, ref).Ref == nil
+	}, time.Second, 10*time.Millisecond)
+	reply, err = system.Ask(ref, &echo{text: "buffered"}, 2*time.Second).Result()
+	require.NoError(t, err)
+	assert.Equal(t, &echo{text: "buffered"}, reply)
+	vividtest.ExpectMessageType[*launched](probe)
+	probe.ExpectNoMessage(50 * time.Millisecond)
+}
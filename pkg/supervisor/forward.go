@@ -0,0 +1,87 @@
+package supervisor
+
+import "github.com/kercylan98/vivid"
+
+var (
+	_ vivid.PrelaunchActor  = (*childActor)(nil)
+	_ vivid.PreRestartActor = (*childActor)(nil)
+	_ vivid.RestartedActor  = (*childActor)(nil)
+	_ vivid.SupervisorActor = (*childActor)(nil)
+	_ vivid.ActorContext    = (*childActorContext)(nil)
+	_ vivid.ActorProvider   = (*childProvider)(nil)
+)
+
+// forwardedMessage 监督者转发给子 Actor 的消息，携带原始 sender 与 message，仅本地使用。
+type forwardedMessage struct {
+	sender  vivid.ActorRef
+	message vivid.Message
+}
+
+// childProvider 包装用户提供的 ActorProvider，使其创建的子 Actor 能够透明地接收 forwardedMessage。
+type childProvider struct {
+	provider vivid.ActorProvider
+}
+
+func (p *childProvider) Provide() vivid.Actor {
+	return &childActor{actor: p.provider.Provide()}
+}
+
+// childActor 将各扩展接口委托给被包装的 Actor，被包装的 Actor 未实现时等同于未实现该接口。
+type childActor struct {
+	actor vivid.Actor
+}
+
+func (a *childActor) OnPrelaunch(ctx vivid.PrelaunchContext) error {
+	if actor, ok := a.actor.(vivid.PrelaunchActor); ok {
+		return actor.OnPrelaunch(ctx)
+	}
+	return nil
+}
+
+func (a *childActor) OnPreRestart(ctx vivid.RestartContext) error {
+	if actor, ok := a.actor.(vivid.PreRestartActor); ok {
+		return actor.OnPreRestart(ctx)
+	}
+	return nil
+}
+
+func (a *childActor) OnRestarted(ctx vivid.RestartContext) error {
+	if actor, ok := a.actor.(vivid.RestartedActor); ok {
+		return actor.OnRestarted(ctx)
+	}
+	return nil
+}
+
+// SupervisionStrategy 被包装的 Actor 未实现 vivid.SupervisorActor 时返回 nil，即使用系统默认策略。
+func (a *childActor) SupervisionStrategy() vivid.SupervisionStrategy {
+	if actor, ok := a.actor.(vivid.SupervisorActor); ok {
+		return actor.SupervisionStrategy()
+	}
+	return nil
+}
+
+func (a *childActor) OnReceive(ctx vivid.ActorContext) {
+	if m, ok := ctx.Message().(*forwardedMessage); ok {
+		ctx = &childActorContext{ActorContext: ctx, message: m.message, sender: m.sender}
+	}
+	a.actor.OnReceive(ctx)
+}
+
+// childActorContext 解包 forwardedMessage，使子 Actor 看到原始 sender，Reply 直接回复原始 sender（如 Ask 的 Future）。
+type childActorContext struct {
+	vivid.ActorContext
+	message vivid.Message
+	sender  vivid.ActorRef
+}
+
+func (c *childActorContext) Message() vivid.Message {
+	return c.message
+}
+
+func (c *childActorContext) Sender() vivid.ActorRef {
+	return c.sender
+}
+
+func (c *childActorContext) Reply(message vivid.Message) {
+	c.Tell(c.sender, message)
+}
@@ -2,6 +2,7 @@ package ves
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/kercylan98/vivid"
 )
@@ -106,6 +107,31 @@ type ActorFailedEvent struct {
 	Type reflect.Type
 	// Fault 导致失败的故障信息，通常为 panic 的值或错误对象
 	Fault any
+	// Time 故障发生时间，与 vivid.FailureRecord.Time 一致
+	Time time.Time
+}
+
+// ActorRestartBudgetExhaustedEvent 表示 Actor 的重启次数超出监督策略限制的事件。
+//
+// 该事件在监督策略配置了 MaxRetries 且子 Actor 在 WithinDuration 内的重启次数超出限制时发布，
+// 此时本次故障的决策已由重启改为 Decision（停止或升级）。
+//
+// 使用场景：
+//   - 发现持续崩溃、无法通过重启恢复的 Actor
+//   - 实现故障告警机制
+type ActorRestartBudgetExhaustedEvent struct {
+	// ActorRef 超出重启次数限制的 Actor 的引用
+	ActorRef vivid.ActorRef
+	// Supervisor 做出决策的监督者的引用
+	Supervisor vivid.ActorRef
+	// MaxRetries 统计窗口内允许的最大重启次数
+	MaxRetries int
+	// WithinDuration 重启次数的统计窗口，0 表示不限时长
+	WithinDuration time.Duration
+	// Decision 超出限制后采用的决策
+	Decision vivid.SupervisionDecision
+	// Fault 本次导致失败的故障信息
+	Fault any
 }
 
 // ActorWatchedEvent 表示 Actor 被监听的事件。
@@ -4,11 +4,12 @@ import "github.com/kercylan98/vivid"
 
 // ClusterMembersChangedEvent 在 members 发生变更时发布到 EventStream（新增或故障剔除）。
 type ClusterMembersChangedEvent struct {
-	NodeRef    vivid.ActorRef // 变更的节点引用
-	Members    []string       // 变更后的成员列表
-	AddedNum   int            // 新增的成员数量
-	RemovedNum int            // 移除的成员数量
-	Removed    []string       // 移除的成员列表
+	NodeRef    vivid.ActorRef    // 变更的节点引用
+	Members    []string          // 变更后的成员列表
+	Statuses   map[string]string // 变更后各成员地址对应的状态（joining/weakly-up/up/suspect 等）
+	AddedNum   int               // 新增的成员数量
+	RemovedNum int               // 移除的成员数量
+	Removed    []string          // 移除的成员列表
 }
 
 // ClusterLeaderChangedEvent 在确定性选主结果变化时发布到 EventStream，便于集群单例等迁移。
@@ -62,3 +63,29 @@ type ClusterDCHealthChangedEvent struct {
 type ClusterLeaveCompletedEvent struct {
 	NodeRef vivid.ActorRef
 }
+
+// ClusterSplitBrainResolvedEvent 脑裂处理器在不可达成员集合稳定后作出裁决时发布。
+// DownedSelf 为 true 表示本节点所在侧被判定下线，节点随后将通过 Leave 退出集群；否则本侧保留并剔除 Unreachable 中的成员。
+type ClusterSplitBrainResolvedEvent struct {
+	NodeRef     vivid.ActorRef
+	Strategy    vivid.SplitBrainStrategy // 作出裁决的策略
+	DownedSelf  bool                     // 本节点所在侧是否被判定下线
+	DownedAll   bool                     // 是否因不稳定保护而全部下线
+	Reachable   []string                 // 裁决时本侧可达成员地址
+	Unreachable []string                 // 裁决时不可达成员地址
+}
+
+// ClusterAppVersionReachedEvent 视图中所有在役成员（不含 Leaving/Exiting/Down/Removed）的应用版本一致达到 WithClusterTargetAppVersion 配置的目标版本时发布，
+// 部署工具可据此判断滚动升级已完成；未配置目标版本时不发布。
+type ClusterAppVersionReachedEvent struct {
+	NodeRef     vivid.ActorRef
+	AppVersion  string // 全体在役成员共同的应用版本
+	MemberCount int    // 达到该版本的在役成员数
+}
+
+// ClusterServiceListingEvent 集群服务注册表中某服务键的当前注册列表，通过 ClusterContext.Subscribe 订阅，
+// 订阅时投递一次，之后列表每次变化（注册、注销、Actor 终止、节点离开集群）时直接投递给订阅者。
+type ClusterServiceListingEvent struct {
+	Key  string           // 服务键标识
+	Refs []vivid.ActorRef // 全集群当前注册的 ActorRef，按地址与路径排序
+}
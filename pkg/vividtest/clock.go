@@ -0,0 +1,142 @@
+package vividtest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+var (
+	_ vivid.Clock      = (*VirtualClock)(nil)
+	_ vivid.ClockTimer = (*virtualTimer)(nil)
+)
+
+// VirtualClock 手动推进的虚拟时钟，通过 vivid.WithActorSystemClock 接入后覆盖 Scheduler、Future 超时与指数退避。
+//
+// 时间仅在调用 Advance 时前进，到期的定时器在 Advance 的调用方 goroutine 中按到期时间与创建顺序依次同步执行，
+// 包括执行过程中新建且在目标时间内到期的定时器。
+type VirtualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	seq    uint64
+	timers []*virtualTimer
+}
+
+type virtualTimer struct {
+	clock    *VirtualClock
+	deadline time.Time
+	seq      uint64
+	fn       func()
+	done     bool // 已触发或已停止
+}
+
+// NewVirtualClock 创建以 start 为当前时间的虚拟时钟。
+func NewVirtualClock(start time.Time) *VirtualClock {
+	return &VirtualClock{now: start}
+}
+
+func (c *VirtualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AfterFunc 创建在 d 之后触发的定时器，d ≤ 0 时在下一次 Advance（含 Advance(0)）时触发。
+func (c *VirtualClock) AfterFunc(d time.Duration, f func()) vivid.ClockTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	timer := &virtualTimer{clock: c, deadline: c.now.Add(max(d, 0)), seq: c.seq, fn: f}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+// Sleep 阻塞直到其他 goroutine 通过 Advance 将时间推进 d。
+func (c *VirtualClock) Sleep(d time.Duration) {
+	wake := make(chan struct{})
+	c.AfterFunc(d, func() { close(wake) })
+	<-wake
+}
+
+// Advance 将时间推进 d，并依次执行期间到期的定时器。
+func (c *VirtualClock) Advance(d time.Duration) {
+	c.advance(d, nil)
+}
+
+// advance 推进时间，每执行一个定时器后调用 fired（非 nil 时），使定时器产生的后续动作在下一个定时器之前完成。
+func (c *VirtualClock) advance(d time.Duration, fired func()) {
+	c.mu.Lock()
+	target := c.now.Add(max(d, 0))
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		timer := c.popDue(target)
+		if timer == nil {
+			c.now = target
+			c.mu.Unlock()
+			return
+		}
+		if timer.deadline.After(c.now) {
+			c.now = timer.deadline
+		}
+		c.mu.Unlock()
+		timer.fn()
+		if fired != nil {
+			fired()
+		}
+	}
+}
+
+// Pending 返回尚未触发且未停止的定时器数量。
+func (c *VirtualClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// popDue 取出目标时间内最早到期的定时器，调用方须持有锁。
+func (c *VirtualClock) popDue(target time.Time) *virtualTimer {
+	index := -1
+	for i, timer := range c.timers {
+		if timer.deadline.After(target) {
+			continue
+		}
+		if index < 0 || timer.deadline.Before(c.timers[index].deadline) ||
+			(timer.deadline.Equal(c.timers[index].deadline) && timer.seq < c.timers[index].seq) {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil
+	}
+	timer := c.timers[index]
+	timer.done = true
+	c.remove(index)
+	return timer
+}
+
+func (c *VirtualClock) remove(index int) {
+	last := len(c.timers) - 1
+	copy(c.timers[index:], c.timers[index+1:])
+	c.timers[last] = nil
+	c.timers = c.timers[:last]
+}
+
+func (t *virtualTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t.done {
+		return false
+	}
+	t.done = true
+	for i, timer := range c.timers {
+		if timer == t {
+			c.remove(i)
+			break
+		}
+	}
+	return true
+}
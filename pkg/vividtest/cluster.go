@@ -0,0 +1,405 @@
+package vividtest
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/pkg/bootstrap"
+)
+
+const (
+	// DefaultClusterTimeout ClusterHarness 的 Await 系列方法的默认超时时间。
+	DefaultClusterTimeout = 10 * time.Second
+
+	// clusterPollInterval Await 系列方法检查条件的间隔。
+	clusterPollInterval = 20 * time.Millisecond
+)
+
+// MemberStatus 成员状态，取值与 vivid.ClusterMemberInfo.Status 一致，用于 AwaitMemberStatus。
+type MemberStatus string
+
+const (
+	MemberStatusJoining     MemberStatus = "joining"
+	MemberStatusWeaklyUp    MemberStatus = "weakly-up"
+	MemberStatusUp          MemberStatus = "up"
+	MemberStatusSuspect     MemberStatus = "suspect"
+	MemberStatusUnreachable MemberStatus = "unreachable"
+	MemberStatusDown        MemberStatus = "down"
+	MemberStatusLeaving     MemberStatus = "leaving"
+	MemberStatusExiting     MemberStatus = "exiting"
+	// MemberStatusRemoved 成员已从视图中移除，视图中不会出现该状态的成员。
+	MemberStatusRemoved MemberStatus = "removed"
+)
+
+// ClusterHarnessOptions ClusterHarness 配置。
+type ClusterHarnessOptions struct {
+	// Timeout Await 系列方法的超时时间；≤0 时使用 DefaultClusterTimeout。
+	Timeout time.Duration
+	// SystemOptions 应用于每个节点的 ActorSystem 选项，例如 vivid.WithActorSystemLogger。
+	SystemOptions []vivid.ActorSystemOption
+	// ClusterOptions 应用于每个节点的集群选项，种子由 ClusterHarness 设置为第一个节点。
+	ClusterOptions []vivid.ClusterOption
+	// NodeClusterOptions 按节点序号返回额外的集群选项，在 ClusterOptions 之后应用，例如为不同节点设置数据中心或应用版本。
+	NodeClusterOptions func(index int) []vivid.ClusterOption
+}
+
+// ClusterHarnessOption 是用于配置 ClusterHarnessOptions 的函数类型。
+type ClusterHarnessOption = func(*ClusterHarnessOptions)
+
+// WithClusterHarnessTimeout 返回一个 ClusterHarnessOption，用于设置 Await 系列方法的超时时间。
+func WithClusterHarnessTimeout(timeout time.Duration) ClusterHarnessOption {
+	return func(o *ClusterHarnessOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// WithClusterHarnessSystemOptions 返回一个 ClusterHarnessOption，用于追加应用于每个节点的 ActorSystem 选项。
+func WithClusterHarnessSystemOptions(options ...vivid.ActorSystemOption) ClusterHarnessOption {
+	return func(o *ClusterHarnessOptions) {
+		o.SystemOptions = append(o.SystemOptions, options...)
+	}
+}
+
+// WithClusterHarnessClusterOptions 返回一个 ClusterHarnessOption，用于追加应用于每个节点的集群选项。
+func WithClusterHarnessClusterOptions(options ...vivid.ClusterOption) ClusterHarnessOption {
+	return func(o *ClusterHarnessOptions) {
+		o.ClusterOptions = append(o.ClusterOptions, options...)
+	}
+}
+
+// WithClusterHarnessNodeClusterOptions 返回一个 ClusterHarnessOption，用于按节点序号设置额外的集群选项。
+func WithClusterHarnessNodeClusterOptions(fn func(index int) []vivid.ClusterOption) ClusterHarnessOption {
+	return func(o *ClusterHarnessOptions) {
+		o.NodeClusterOptions = fn
+	}
+}
+
+// ClusterNode ClusterHarness 中的一个节点。
+type ClusterNode struct {
+	Index   int                      // 节点序号
+	Address string                   // Remoting 广告地址 host:port
+	System  vivid.PrimaryActorSystem // 节点的 ActorSystem
+}
+
+// Cluster 返回节点的集群上下文。
+func (n *ClusterNode) Cluster() vivid.ClusterContext {
+	return n.System.Cluster()
+}
+
+type clusterLink struct {
+	from, to string
+}
+
+// ClusterHarness 进程内多节点集群测试工具：在回环地址的空闲端口上启动 N 个启用 Remoting 与集群的 ActorSystem，
+// 全部节点以第一个节点为种子。
+//
+// 通过为每个节点配置 vivid.RemotingFrameFilter，可在选定节点之间丢弃远程消息帧以模拟网络分区，或将节点彻底隔离后停止以模拟崩溃。
+// 为使故障检测在测试时长内完成，默认使用较短的发现间隔（100ms）与故障检测超时（2s），可通过集群选项覆盖。
+// 测试结束时通过 t.Cleanup 停止仍在运行的节点。
+type ClusterHarness struct {
+	t       testing.TB
+	options ClusterHarnessOptions
+	nodes   []*ClusterNode
+
+	mu      sync.RWMutex
+	blocked map[clusterLink]bool
+	killed  map[string]bool // 已被 Kill 的节点地址，收发的帧全部丢弃
+	stopped map[int]bool    // 已停止的节点序号
+}
+
+// NewClusterHarness 启动 n 个节点组成的集群，启动失败时测试失败。节点启动后未必已收敛，需要时调用 AwaitConvergence。
+func NewClusterHarness(t testing.TB, n int, opts ...ClusterHarnessOption) *ClusterHarness {
+	t.Helper()
+	if n <= 0 {
+		t.Fatalf("vividtest: cluster harness requires at least one node, got %d", n)
+	}
+	h := &ClusterHarness{
+		t:       t,
+		blocked: make(map[clusterLink]bool),
+		killed:  make(map[string]bool),
+		stopped: make(map[int]bool),
+	}
+	for _, opt := range opts {
+		opt(&h.options)
+	}
+	if h.options.Timeout <= 0 {
+		h.options.Timeout = DefaultClusterTimeout
+	}
+
+	t.Cleanup(h.stopAll)
+
+	var seed string
+	for i := range n {
+		// 由节点自身持有绑定到空闲端口的监听器，避免探测端口后关闭再绑定之间被其他进程抢占
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("vividtest: listen cluster node %d: %v", i, err)
+		}
+		address := listener.Addr().String()
+		if i == 0 {
+			seed = address
+		}
+		clusterOptions := append([]vivid.ClusterOption{
+			vivid.WithClusterDiscoveryInterval(100 * time.Millisecond),
+			vivid.WithClusterFailureDetectionTimeout(2 * time.Second),
+		}, h.options.ClusterOptions...)
+		if h.options.NodeClusterOptions != nil {
+			clusterOptions = append(clusterOptions, h.options.NodeClusterOptions(i)...)
+		}
+		clusterOptions = append(clusterOptions, vivid.WithClusterSeeds([]string{seed}))
+
+		options := append([]vivid.ActorSystemOption{}, h.options.SystemOptions...)
+		options = append(options,
+			vivid.WithActorSystemRemoting(address),
+			vivid.WithActorSystemRemotingOption(
+				vivid.WithActorSystemRemotingListener(listener),
+				vivid.WithActorSystemRemotingFrameFilter(func(remoteAddr string, outbound bool) bool {
+					return h.allow(address, remoteAddr, outbound)
+				}),
+				vivid.WithActorSystemRemotingClusterOption(clusterOptions...),
+			),
+		)
+		system := bootstrap.NewActorSystem(options...)
+		if err := system.Start(); err != nil {
+			_ = listener.Close()
+			h.stopped[i] = true
+			t.Fatalf("vividtest: start cluster node %d (%s): %v", i, address, err)
+		}
+		h.nodes = append(h.nodes, &ClusterNode{Index: i, Address: address, System: system})
+	}
+	return h
+}
+
+// Len 返回节点总数（含已停止的节点）。
+func (h *ClusterHarness) Len() int {
+	return len(h.nodes)
+}
+
+// Node 返回序号为 index 的节点。
+func (h *ClusterHarness) Node(index int) *ClusterNode {
+	h.t.Helper()
+	if index < 0 || index >= len(h.nodes) {
+		h.t.Fatalf("vividtest: cluster node %d out of range [0, %d)", index, len(h.nodes))
+	}
+	return h.nodes[index]
+}
+
+// NodeByAddress 返回地址为 address 的节点，不存在时返回 nil。
+func (h *ClusterHarness) NodeByAddress(address string) *ClusterNode {
+	for _, node := range h.nodes {
+		if node.Address == address {
+			return node
+		}
+	}
+	return nil
+}
+
+// Running 返回仍在运行（未被 Stop 或 Kill）的节点序号。
+func (h *ClusterHarness) Running() []int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var indexes []int
+	for _, node := range h.nodes {
+		if !h.stopped[node.Index] {
+			indexes = append(indexes, node.Index)
+		}
+	}
+	return indexes
+}
+
+// Partition 丢弃 side 与 other 两组节点之间双向的全部远程消息帧。
+func (h *ClusterHarness) Partition(side []int, other []int) {
+	h.t.Helper()
+	h.setLinks(side, other, true)
+}
+
+// Heal 恢复 side 与 other 两组节点之间的连通。
+func (h *ClusterHarness) Heal(side []int, other []int) {
+	h.t.Helper()
+	h.setLinks(side, other, false)
+}
+
+// HealAll 恢复全部节点之间的连通，已 Kill 的节点除外。
+func (h *ClusterHarness) HealAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	clear(h.blocked)
+}
+
+func (h *ClusterHarness) setLinks(side []int, other []int, blocked bool) {
+	h.t.Helper()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, a := range side {
+		for _, b := range other {
+			from, to := h.Node(a).Address, h.Node(b).Address
+			for _, link := range []clusterLink{{from, to}, {to, from}} {
+				if blocked {
+					h.blocked[link] = true
+				} else {
+					delete(h.blocked, link)
+				}
+			}
+		}
+	}
+}
+
+// allow 作为 self 节点的帧过滤器，判断与 remoteAddr 之间的帧是否放行。
+func (h *ClusterHarness) allow(self, remoteAddr string, outbound bool) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.killed[self] || h.killed[remoteAddr] {
+		return false
+	}
+	link := clusterLink{from: self, to: remoteAddr}
+	if !outbound {
+		link = clusterLink{from: remoteAddr, to: self}
+	}
+	return !h.blocked[link]
+}
+
+// Kill 模拟节点崩溃：先丢弃该节点收发的全部帧，使其余节点无法收到离开通知，再停止其 ActorSystem。
+// 其余节点只能依赖故障检测将其剔除。
+func (h *ClusterHarness) Kill(index int) {
+	h.t.Helper()
+	node := h.Node(index)
+	h.mu.Lock()
+	if h.stopped[index] {
+		h.mu.Unlock()
+		return
+	}
+	h.killed[node.Address] = true
+	h.stopped[index] = true
+	h.mu.Unlock()
+	if err := node.System.Stop(); err != nil {
+		h.t.Errorf("vividtest: kill cluster node %d (%s): %v", index, node.Address, err)
+	}
+}
+
+// Stop 优雅停止节点，节点在停止过程中离开集群。
+func (h *ClusterHarness) Stop(index int) {
+	h.t.Helper()
+	node := h.Node(index)
+	h.mu.Lock()
+	if h.stopped[index] {
+		h.mu.Unlock()
+		return
+	}
+	h.stopped[index] = true
+	h.mu.Unlock()
+	if err := node.System.Stop(); err != nil {
+		h.t.Errorf("vividtest: stop cluster node %d (%s): %v", index, node.Address, err)
+	}
+}
+
+func (h *ClusterHarness) stopAll() {
+	var wg sync.WaitGroup
+	for _, index := range h.Running() {
+		node := h.nodes[index]
+		h.mu.Lock()
+		h.stopped[index] = true
+		h.mu.Unlock()
+		wg.Go(func() {
+			if err := node.System.Stop(); err != nil {
+				h.t.Errorf("vividtest: stop cluster node %d (%s): %v", index, node.Address, err)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+// AwaitCondition 每隔一小段时间检查 condition，直到其返回 true；超时后以 description 描述期望并使测试失败。
+func (h *ClusterHarness) AwaitCondition(description string, condition func() bool) {
+	h.t.Helper()
+	deadline := time.Now().Add(h.options.Timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			h.t.Fatalf("vividtest: timeout after %s waiting for %s", h.options.Timeout, description)
+		}
+		time.Sleep(clusterPollInterval)
+	}
+}
+
+// AwaitConvergence 等待 nodes（为空时为全部运行中的节点）的视图收敛：每个节点看到的成员恰为 nodes 且状态均为 up，并认同同一个 Leader。
+// 返回 Leader 地址。
+func (h *ClusterHarness) AwaitConvergence(nodes ...int) string {
+	h.t.Helper()
+	if len(nodes) == 0 {
+		nodes = h.Running()
+	}
+	var leader string
+	h.AwaitCondition(fmt.Sprintf("convergence of nodes %v", nodes), func() bool {
+		leader = ""
+		for _, index := range nodes {
+			if !h.membersExactlyUp(index, nodes) {
+				return false
+			}
+			view, err := h.nodes[index].Cluster().GetView()
+			if err != nil || view.LeaderAddr == "" || (leader != "" && view.LeaderAddr != leader) {
+				return false
+			}
+			leader = view.LeaderAddr
+		}
+		return true
+	})
+	return leader
+}
+
+func (h *ClusterHarness) membersExactlyUp(observer int, nodes []int) bool {
+	members, err := h.nodes[observer].Cluster().GetMembers()
+	if err != nil || len(members) != len(nodes) {
+		return false
+	}
+	expected := make(map[string]bool, len(nodes))
+	for _, index := range nodes {
+		expected[h.nodes[index].Address] = true
+	}
+	for _, member := range members {
+		if !expected[member.Address] || MemberStatus(member.Status) != MemberStatusUp {
+			return false
+		}
+	}
+	return true
+}
+
+// AwaitLeader 等待 observers（为空时为全部运行中的节点）认同的 Leader 为 leader 节点。
+func (h *ClusterHarness) AwaitLeader(leader int, observers ...int) {
+	h.t.Helper()
+	if len(observers) == 0 {
+		observers = h.Running()
+	}
+	address := h.Node(leader).Address
+	h.AwaitCondition(fmt.Sprintf("leader %s seen by nodes %v", address, observers), func() bool {
+		for _, index := range observers {
+			view, err := h.nodes[index].Cluster().GetView()
+			if err != nil || view.LeaderAddr != address {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// AwaitMemberStatus 等待 observer 节点视图中 member 节点的状态为 status（如 MemberStatusUp、MemberStatusSuspect）；
+// status 为 MemberStatusRemoved 时等待该成员从视图中移除。
+func (h *ClusterHarness) AwaitMemberStatus(observer, member int, status MemberStatus) {
+	h.t.Helper()
+	address := h.Node(member).Address
+	h.Node(observer)
+	h.AwaitCondition(fmt.Sprintf("member %s with status %q seen by node %d", address, status, observer), func() bool {
+		members, err := h.nodes[observer].Cluster().GetMembers()
+		if err != nil {
+			return false
+		}
+		for _, m := range members {
+			if m.Address == address {
+				return MemberStatus(m.Status) == status
+			}
+		}
+		return status == MemberStatusRemoved
+	})
+}
@@ -0,0 +1,33 @@
+package vividtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid/pkg/vividtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterHarness_PartitionAndHeal(t *testing.T) {
+	// 分区两侧各自经故障检测剔除对方，节点间剔除时刻不同步时视图需要多轮 Gossip 才能收敛，负载较高时留出更长的等待时间
+	h := vividtest.NewClusterHarness(t, 3, vividtest.WithClusterHarnessTimeout(30*time.Second))
+	leader := h.AwaitConvergence()
+	assert.NotNil(t, h.NodeByAddress(leader))
+
+	h.Partition([]int{2}, []int{0, 1})
+	h.AwaitMemberStatus(0, 2, vividtest.MemberStatusRemoved)
+	h.AwaitConvergence(0, 1)
+
+	h.HealAll()
+	h.AwaitConvergence()
+}
+
+func TestClusterHarness_Kill(t *testing.T) {
+	h := vividtest.NewClusterHarness(t, 3)
+	h.AwaitConvergence()
+
+	h.Kill(1)
+	assert.Equal(t, []int{0, 2}, h.Running())
+	h.AwaitMemberStatus(0, 1, vividtest.MemberStatusRemoved)
+	h.AwaitConvergence()
+}
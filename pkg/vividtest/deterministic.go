@@ -0,0 +1,68 @@
+package vividtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/pkg/bootstrap"
+)
+
+// DeterministicEpoch 确定性 ActorSystem 虚拟时钟的起始时间。
+var DeterministicEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// DeterministicSystem 运行在 DeterministicDispatcher 与 VirtualClock 上的 ActorSystem。
+//
+// 消息只在调用 Step、RunUntilIdle 或 Advance 时投递，时间只在调用 Advance 时前进，测试可逐条推进消息并精确控制定时任务与超时。
+// 由于消息在测试 goroutine 中处理，Ask 返回的 Future 须在 RunUntilIdle 之后再调用 Result，否则将永久阻塞。
+// 传入 NewTestProbe 时，探针在断言前先执行 RunUntilIdle，且不等待真实时间。
+type DeterministicSystem struct {
+	vivid.PrimaryActorSystem
+	Clock      *VirtualClock
+	Dispatcher *DeterministicDispatcher
+}
+
+// NewDeterministicActorSystem 创建并启动确定性 ActorSystem，并通过 t.Cleanup 在测试结束时停止；启动或停止失败时测试失败。
+// 时钟与调度器选项会覆盖 options 中的同类设置。
+func NewDeterministicActorSystem(t testing.TB, options ...vivid.ActorSystemOption) *DeterministicSystem {
+	t.Helper()
+	s := &DeterministicSystem{
+		Clock:      NewVirtualClock(DeterministicEpoch),
+		Dispatcher: NewDeterministicDispatcher(),
+	}
+	options = append(options, vivid.WithActorSystemClock(s.Clock), vivid.WithActorSystemDispatcher(s.Dispatcher))
+	s.PrimaryActorSystem = bootstrap.NewActorSystem(options...)
+	if err := s.PrimaryActorSystem.Start(); err != nil {
+		t.Fatalf("vividtest: start actor system: %v", err)
+	}
+	s.RunUntilIdle()
+	t.Cleanup(func() {
+		var err error
+		s.Dispatcher.Auto(func() {
+			err = s.PrimaryActorSystem.Stop()
+		})
+		if err != nil {
+			t.Errorf("vividtest: stop actor system: %v", err)
+		}
+	})
+	return s
+}
+
+// Step 投递一条消息，没有待投递的消息时返回 false。
+func (s *DeterministicSystem) Step() bool {
+	return s.Dispatcher.Step()
+}
+
+// RunUntilIdle 投递全部待处理消息直到没有消息可投递，返回投递次数。
+func (s *DeterministicSystem) RunUntilIdle() int {
+	return s.Dispatcher.RunUntilIdle()
+}
+
+// Advance 先投递全部待处理消息，再将虚拟时间推进 d；期间每触发一个定时器便投递由此产生的全部消息。返回投递次数。
+func (s *DeterministicSystem) Advance(d time.Duration) int {
+	n := s.Dispatcher.RunUntilIdle()
+	s.Clock.advance(d, func() {
+		n += s.Dispatcher.RunUntilIdle()
+	})
+	return n + s.Dispatcher.RunUntilIdle()
+}
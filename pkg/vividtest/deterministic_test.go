@@ -0,0 +1,101 @@
+package vividtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/pkg/vividtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVirtualClock(t *testing.T) {
+	clock := vividtest.NewVirtualClock(vividtest.DeterministicEpoch)
+
+	var fired []int
+	clock.AfterFunc(2*time.Second, func() { fired = append(fired, 2) })
+	clock.AfterFunc(time.Second, func() {
+		fired = append(fired, 1)
+		// 推进过程中新建且在目标时间内到期的定时器同样触发
+		clock.AfterFunc(500*time.Millisecond, func() { fired = append(fired, 15) })
+	})
+	stopped := clock.AfterFunc(time.Second, func() { fired = append(fired, -1) })
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop())
+
+	clock.Advance(999 * time.Millisecond)
+	assert.Empty(t, fired)
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, []int{1, 15, 2}, fired)
+	assert.Equal(t, vividtest.DeterministicEpoch.Add(2999*time.Millisecond), clock.Now())
+	assert.Zero(t, clock.Pending())
+}
+
+func TestDeterministicSystem_Step(t *testing.T) {
+	system := vividtest.NewDeterministicActorSystem(t)
+
+	var received []vivid.Message
+	record := func(ctx vivid.ActorContext) {
+		if _, ok := ctx.Message().(int); ok {
+			received = append(received, ctx.Message())
+		}
+	}
+	a, err := system.ActorOf(vivid.ActorFN(record))
+	require.NoError(t, err)
+	b, err := system.ActorOf(vivid.ActorFN(record))
+	require.NoError(t, err)
+	system.RunUntilIdle()
+
+	system.Tell(a, 1)
+	system.Tell(a, 2)
+	system.Tell(b, 3)
+
+	// 每次 Step 投递一条消息，各邮箱按提交顺序交替处理
+	require.True(t, system.Step())
+	assert.Equal(t, []vivid.Message{1}, received)
+	require.True(t, system.Step())
+	assert.Equal(t, []vivid.Message{1, 3}, received)
+	require.True(t, system.Step())
+	assert.Equal(t, []vivid.Message{1, 3, 2}, received)
+	system.RunUntilIdle()
+	assert.False(t, system.Step())
+}
+
+func TestDeterministicSystem_Scheduler(t *testing.T) {
+	system := vividtest.NewDeterministicActorSystem(t)
+	probe := vividtest.NewTestProbe(t, system)
+
+	_, err := system.ActorOf(vivid.ActorFN(func(ctx vivid.ActorContext) {
+		if _, ok := ctx.Message().(*vivid.OnLaunch); ok {
+			_ = ctx.Scheduler().Once(probe.Ref(), time.Second, "once")
+			_ = ctx.Scheduler().Loop(probe.Ref(), 300*time.Millisecond, "loop", vivid.WithSchedulerReference("loop"))
+		}
+	}))
+	require.NoError(t, err)
+
+	system.Advance(899 * time.Millisecond)
+	probe.ExpectMessage("loop")
+	probe.ExpectMessage("loop")
+	probe.ExpectNoMessage(0)
+
+	system.Advance(time.Millisecond)
+	probe.ExpectMessage("loop")
+	probe.ExpectNoMessage(0)
+
+	system.Advance(100 * time.Millisecond)
+	probe.ExpectMessage("once")
+	probe.ExpectNoMessage(0)
+}
+
+func TestDeterministicSystem_AskTimeout(t *testing.T) {
+	system := vividtest.NewDeterministicActorSystem(t)
+	probe := vividtest.NewTestProbe(t, system)
+
+	future := system.Ask(probe.Ref(), &ping{N: 1}, time.Second)
+	vividtest.ExpectMessageType[*ping](probe)
+
+	system.Advance(time.Second)
+	_, err := future.Result()
+	assert.ErrorIs(t, err, vivid.ErrorFutureTimeout)
+}
@@ -0,0 +1,101 @@
+package vividtest
+
+import (
+	"sync"
+
+	"github.com/kercylan98/vivid"
+)
+
+var (
+	_ vivid.Dispatcher = (*DeterministicDispatcher)(nil)
+)
+
+// DeterministicDispatcher 单线程的确定性邮箱调度器，通过 vivid.WithActorSystemDispatcher 接入。
+//
+// 邮箱提交的处理任务进入先进先出队列，仅在调用 Step 或 RunUntilIdle 时由调用方 goroutine 执行；
+// 每个任务只处理一条消息后重新排队，因此同一时刻至多一条消息在处理，且消息按入队顺序在各 Actor 间交替投递。
+type DeterministicDispatcher struct {
+	mu      sync.Mutex
+	tasks   []func()
+	running sync.Mutex // 保证同一时刻只有一个任务在执行
+	notify  chan struct{}
+}
+
+// NewDeterministicDispatcher 创建确定性调度器。
+func NewDeterministicDispatcher() *DeterministicDispatcher {
+	return &DeterministicDispatcher{notify: make(chan struct{}, 1)}
+}
+
+func (d *DeterministicDispatcher) Dispatch(task func()) {
+	d.mu.Lock()
+	d.tasks = append(d.tasks, task)
+	d.mu.Unlock()
+	select {
+	case d.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Throughput 固定返回 1，邮箱每处理一条消息便让出调度器。
+func (d *DeterministicDispatcher) Throughput() int {
+	return 1
+}
+
+// Pending 返回等待执行的任务数。
+func (d *DeterministicDispatcher) Pending() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.tasks)
+}
+
+// Step 执行队首任务，即投递一条消息；队列为空时返回 false。
+func (d *DeterministicDispatcher) Step() bool {
+	d.running.Lock()
+	defer d.running.Unlock()
+
+	d.mu.Lock()
+	if len(d.tasks) == 0 {
+		d.mu.Unlock()
+		return false
+	}
+	task := d.tasks[0]
+	d.tasks[0] = nil
+	d.tasks = d.tasks[1:]
+	d.mu.Unlock()
+
+	task()
+	return true
+}
+
+// RunUntilIdle 持续执行任务直到队列为空，返回执行的任务数。
+// Actor 之间无限往复发送消息时不会返回，此类场景应使用 Step 逐条推进。
+func (d *DeterministicDispatcher) RunUntilIdle() int {
+	n := 0
+	for d.Step() {
+		n++
+	}
+	return n
+}
+
+// Auto 在 fn 执行期间由后台 goroutine 自动执行提交的任务，用于调用 ActorSystem.Stop 等需要等待消息处理完成的阻塞操作。
+func (d *DeterministicDispatcher) Auto(fn func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			d.RunUntilIdle()
+			select {
+			case <-d.notify:
+			case <-done:
+				d.RunUntilIdle()
+				return
+			}
+		}
+	}()
+	defer func() {
+		close(done)
+		<-stopped
+	}()
+	fn()
+}
@@ -0,0 +1,445 @@
+package vividtest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+var (
+	_ vivid.RemotingTransport = (*FaultTransport)(nil)
+	_ net.Conn                = (*faultConn)(nil)
+)
+
+// ErrFaultInjected 由 FaultTransport 注入的拨号拒绝与连接重置错误均包装该错误，可通过 errors.Is 判断。
+var ErrFaultInjected = errors.New("vividtest: fault injected")
+
+// faultReorderWindow 被重排的帧相对其原定发送时刻额外扣留的时长，期间后续的帧先于它发出。
+const faultReorderWindow = 20 * time.Millisecond
+
+// Faults 对某个对端地址注入的故障，零值表示不注入任何故障。
+//
+// 故障作用于经 FaultTransport 建立的出站连接上的每个消息帧，连接建立时的握手数据不受影响；
+// 修改后立即对已建立的连接生效。
+type Faults struct {
+	Latency     time.Duration // 每帧的固定延迟
+	Jitter      time.Duration // 在 Latency 之上附加 [0, Jitter) 的随机延迟，不会打乱帧的顺序
+	DropRate    float64       // 帧被静默丢弃的概率，取值 [0, 1]
+	ReorderRate float64       // 帧被扣留、晚于其后的帧发出的概率，取值 [0, 1]
+	ResetRate   float64       // 写入帧时连接被重置的概率，取值 [0, 1]；重置后写入返回错误，由 Remoting 重连
+	Bandwidth   int           // 每秒可发送的字节数，≤0 表示不限速
+	RefuseDial  bool          // 拒绝建立新的连接
+}
+
+// FaultStats 某个对端地址上的故障注入统计。
+type FaultStats struct {
+	Dials        int // 拨号次数（含被拒绝的拨号）
+	RefusedDials int // 被拒绝的拨号次数
+	Frames       int // 写入的帧数（含被丢弃的帧）
+	Dropped      int // 被丢弃的帧数
+	Reordered    int // 被重排的帧数
+	Resets       int // 被重置的连接数（含 Reset 主动重置）
+}
+
+// FaultTransportOptions FaultTransport 配置。
+type FaultTransportOptions struct {
+	// Base 实际建立连接的传输层，为 nil 时直接使用 TCP 拨号。
+	Base vivid.RemotingTransport
+	// Seed 随机数种子，相同的种子与相同的写入序列产生相同的故障决策；为 0 时使用随机种子。
+	Seed uint64
+}
+
+// FaultTransportOption 是用于配置 FaultTransportOptions 的函数类型。
+type FaultTransportOption = func(*FaultTransportOptions)
+
+// WithFaultTransportBase 返回一个 FaultTransportOption，用于设置实际建立连接的传输层。
+func WithFaultTransportBase(base vivid.RemotingTransport) FaultTransportOption {
+	return func(o *FaultTransportOptions) {
+		o.Base = base
+	}
+}
+
+// WithFaultTransportSeed 返回一个 FaultTransportOption，用于设置故障决策的随机数种子。
+func WithFaultTransportSeed(seed uint64) FaultTransportOption {
+	return func(o *FaultTransportOptions) {
+		o.Seed = seed
+	}
+}
+
+// FaultTransport 故障注入传输层：包装 Remoting 的出站连接，按对端地址注入延迟、抖动、丢帧、重排、连接重置、拒绝拨号与限速，
+// 无需任何外部工具即可在进程内复现远程邮箱的退避重试与重连行为。
+//
+// 通过 vivid.WithActorSystemRemotingTransport 接入 ActorSystem；故障仅作用于该 ActorSystem 发出的帧，需要双向故障时为两端分别配置。
+// 全部方法均可在运行时并发调用。
+type FaultTransport struct {
+	options  FaultTransportOptions
+	mu       sync.Mutex
+	rand     *rand.Rand
+	defaults Faults
+	faults   map[string]Faults
+	stats    map[string]*FaultStats
+	conns    map[string]map[*faultConn]struct{}
+}
+
+// NewFaultTransport 创建故障注入传输层，初始时不注入任何故障。
+func NewFaultTransport(opts ...FaultTransportOption) *FaultTransport {
+	t := &FaultTransport{
+		faults: make(map[string]Faults),
+		stats:  make(map[string]*FaultStats),
+		conns:  make(map[string]map[*faultConn]struct{}),
+	}
+	for _, opt := range opts {
+		opt(&t.options)
+	}
+	seed := t.options.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	t.rand = rand.New(rand.NewPCG(seed, seed))
+	return t
+}
+
+// SetFaults 设置对 address 注入的故障，覆盖此前的设置。
+func (t *FaultTransport) SetFaults(address string, faults Faults) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.faults[address] = faults
+}
+
+// SetDefaultFaults 设置对未单独配置的对端地址注入的故障。
+func (t *FaultTransport) SetDefaultFaults(faults Faults) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.defaults = faults
+}
+
+// ClearFaults 清除对 address 单独设置的故障，此后使用默认故障。
+func (t *FaultTransport) ClearFaults(address string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.faults, address)
+}
+
+// ClearAll 清除全部故障设置，包括默认故障。
+func (t *FaultTransport) ClearAll() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.faults = make(map[string]Faults)
+	t.defaults = Faults{}
+}
+
+// Faults 返回当前对 address 生效的故障。
+func (t *FaultTransport) Faults(address string) Faults {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.faultsOf(address)
+}
+
+// Stats 返回 address 上的故障注入统计。
+func (t *FaultTransport) Stats(address string) FaultStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return *t.statsOf(address)
+}
+
+// Reset 立即重置到 address 的全部已建立连接，返回被重置的连接数。连接上尚未发出的帧随之丢失。
+func (t *FaultTransport) Reset(address string) int {
+	t.mu.Lock()
+	conns := make([]*faultConn, 0, len(t.conns[address]))
+	for c := range t.conns[address] {
+		conns = append(conns, c)
+	}
+	t.statsOf(address).Resets += len(conns)
+	t.mu.Unlock()
+	for _, c := range conns {
+		c.reset()
+	}
+	return len(conns)
+}
+
+// Dial 实现 vivid.RemotingTransport，在 RefuseDial 时返回包装 ErrFaultInjected 的错误。
+func (t *FaultTransport) Dial(address string) (net.Conn, error) {
+	t.mu.Lock()
+	stats := t.statsOf(address)
+	stats.Dials++
+	if t.faultsOf(address).RefuseDial {
+		stats.RefusedDials++
+		t.mu.Unlock()
+		return nil, fmt.Errorf("%w: dial %s refused", ErrFaultInjected, address)
+	}
+	t.mu.Unlock()
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if t.options.Base != nil {
+		conn, err = t.options.Base.Dial(address)
+	} else {
+		conn, err = net.Dial("tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c := newFaultConn(t, address, conn)
+	t.mu.Lock()
+	if t.conns[address] == nil {
+		t.conns[address] = make(map[*faultConn]struct{})
+	}
+	t.conns[address][c] = struct{}{}
+	t.mu.Unlock()
+	return c, nil
+}
+
+func (t *FaultTransport) faultsOf(address string) Faults {
+	if f, ok := t.faults[address]; ok {
+		return f
+	}
+	return t.defaults
+}
+
+func (t *FaultTransport) statsOf(address string) *FaultStats {
+	stats, ok := t.stats[address]
+	if !ok {
+		stats = &FaultStats{}
+		t.stats[address] = stats
+	}
+	return stats
+}
+
+// faultAction 对单个帧的故障决策。
+type faultAction int
+
+const (
+	faultActionSend faultAction = iota
+	faultActionDrop
+	faultActionReorder
+	faultActionReset
+)
+
+// plan 为发往 address 的一个帧做出故障决策，返回动作、帧的延迟与限速。
+func (t *FaultTransport) plan(address string) (action faultAction, delay time.Duration, bandwidth int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f := t.faultsOf(address)
+	stats := t.statsOf(address)
+	stats.Frames++
+
+	delay = f.Latency
+	if f.Jitter > 0 {
+		delay += time.Duration(t.rand.Int64N(int64(f.Jitter)))
+	}
+	switch {
+	case f.ResetRate > 0 && t.rand.Float64() < f.ResetRate:
+		stats.Resets++
+		return faultActionReset, 0, 0
+	case f.DropRate > 0 && t.rand.Float64() < f.DropRate:
+		stats.Dropped++
+		return faultActionDrop, 0, 0
+	case f.ReorderRate > 0 && t.rand.Float64() < f.ReorderRate:
+		stats.Reordered++
+		return faultActionReorder, delay, f.Bandwidth
+	}
+	return faultActionSend, delay, f.Bandwidth
+}
+
+func (t *FaultTransport) forget(c *faultConn) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.conns[c.address], c)
+	if len(t.conns[c.address]) == 0 {
+		delete(t.conns, c.address)
+	}
+}
+
+// delayedFrame 等待发出的帧。
+type delayedFrame struct {
+	data []byte
+	due  time.Time
+	seq  uint64
+}
+
+// faultConn 注入故障的出站连接。首次写入视为握手直接透传，此后每次写入视为一个帧。
+// 无需延迟的帧在调用方 goroutine 中同步写入，写入错误直接返回；需要延迟的帧交由后台 goroutine 按到期时间依次写入，
+// 后台写入失败时关闭连接，之后的写入返回该错误。
+type faultConn struct {
+	net.Conn
+	transport  *FaultTransport
+	address    string
+	mu         sync.Mutex
+	handshaken bool
+	queue      []delayedFrame // 按 due、seq 排序
+	seq        uint64
+	lastDue    time.Time // 最近一个非重排帧的到期时刻，保证延迟与抖动不打乱帧的顺序
+	nextFree   time.Time // 限速下链路再次空闲的时刻
+	writing    bool      // 后台 goroutine 是否在运行
+	wake       chan struct{}
+	err        error
+	closed     bool
+}
+
+func newFaultConn(transport *FaultTransport, address string, conn net.Conn) *faultConn {
+	return &faultConn{
+		Conn:      conn,
+		transport: transport,
+		address:   address,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+func (c *faultConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	if c.err != nil {
+		err := c.err
+		c.mu.Unlock()
+		return 0, err
+	}
+	if !c.handshaken {
+		c.handshaken = true
+		c.mu.Unlock()
+		return c.Conn.Write(b)
+	}
+	c.mu.Unlock()
+
+	action, delay, bandwidth := c.transport.plan(c.address)
+	switch action {
+	case faultActionReset:
+		c.reset()
+		return 0, c.failure()
+	case faultActionDrop:
+		return len(b), nil
+	}
+
+	c.mu.Lock()
+	now := time.Now()
+	due := now.Add(delay)
+	if action == faultActionReorder {
+		due = due.Add(faultReorderWindow)
+	} else {
+		if due.Before(c.lastDue) {
+			due = c.lastDue
+		}
+		c.lastDue = due
+	}
+	if bandwidth > 0 {
+		if due.Before(c.nextFree) {
+			due = c.nextFree
+		}
+		c.nextFree = due.Add(time.Duration(len(b)) * time.Second / time.Duration(bandwidth))
+	}
+	if !due.After(now) && !c.writing {
+		c.mu.Unlock()
+		n, err := c.Conn.Write(b)
+		if err != nil {
+			c.fail(err)
+		}
+		return n, err
+	}
+
+	c.seq++
+	frame := delayedFrame{data: append([]byte(nil), b...), due: due, seq: c.seq}
+	i := sort.Search(len(c.queue), func(i int) bool {
+		return c.queue[i].due.After(frame.due)
+	})
+	c.queue = append(c.queue, delayedFrame{})
+	copy(c.queue[i+1:], c.queue[i:])
+	c.queue[i] = frame
+	if !c.writing {
+		c.writing = true
+		go c.writeLoop()
+	}
+	c.mu.Unlock()
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+	return len(b), nil
+}
+
+// writeLoop 按到期时间依次写入排队的帧，队列为空时退出。
+func (c *faultConn) writeLoop() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		c.mu.Lock()
+		if c.closed || c.err != nil || len(c.queue) == 0 {
+			c.queue = nil
+			c.writing = false
+			c.mu.Unlock()
+			return
+		}
+		head := c.queue[0]
+		if wait := time.Until(head.due); wait > 0 {
+			c.mu.Unlock()
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(wait)
+			select {
+			case <-timer.C:
+			case <-c.wake:
+			}
+			continue
+		}
+		c.queue = c.queue[1:]
+		c.mu.Unlock()
+		if _, err := c.Conn.Write(head.data); err != nil {
+			c.fail(err)
+		}
+	}
+}
+
+// fail 记录写入错误并关闭底层连接。
+func (c *faultConn) fail(err error) {
+	c.mu.Lock()
+	if c.err == nil {
+		c.err = err
+	}
+	c.mu.Unlock()
+	_ = c.Close()
+}
+
+// failure 返回连接当前的错误。
+func (c *faultConn) failure() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// reset 以 RST 方式立即中断连接，未发出的帧随之丢失。
+func (c *faultConn) reset() {
+	c.mu.Lock()
+	if c.err == nil {
+		c.err = fmt.Errorf("%w: connection to %s reset", ErrFaultInjected, c.address)
+	}
+	c.mu.Unlock()
+	if tcp, ok := c.Conn.(interface{ SetLinger(sec int) error }); ok {
+		_ = tcp.SetLinger(0)
+	}
+	_ = c.Close()
+}
+
+func (c *faultConn) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	c.mu.Unlock()
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+	c.transport.forget(c)
+	return c.Conn.Close()
+}
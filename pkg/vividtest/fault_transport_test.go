@@ -0,0 +1,102 @@
+package vividtest_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/internal/messages"
+	"github.com/kercylan98/vivid/pkg/vividtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	vivid.RegisterCustomMessage[*faultMessage]("VividtestFaultMessage",
+		func(message any, reader *messages.Reader, codec messages.Codec) error {
+			return reader.ReadInto(&message.(*faultMessage).Text)
+		},
+		func(message any, writer *messages.Writer, codec messages.Codec) error {
+			return writer.WriteFrom(message.(*faultMessage).Text)
+		},
+	)
+}
+
+type faultMessage struct {
+	Text string
+}
+
+// newFaultPair 启动出站连接经 transport 的 sender 系统与 receiver 系统，返回 sender 系统、receiver 上的探针、探针的远程引用与 receiver 地址。
+func newFaultPair(t *testing.T, transport *vividtest.FaultTransport) (vivid.PrimaryActorSystem, *vividtest.TestProbe, vivid.ActorRef, string) {
+	senderAddr, receiverAddr := freeAddress(t), freeAddress(t)
+	sender := vividtest.NewActorSystem(t,
+		vivid.WithActorSystemRemoting(senderAddr),
+		vivid.WithActorSystemRemotingOption(vivid.WithActorSystemRemotingTransport(transport)),
+	)
+	receiver := vividtest.NewActorSystem(t, vivid.WithActorSystemRemoting(receiverAddr))
+	probe := vividtest.NewTestProbe(t, receiver)
+	ref, err := sender.CreateRef(receiverAddr, probe.Ref().GetPath())
+	require.NoError(t, err)
+
+	sender.Tell(ref, &faultMessage{Text: "hello"})
+	probe.ExpectMessage(&faultMessage{Text: "hello"})
+	return sender, probe, ref, receiverAddr
+}
+
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+func TestFaultTransport_DropAndLatency(t *testing.T) {
+	transport := vividtest.NewFaultTransport(vividtest.WithFaultTransportSeed(1))
+	sender, probe, ref, addr := newFaultPair(t, transport)
+
+	transport.SetFaults(addr, vividtest.Faults{DropRate: 1})
+	sender.Tell(ref, &faultMessage{Text: "lost"})
+	probe.ExpectNoMessage(100 * time.Millisecond)
+	assert.Equal(t, 1, transport.Stats(addr).Dropped)
+
+	transport.SetFaults(addr, vividtest.Faults{Latency: 200 * time.Millisecond, Jitter: 50 * time.Millisecond})
+	start := time.Now()
+	sender.Tell(ref, &faultMessage{Text: "slow-1"})
+	sender.Tell(ref, &faultMessage{Text: "slow-2"})
+	probe.ExpectMessage(&faultMessage{Text: "slow-1"})
+	probe.ExpectMessage(&faultMessage{Text: "slow-2"})
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestFaultTransport_Reorder(t *testing.T) {
+	transport := vividtest.NewFaultTransport()
+	sender, probe, ref, addr := newFaultPair(t, transport)
+
+	transport.SetFaults(addr, vividtest.Faults{ReorderRate: 1})
+	sender.Tell(ref, &faultMessage{Text: "first"})
+	transport.ClearFaults(addr)
+	sender.Tell(ref, &faultMessage{Text: "second"})
+	probe.ExpectMessage(&faultMessage{Text: "second"})
+	probe.ExpectMessage(&faultMessage{Text: "first"})
+	assert.Equal(t, 1, transport.Stats(addr).Reordered)
+}
+
+func TestFaultTransport_ResetAndRefuseDial(t *testing.T) {
+	transport := vividtest.NewFaultTransport()
+	sender, probe, ref, addr := newFaultPair(t, transport)
+
+	transport.SetFaults(addr, vividtest.Faults{RefuseDial: true})
+	assert.Equal(t, 1, transport.Reset(addr))
+	time.AfterFunc(300*time.Millisecond, func() {
+		transport.ClearFaults(addr)
+	})
+
+	// 连接被重置且拨号被拒绝，远程邮箱退避重连直至故障解除
+	sender.Tell(ref, &faultMessage{Text: "after-reset"})
+	probe.ExpectMessage(&faultMessage{Text: "after-reset"})
+	stats := transport.Stats(addr)
+	assert.Equal(t, 1, stats.Resets)
+	assert.Positive(t, stats.RefusedDials)
+	assert.Greater(t, stats.Dials, stats.RefusedDials)
+}
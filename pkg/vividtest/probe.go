@@ -0,0 +1,269 @@
+package vividtest
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+)
+
+const (
+	// DefaultTimeout TestProbe 等待消息的默认超时时间。
+	DefaultTimeout = 3 * time.Second
+)
+
+// ProbeOptions TestProbe 配置。
+type ProbeOptions struct {
+	// Timeout Expect 系列方法等待消息的超时时间；≤0 时使用 DefaultTimeout。
+	Timeout time.Duration
+	// ActorOptions 创建探针 Actor 时使用的选项，例如 vivid.WithActorName。
+	ActorOptions []vivid.ActorOption
+}
+
+// ProbeOption 是用于配置 ProbeOptions 的函数类型。
+type ProbeOption = func(*ProbeOptions)
+
+// WithProbeTimeout 返回一个 ProbeOption，用于设置等待消息的超时时间。
+func WithProbeTimeout(timeout time.Duration) ProbeOption {
+	return func(o *ProbeOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// WithProbeActorOptions 返回一个 ProbeOption，用于设置创建探针 Actor 时的选项。
+func WithProbeActorOptions(options ...vivid.ActorOption) ProbeOption {
+	return func(o *ProbeOptions) {
+		o.ActorOptions = append(o.ActorOptions, options...)
+	}
+}
+
+// TestProbe 测试探针：内部运行一个 Actor，将收到的消息按顺序排队，供测试 goroutine 断言。
+//
+// 探针 Actor 的 OnLaunch、OnKill 等自身生命周期消息不会入队；被 Watch 的 Actor 终止时的 *vivid.OnKilled 会入队。
+// Expect 系列方法在断言失败时调用 t.Fatalf，须在测试 goroutine 中调用。
+//
+// system 为 *DeterministicSystem 时，探针在取消息与执行命令前先投递全部待处理消息，队列为空即视为超时，不等待真实时间。
+type TestProbe struct {
+	t             testing.TB
+	system        vivid.ActorSystem
+	deterministic *DeterministicSystem
+	ref           vivid.ActorRef
+	options       ProbeOptions
+
+	mu      sync.Mutex
+	queue   []envelope
+	notify  chan struct{}
+	last    envelope
+	watched map[string]bool
+}
+
+type envelope struct {
+	message vivid.Message
+	sender  vivid.ActorRef
+}
+
+// probeCommand 在探针 Actor 上下文中执行的操作，用于以探针身份发送消息或 Watch。
+type probeCommand struct {
+	fn   func(ctx vivid.ActorContext)
+	done chan struct{}
+}
+
+// NewTestProbe 在 system 下创建测试探针，创建失败时测试失败。
+func NewTestProbe(t testing.TB, system vivid.ActorSystem, opts ...ProbeOption) *TestProbe {
+	t.Helper()
+	p := &TestProbe{
+		t:       t,
+		system:  system,
+		notify:  make(chan struct{}, 1),
+		watched: make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(&p.options)
+	}
+	if p.options.Timeout <= 0 {
+		p.options.Timeout = DefaultTimeout
+	}
+	p.deterministic, _ = system.(*DeterministicSystem)
+	ref, err := system.ActorOf(vivid.ActorFN(p.onReceive), p.options.ActorOptions...)
+	if err != nil {
+		t.Fatalf("vividtest: spawn probe: %v", err)
+	}
+	p.ref = ref
+	return p
+}
+
+func (p *TestProbe) onReceive(ctx vivid.ActorContext) {
+	switch m := ctx.Message().(type) {
+	case *vivid.OnLaunch, *vivid.OnKill:
+	case *probeCommand:
+		m.fn(ctx)
+		close(m.done)
+	default:
+		p.mu.Lock()
+		p.queue = append(p.queue, envelope{message: m, sender: ctx.Sender()})
+		p.mu.Unlock()
+		select {
+		case p.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Ref 返回探针 Actor 的 ActorRef，可作为被测 Actor 的消息接收方。
+func (p *TestProbe) Ref() vivid.ActorRef {
+	return p.ref
+}
+
+// Sender 返回最近一次 Expect 取得的消息的发送方。
+func (p *TestProbe) Sender() vivid.ActorRef {
+	return p.last.sender
+}
+
+// Tell 以探针为发送方向 target 发送 message，target 的回复将进入探针队列。
+func (p *TestProbe) Tell(target vivid.ActorRef, message vivid.Message) {
+	p.t.Helper()
+	p.execute(func(ctx vivid.ActorContext) {
+		ctx.Tell(target, message)
+	})
+}
+
+// Reply 向最近一次 Expect 取得的消息的发送方回复 message，发送方可以是 Ask 的等待方。
+func (p *TestProbe) Reply(message vivid.Message) {
+	p.t.Helper()
+	sender := p.last.sender
+	if sender == nil {
+		p.t.Fatalf("vividtest: no sender to reply to")
+	}
+	p.Tell(sender, message)
+}
+
+// Watch 由探针 Watch ref，ref 终止后探针收到 *vivid.OnKilled。
+func (p *TestProbe) Watch(ref vivid.ActorRef) {
+	p.t.Helper()
+	p.mu.Lock()
+	p.watched[ref.String()] = true
+	p.mu.Unlock()
+	p.execute(func(ctx vivid.ActorContext) {
+		ctx.Watch(ref)
+	})
+}
+
+// ExpectMessage 等待下一条消息并断言其与 expected 相等（reflect.DeepEqual），返回收到的消息。
+func (p *TestProbe) ExpectMessage(expected vivid.Message) vivid.Message {
+	p.t.Helper()
+	message := p.receive(p.options.Timeout, "message %#v", expected)
+	if !reflect.DeepEqual(message, expected) {
+		p.t.Fatalf("vividtest: expected message %#v, got %#v", expected, message)
+	}
+	return message
+}
+
+// ExpectMessageType 等待 p 的下一条消息并断言其类型为 T，返回转换后的消息。
+func ExpectMessageType[T any](p *TestProbe) T {
+	p.t.Helper()
+	var zero T
+	message := p.receive(p.options.Timeout, "message of type %T", zero)
+	typed, ok := message.(T)
+	if !ok {
+		p.t.Fatalf("vividtest: expected message of type %T, got %#v", zero, message)
+	}
+	return typed
+}
+
+// ExpectNoMessage 断言在 d 内未收到任何消息；确定性 ActorSystem 下断言投递全部待处理消息后未收到消息，忽略 d。
+func (p *TestProbe) ExpectNoMessage(d time.Duration) {
+	p.t.Helper()
+	if message, ok := p.poll(d); ok {
+		p.t.Fatalf("vividtest: expected no message within %s, got %#v", d, message.message)
+	}
+}
+
+// FishForMessage 依次取出消息直到 fn 返回 true，之前的消息被丢弃；超时未找到时测试失败。返回匹配的消息。
+func (p *TestProbe) FishForMessage(fn func(message vivid.Message) bool) vivid.Message {
+	p.t.Helper()
+	deadline := time.Now().Add(p.options.Timeout)
+	for {
+		message := p.receive(time.Until(deadline), "message matching predicate")
+		if fn(message) {
+			return message
+		}
+	}
+}
+
+// ExpectTerminated 等待 ref 终止的 *vivid.OnKilled，期间的其他消息被丢弃。
+// 探针尚未 Watch ref 时会先行 Watch；ref 已终止时 Watch 无效，因此应在触发终止前调用 Watch。
+func (p *TestProbe) ExpectTerminated(ref vivid.ActorRef) {
+	p.t.Helper()
+	p.mu.Lock()
+	watched := p.watched[ref.String()]
+	p.mu.Unlock()
+	if !watched {
+		p.Watch(ref)
+	}
+	deadline := time.Now().Add(p.options.Timeout)
+	for {
+		message := p.receive(time.Until(deadline), "termination of %s", ref)
+		if killed, ok := message.(*vivid.OnKilled); ok && killed.Ref != nil && killed.Ref.Equals(ref) {
+			return
+		}
+	}
+}
+
+// receive 等待下一条消息，超时时以 format 描述期望并使测试失败。
+func (p *TestProbe) receive(timeout time.Duration, format string, args ...any) vivid.Message {
+	p.t.Helper()
+	message, ok := p.poll(timeout)
+	if !ok {
+		p.t.Fatalf("vividtest: timeout after %s waiting for "+format, append([]any{p.options.Timeout}, args...)...)
+	}
+	p.last = message
+	return message.message
+}
+
+func (p *TestProbe) poll(timeout time.Duration) (envelope, bool) {
+	if p.deterministic != nil {
+		p.deterministic.RunUntilIdle()
+		return p.pop()
+	}
+	timer := time.NewTimer(max(timeout, 0))
+	defer timer.Stop()
+	for {
+		if message, ok := p.pop(); ok {
+			return message, true
+		}
+		select {
+		case <-p.notify:
+		case <-timer.C:
+			return envelope{}, false
+		}
+	}
+}
+
+func (p *TestProbe) pop() (envelope, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.queue) == 0 {
+		return envelope{}, false
+	}
+	message := p.queue[0]
+	p.queue[0] = envelope{}
+	p.queue = p.queue[1:]
+	return message, true
+}
+
+// execute 在探针 Actor 中执行 fn 并等待完成，保证之后的消息发送顺序。
+func (p *TestProbe) execute(fn func(ctx vivid.ActorContext)) {
+	p.t.Helper()
+	command := &probeCommand{fn: fn, done: make(chan struct{})}
+	p.system.Tell(p.ref, command)
+	if p.deterministic != nil {
+		p.deterministic.RunUntilIdle()
+	}
+	select {
+	case <-command.done:
+	case <-time.After(p.options.Timeout):
+		p.t.Fatalf("vividtest: probe did not respond within %s", p.options.Timeout)
+	}
+}
@@ -0,0 +1,133 @@
+package vividtest_test
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/pkg/vividtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ping struct {
+	N int
+}
+
+type pong struct {
+	N int
+}
+
+// newEchoActor 对 ping 回复 pong，对 string 原样回复。
+func newEchoActor() vivid.Actor {
+	return vivid.ActorFN(func(ctx vivid.ActorContext) {
+		switch m := ctx.Message().(type) {
+		case *ping:
+			ctx.Reply(&pong{N: m.N})
+		case string:
+			ctx.Reply(m)
+		}
+	})
+}
+
+func TestTestProbe_ExpectMessage(t *testing.T) {
+	system := vividtest.NewActorSystem(t)
+	probe := vividtest.NewTestProbe(t, system)
+	echo, err := system.ActorOf(newEchoActor())
+	require.NoError(t, err)
+
+	probe.Tell(echo, "hello")
+	probe.ExpectMessage("hello")
+	assert.True(t, probe.Sender().Equals(echo))
+
+	probe.Tell(echo, &ping{N: 1})
+	assert.Equal(t, 1, vividtest.ExpectMessageType[*pong](probe).N)
+	probe.ExpectNoMessage(50 * time.Millisecond)
+}
+
+func TestTestProbe_Reply(t *testing.T) {
+	system := vividtest.NewActorSystem(t)
+	probe := vividtest.NewTestProbe(t, system)
+
+	future := system.Ask(probe.Ref(), &ping{N: 7})
+	assert.Equal(t, 7, vividtest.ExpectMessageType[*ping](probe).N)
+	probe.Reply(&pong{N: 7})
+
+	reply, err := future.Result()
+	require.NoError(t, err)
+	assert.Equal(t, &pong{N: 7}, reply)
+}
+
+func TestTestProbe_FishForMessage(t *testing.T) {
+	system := vividtest.NewActorSystem(t)
+	probe := vividtest.NewTestProbe(t, system)
+
+	for i := range 5 {
+		system.Tell(probe.Ref(), &ping{N: i})
+	}
+	message := probe.FishForMessage(func(message vivid.Message) bool {
+		m, ok := message.(*ping)
+		return ok && m.N == 3
+	})
+	assert.Equal(t, &ping{N: 3}, message)
+	probe.ExpectMessage(&ping{N: 4})
+}
+
+func TestTestProbe_ExpectTerminated(t *testing.T) {
+	system := vividtest.NewActorSystem(t)
+	probe := vividtest.NewTestProbe(t, system)
+	target, err := system.ActorOf(newEchoActor())
+	require.NoError(t, err)
+
+	probe.Watch(target)
+	system.Kill(target, false)
+	probe.ExpectTerminated(target)
+}
+
+// recorder 记录 Fatalf 并以 runtime.Goexit 终止调用方 goroutine，用于验证断言失败路径。
+type recorder struct {
+	testing.TB
+	mu      sync.Mutex
+	failure string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.mu.Lock()
+	r.failure = fmt.Sprintf(format, args...)
+	r.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (r *recorder) run(fn func()) string {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	<-done
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.failure
+}
+
+func TestTestProbe_Failures(t *testing.T) {
+	system := vividtest.NewActorSystem(t)
+	r := &recorder{TB: t}
+	probe := vividtest.NewTestProbe(r, system, vividtest.WithProbeTimeout(50*time.Millisecond))
+
+	assert.Contains(t, r.run(func() { probe.ExpectMessage("missing") }), "timeout")
+
+	system.Tell(probe.Ref(), "actual")
+	assert.Contains(t, r.run(func() { probe.ExpectMessage("expected") }), `got "actual"`)
+
+	system.Tell(probe.Ref(), "actual")
+	assert.Contains(t, r.run(func() { vividtest.ExpectMessageType[*ping](probe) }), "expected message of type *vividtest_test.ping")
+
+	system.Tell(probe.Ref(), "unexpected")
+	assert.Contains(t, r.run(func() { probe.ExpectNoMessage(50 * time.Millisecond) }), "expected no message")
+}
@@ -0,0 +1,36 @@
+// Package vividtest 提供编写 Actor 测试的辅助工具：随测试结束自动停止的 ActorSystem，
+// 可断言收到消息的 TestProbe，以及可逐条投递消息、手动推进时间的确定性 ActorSystem。
+//
+// 典型用法：
+//
+//	func TestEcho(t *testing.T) {
+//		system := vividtest.NewActorSystem(t)
+//		probe := vividtest.NewTestProbe(t, system)
+//		echo, _ := system.ActorOf(newEchoActor())
+//
+//		probe.Tell(echo, "hello")
+//		probe.ExpectMessage("hello")
+//	}
+package vividtest
+
+import (
+	"testing"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/pkg/bootstrap"
+)
+
+// NewActorSystem 创建并启动一个 ActorSystem，并通过 t.Cleanup 在测试结束时停止；启动或停止失败时测试失败。
+func NewActorSystem(t testing.TB, options ...vivid.ActorSystemOption) vivid.PrimaryActorSystem {
+	t.Helper()
+	system := bootstrap.NewActorSystem(options...)
+	if err := system.Start(); err != nil {
+		t.Fatalf("vividtest: start actor system: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := system.Stop(); err != nil {
+			t.Errorf("vividtest: stop actor system: %v", err)
+		}
+	})
+	return system
+}
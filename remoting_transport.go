@@ -0,0 +1,22 @@
+package vivid
+
+import "net"
+
+// RemotingTransport 远程传输层，负责建立到对端节点的出站连接。
+//
+// 远程消息总是经由出站连接发送：连接建立后先写入一次握手数据，此后每次 Write 恰好写入一个完整的消息帧（4 字节长度前缀与消息体）。
+// 实现可以包装底层连接，在测试中注入延迟、丢包、重排或连接重置等故障（参见 vividtest.FaultTransport）。
+type RemotingTransport interface {
+	// Dial 建立到 address（对端的广告地址 host:port）的连接。
+	Dial(address string) (net.Conn, error)
+}
+
+// WithActorSystemRemotingTransport 返回一个 ActorSystemRemotingOption，用于指定 Remoting 的出站传输层。
+//
+// 参数：
+//   - transport: 期望设置的传输层，为 nil 时直接使用 TCP 拨号。
+func WithActorSystemRemotingTransport(transport RemotingTransport) ActorSystemRemotingOption {
+	return func(opts *ActorSystemRemotingOptions) {
+		opts.Transport = transport
+	}
+}
@@ -0,0 +1,130 @@
+package vivid
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var _ SupervisionStrategyDecisionMaker = (*SupervisionDecider)(nil)
+
+// FaultMatcher 判断故障是否命中某条决策规则，fault 为 panic 值或 ActorContext.Failed 上报的消息。
+type FaultMatcher func(fault Message) bool
+
+// FaultIs 返回一个 FaultMatcher：故障为 error 时按 errors.Is 匹配 target，否则按值相等匹配。
+//
+// target 为 nil 时仅匹配 nil 故障；不可比较的值永不匹配。
+func FaultIs(target Message) FaultMatcher {
+	return func(fault Message) bool {
+		if targetErr, ok := target.(error); ok {
+			if faultErr, ok := fault.(error); ok {
+				return errors.Is(faultErr, targetErr)
+			}
+		}
+		return faultEquals(fault, target)
+	}
+}
+
+// FaultOfType 返回一个 FaultMatcher，当故障或其错误链（Unwrap）中任一值的类型为 T 时匹配。
+//
+// T 可为具体类型或接口类型，非 error 的 panic 值同样可按类型匹配，如 FaultOfType[string]()。
+func FaultOfType[T any]() FaultMatcher {
+	return func(fault Message) bool {
+		return walkFault(fault, func(v Message) bool {
+			_, ok := v.(T)
+			return ok
+		})
+	}
+}
+
+// decisionRule 一条“匹配 -> 决策”的规则。
+type decisionRule struct {
+	matcher  FaultMatcher
+	decision SupervisionDecision
+	label    string
+}
+
+// SupervisionDecider 按故障类型声明式地选择监督决策，实现 SupervisionStrategyDecisionMaker，可直接用于 OneForOneStrategy 与 OneForAllStrategy。
+//
+// 规则按添加顺序匹配，首个命中的规则决定结果；均未命中时使用 Default 设置的决策，未设置时为 SupervisionDecisionEscalate。
+// 应在构建完成后再交由监督策略使用，构建过程非并发安全。
+//
+// 示例：
+//
+//	decider := vivid.Decide().
+//		On(ErrTimeout, vivid.SupervisionDecisionRestart).
+//		OnType(vivid.FaultOfType[*MyErr](), vivid.SupervisionDecisionStop).
+//		Default(vivid.SupervisionDecisionEscalate)
+//	strategy := vivid.OneForOneStrategy(decider)
+type SupervisionDecider struct {
+	rules           []decisionRule
+	defaultDecision SupervisionDecision
+}
+
+// Decide 创建一个空的 SupervisionDecider。
+func Decide() *SupervisionDecider {
+	return &SupervisionDecider{defaultDecision: SupervisionDecisionEscalate}
+}
+
+// On 添加规则：故障命中 FaultIs(target) 时返回 decision。
+func (d *SupervisionDecider) On(target Message, decision SupervisionDecision) *SupervisionDecider {
+	d.rules = append(d.rules, decisionRule{matcher: FaultIs(target), decision: decision, label: fmt.Sprintf("%v", target)})
+	return d
+}
+
+// OnType 添加规则：故障命中 matcher 时返回 decision。
+//
+// Go 方法不支持类型参数，因此类型匹配以 FaultOfType[T]() 的形式传入；也可传入自定义的 FaultMatcher。
+func (d *SupervisionDecider) OnType(matcher FaultMatcher, decision SupervisionDecision) *SupervisionDecider {
+	d.rules = append(d.rules, decisionRule{matcher: matcher, decision: decision, label: "matcher"})
+	return d
+}
+
+// Default 设置所有规则均未命中时的决策。
+func (d *SupervisionDecider) Default(decision SupervisionDecision) *SupervisionDecider {
+	d.defaultDecision = decision
+	return d
+}
+
+// MakeDecision 实现 SupervisionStrategyDecisionMaker 接口。
+func (d *SupervisionDecider) MakeDecision(ctx SupervisionContext) (decision SupervisionDecision, reason string) {
+	fault := ctx.Fault()
+	for i, rule := range d.rules {
+		if rule.matcher != nil && rule.matcher(fault) {
+			return rule.decision, fmt.Sprintf("fault %T matched rule #%d (%s)", fault, i+1, rule.label)
+		}
+	}
+	return d.defaultDecision, fmt.Sprintf("fault %T matched no rule, using default decision", fault)
+}
+
+// walkFault 依次对 fault 及其错误链（Unwrap() error 与 Unwrap() []error）中的值调用 fn，任一返回 true 即返回 true。
+func walkFault(fault Message, fn func(v Message) bool) bool {
+	if fn(fault) {
+		return true
+	}
+	switch err := fault.(type) {
+	case interface{ Unwrap() error }:
+		if inner := err.Unwrap(); inner != nil {
+			return walkFault(inner, fn)
+		}
+	case interface{ Unwrap() []error }:
+		for _, inner := range err.Unwrap() {
+			if inner != nil && walkFault(inner, fn) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// faultEquals 比较两个值是否相等，避免对不可比较的类型使用 == 导致 panic。
+func faultEquals(a, b Message) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	typ := reflect.TypeOf(a)
+	if typ != reflect.TypeOf(b) || !typ.Comparable() {
+		return false
+	}
+	return a == b
+}
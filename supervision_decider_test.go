@@ -0,0 +1,70 @@
+package vivid_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kercylan98/vivid"
+	"github.com/kercylan98/vivid/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+var errTestTimeout = errors.New("timeout")
+
+type testFaultErr struct {
+	code int
+}
+
+func (e *testFaultErr) Error() string {
+	return fmt.Sprintf("fault %d", e.code)
+}
+
+// faultContext 仅提供 Fault 的 SupervisionContext。
+type faultContext struct {
+	fault vivid.Message
+}
+
+func (c faultContext) Logger() log.Logger                    { return nil }
+func (c faultContext) Child() vivid.ActorRefs                { return nil }
+func (c faultContext) Children() vivid.ActorRefs             { return nil }
+func (c faultContext) Fault() vivid.Message                  { return c.fault }
+func (c faultContext) FaultStack() []byte                    { return nil }
+func (c faultContext) FailureHistory() []vivid.FailureRecord { return nil }
+func (c faultContext) FailureCount(time.Duration) int        { return 1 }
+
+func TestSupervisionDecider(t *testing.T) {
+	decider := vivid.Decide().
+		On(errTestTimeout, vivid.SupervisionDecisionRestart).
+		OnType(vivid.FaultOfType[*testFaultErr](), vivid.SupervisionDecisionStop).
+		On("reload", vivid.SupervisionDecisionResume).
+		OnType(vivid.FaultOfType[int](), vivid.SupervisionDecisionGracefulStop).
+		Default(vivid.SupervisionDecisionGracefulRestart)
+
+	cases := []struct {
+		name     string
+		fault    vivid.Message
+		expected vivid.SupervisionDecision
+	}{
+		{"errors.Is", errTestTimeout, vivid.SupervisionDecisionRestart},
+		{"wrapped errors.Is", fmt.Errorf("dial: %w", errTestTimeout), vivid.SupervisionDecisionRestart},
+		{"type", &testFaultErr{code: 1}, vivid.SupervisionDecisionStop},
+		{"wrapped type", fmt.Errorf("query: %w", &testFaultErr{code: 2}), vivid.SupervisionDecisionStop},
+		{"joined type", errors.Join(errors.New("other"), &testFaultErr{code: 3}), vivid.SupervisionDecisionStop},
+		{"panic value", "reload", vivid.SupervisionDecisionResume},
+		{"panic value type", 42, vivid.SupervisionDecisionGracefulStop},
+		{"uncomparable panic value", []string{"reload"}, vivid.SupervisionDecisionGracefulRestart},
+		{"default", errors.New("unknown"), vivid.SupervisionDecisionGracefulRestart},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			decision, reason := decider.MakeDecision(faultContext{fault: c.fault})
+			assert.Equal(t, c.expected, decision)
+			assert.NotEmpty(t, reason)
+		})
+	}
+
+	decision, _ := vivid.Decide().MakeDecision(faultContext{fault: "anything"})
+	assert.Equal(t, vivid.SupervisionDecisionEscalate, decision, "decider without Default escalates")
+}
@@ -0,0 +1,29 @@
+package vivid
+
+import "time"
+
+// DefaultFailureHistorySize 每个 Actor 默认保留的故障记录条数。
+const DefaultFailureHistorySize = 16
+
+// FailureRecord 一次故障及其监督结果的记录。
+//
+// 记录由监督者在做出决策后写入发生故障的 Actor，随 Actor 重启保留，Actor 终止后随之释放。
+type FailureRecord struct {
+	Time       time.Time           // 故障发生时间
+	Fault      Message             // 故障内容，通常为 panic 的值或 ActorContext.Failed 上报的消息
+	Supervisor ActorRef            // 做出决策的监督者
+	Decision   SupervisionDecision // 监督决策
+	Reason     string              // 决策原因
+}
+
+// WithActorSystemFailureHistorySize 返回一个 ActorSystemOption，用于指定每个 Actor 保留的故障记录条数。
+//
+// 超出上限时丢弃最早的记录，可通过 SupervisionContext.FailureHistory 与 ActorSystem.FailureHistory 查询。
+//
+// 参数：
+//   - size: 保留条数，≤0 时使用 DefaultFailureHistorySize。
+func WithActorSystemFailureHistorySize(size int) ActorSystemOption {
+	return func(opts *ActorSystemOptions) {
+		opts.FailureHistorySize = size
+	}
+}
@@ -2,6 +2,7 @@ package vivid
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/kercylan98/vivid/internal/utils"
@@ -119,15 +120,134 @@ type SupervisionContext interface {
 
 	// FaultStack 获取故障堆栈。
 	FaultStack() []byte
+
+	// FailureHistory 获取触发本次监督的子 Actor 此前的故障记录（由旧到新），不含本次故障。
+	FailureHistory() []FailureRecord
+
+	// FailureCount 获取触发本次监督的子 Actor 在最近 within 时间内的故障次数（含本次），within ≤0 时统计全部保留的记录。
+	// 决策器可据此按故障频率选择决策。
+	FailureCount(within time.Duration) int
+}
+
+// restartBudgetReporter 由框架内部的监督上下文实现，内置监督策略借此报告重启次数超限，框架据此发布 ves.ActorRestartBudgetExhaustedEvent。
+// 不属于 SupervisionContext 的公开约定，自定义的 SupervisionContext 无需实现。
+type restartBudgetReporter interface {
+	// ReportRestartBudgetExhausted 报告 child 在 within 时间窗口内的重启次数已超出 maxRetries，决策已改为 decision。
+	ReportRestartBudgetExhausted(child ActorRef, maxRetries int, within time.Duration, decision SupervisionDecision)
+}
+
+// supervisionClock 由框架内部的监督上下文实现，提供 ActorSystem 的时钟（参见 WithActorSystemClock），使重启次数统计与虚拟时钟一致。
+type supervisionClock interface {
+	// Clock 返回 ActorSystem 的时钟。
+	Clock() Clock
+}
+
+// childTerminationObserver 由内置监督策略实现，框架在子 Actor 终止（非重启）后通知策略清理该子 Actor 的重启记录。
+type childTerminationObserver interface {
+	// ChildTerminated 通知 child 已终止。
+	ChildTerminated(child ActorRef)
+}
+
+var (
+	_ childTerminationObserver = (*oneForOneStrategy)(nil)
+	_ childTerminationObserver = (*oneForAllStrategy)(nil)
+)
+
+// restartLimiter 按子 Actor 记录重启历史，实现“时间窗口内最多重启 N 次”的限制。
+//
+// 同一监督策略可能被多个监督者并发使用，因此内部加锁。
+type restartLimiter struct {
+	maxRetries int                    // 窗口内允许的最大重启次数，≤0 表示不限制
+	within     time.Duration          // 统计窗口，≤0 表示不限时长
+	exceeded   SupervisionDecision    // 超出限制后的决策
+	lock       sync.Mutex             // 保护 history
+	history    map[string][]time.Time // 子 Actor 引用 -> 窗口内的重启时刻
+}
+
+func newRestartLimiter(maxRetries int, within time.Duration, exceeded SupervisionDecision) *restartLimiter {
+	if !exceeded.IsStop() && !exceeded.IsEscalate() {
+		exceeded = SupervisionDecisionStop
+	}
+	return &restartLimiter{
+		maxRetries: maxRetries,
+		within:     within,
+		exceeded:   exceeded,
+		history:    make(map[string][]time.Time),
+	}
+}
+
+// limit 在决策为重启时记录一次重启，若超出限制则将决策改为 Stop 或 Escalate，ctx 实现 restartBudgetReporter 时向其报告。
+func (l *restartLimiter) limit(ctx SupervisionContext, decision SupervisionDecision, reason string) (SupervisionDecision, string) {
+	if l.maxRetries <= 0 || !decision.IsRestart() {
+		return decision, reason
+	}
+	child := ctx.Child().First()
+	if child == nil {
+		return decision, reason
+	}
+
+	now := time.Now()
+	if c, ok := ctx.(supervisionClock); ok && c.Clock() != nil {
+		now = c.Clock().Now()
+	}
+	key := child.String()
+	l.lock.Lock()
+	if l.within > 0 {
+		l.prune(now)
+	}
+	restarts := append(l.history[key], now)
+	exhausted := len(restarts) > l.maxRetries
+	if exhausted {
+		delete(l.history, key)
+	} else {
+		l.history[key] = restarts
+	}
+	l.lock.Unlock()
+
+	if !exhausted {
+		return decision, reason
+	}
+	if reporter, ok := ctx.(restartBudgetReporter); ok {
+		reporter.ReportRestartBudgetExhausted(child, l.maxRetries, l.within, l.exceeded)
+	}
+	return l.exceeded, fmt.Sprintf("restart budget exhausted: more than %d restarts within %s, last reason: %s", l.maxRetries, l.within, reason)
+}
+
+// forget 清除 child 的重启记录，避免已终止的子 Actor（尤其是不限时长统计时）的记录常驻，以及同名子 Actor 重新创建后沿用旧记录。
+func (l *restartLimiter) forget(child ActorRef) {
+	if l.maxRetries <= 0 || child == nil {
+		return
+	}
+	l.lock.Lock()
+	delete(l.history, child.String())
+	l.lock.Unlock()
+}
+
+// prune 移除统计窗口之外的重启记录，调用方需持有锁。
+func (l *restartLimiter) prune(now time.Time) {
+	for key, restarts := range l.history {
+		i := 0
+		for i < len(restarts) && now.Sub(restarts[i]) > l.within {
+			i++
+		}
+		if i == len(restarts) {
+			delete(l.history, key)
+		} else if i > 0 {
+			l.history[key] = restarts[i:]
+		}
+	}
 }
 
 type OneForOneStrategyOption func(options *OneForOneStrategyOptions)
 
 type OneForOneStrategyOptions struct {
-	InitialDelay time.Duration // 初始延迟时间，默认 1 秒
-	MaxDelay     time.Duration // 最大延迟时间，默认 1 分钟
-	Factor       float64       // 退避因子，默认 2.0
-	Jitter       bool          // 是否启用抖动，默认 true
+	InitialDelay     time.Duration       // 初始延迟时间，默认 1 秒
+	MaxDelay         time.Duration       // 最大延迟时间，默认 1 分钟
+	Factor           float64             // 退避因子，默认 2.0
+	Jitter           bool                // 是否启用抖动，默认 true
+	MaxRetries       int                 // WithinDuration 内每个子 Actor 允许的最大重启次数，默认 0 表示不限制
+	WithinDuration   time.Duration       // 重启次数的统计窗口，默认 0 表示不限时长
+	ExceededDecision SupervisionDecision // 超出重启次数后的决策，仅可为停止或升级，默认 SupervisionDecisionStop
 }
 
 // WithOneForOneStrategyOptions 返回一个设置 OneForOneStrategyOptions 的配置项。
@@ -202,6 +322,48 @@ func WithOneForOneStrategyJitter(jitter bool) OneForOneStrategyOption {
 	}
 }
 
+// WithOneForOneStrategyMaxRetries 返回一个设置 OneForOneStrategyOptions.MaxRetries 的配置项。
+//
+// maxRetries 为每个子 Actor 在 WithinDuration 内允许的最大重启次数，超出后决策改为 ExceededDecision，
+// 并发布 ves.ActorRestartBudgetExhaustedEvent。
+// 如果 maxRetries <= 0，则不限制重启次数。
+//
+// 返回:
+//   - OneForOneStrategyOption: 一个设置 OneForOneStrategyOptions.MaxRetries 的配置项。
+func WithOneForOneStrategyMaxRetries(maxRetries int) OneForOneStrategyOption {
+	return func(options *OneForOneStrategyOptions) {
+		options.MaxRetries = maxRetries
+	}
+}
+
+// WithOneForOneStrategyWithinDuration 返回一个设置 OneForOneStrategyOptions.WithinDuration 的配置项。
+//
+// within 为重启次数的统计窗口，仅统计最近 within 内发生的重启。
+// 如果 within <= 0，则统计子 Actor 的全部重启。
+//
+// 返回:
+//   - OneForOneStrategyOption: 一个设置 OneForOneStrategyOptions.WithinDuration 的配置项。
+func WithOneForOneStrategyWithinDuration(within time.Duration) OneForOneStrategyOption {
+	return func(options *OneForOneStrategyOptions) {
+		options.WithinDuration = within
+	}
+}
+
+// WithOneForOneStrategyExceededDecision 返回一个设置 OneForOneStrategyOptions.ExceededDecision 的配置项。
+//
+// decision 为超出重启次数后的决策，仅接受停止（含优雅停止）或升级。
+// 如果 decision 为其他值，则使用默认值 SupervisionDecisionStop。
+//
+// 返回:
+//   - OneForOneStrategyOption: 一个设置 OneForOneStrategyOptions.ExceededDecision 的配置项。
+func WithOneForOneStrategyExceededDecision(decision SupervisionDecision) OneForOneStrategyOption {
+	return func(options *OneForOneStrategyOptions) {
+		if decision.IsStop() || decision.IsEscalate() {
+			options.ExceededDecision = decision
+		}
+	}
+}
+
 // OneForOneStrategy 返回一个一对一监督策略，使用指数退避算法。
 // 它将根据决策器返回的决策决定如何处理故障的 Actor。
 //
@@ -221,27 +383,38 @@ func OneForOneStrategy(decisionMaker SupervisionStrategyDecisionMaker, options .
 
 	return &oneForOneStrategy{
 		backoff:       utils.NewExponentialBackoff(opts.InitialDelay, opts.MaxDelay, opts.Factor, opts.Jitter),
+		limiter:       newRestartLimiter(opts.MaxRetries, opts.WithinDuration, opts.ExceededDecision),
 		decisionMaker: decisionMaker,
 	}
 }
 
 type oneForOneStrategy struct {
 	backoff       *utils.ExponentialBackoff
+	limiter       *restartLimiter
 	decisionMaker SupervisionStrategyDecisionMaker
 }
 
 func (strategy *oneForOneStrategy) Supervise(ctx SupervisionContext) (targets ActorRefs, decision SupervisionDecision, reason string) {
 	decision, reason = strategy.decisionMaker.MakeDecision(ctx)
+	decision, reason = strategy.limiter.limit(ctx, decision, reason)
 	return ctx.Child(), decision, reason
 }
 
+// ChildTerminated 实现 childTerminationObserver 接口。
+func (strategy *oneForOneStrategy) ChildTerminated(child ActorRef) {
+	strategy.limiter.forget(child)
+}
+
 type OneForAllStrategyOption func(options *OneForAllStrategyOptions)
 
 type OneForAllStrategyOptions struct {
-	InitialDelay time.Duration // 初始延迟时间，默认 1 秒
-	MaxDelay     time.Duration // 最大延迟时间，默认 1 分钟
-	Factor       float64       // 退避因子，默认 2.0
-	Jitter       bool          // 是否启用抖动，默认 true
+	InitialDelay     time.Duration       // 初始延迟时间，默认 1 秒
+	MaxDelay         time.Duration       // 最大延迟时间，默认 1 分钟
+	Factor           float64             // 退避因子，默认 2.0
+	Jitter           bool                // 是否启用抖动，默认 true
+	MaxRetries       int                 // WithinDuration 内每个子 Actor 允许的最大重启次数，默认 0 表示不限制
+	WithinDuration   time.Duration       // 重启次数的统计窗口，默认 0 表示不限时长
+	ExceededDecision SupervisionDecision // 超出重启次数后的决策，仅可为停止或升级，默认 SupervisionDecisionStop
 }
 
 // WithOneForAllStrategyOptions 返回一个设置 OneForAllStrategyOptions 的配置项。
@@ -316,6 +489,48 @@ func WithOneForAllStrategyJitter(jitter bool) OneForAllStrategyOption {
 	}
 }
 
+// WithOneForAllStrategyMaxRetries 返回一个设置 OneForAllStrategyOptions.MaxRetries 的配置项。
+//
+// maxRetries 为每个子 Actor 在 WithinDuration 内允许的最大重启次数，超出后决策改为 ExceededDecision，
+// 并发布 ves.ActorRestartBudgetExhaustedEvent。
+// 如果 maxRetries <= 0，则不限制重启次数。
+//
+// 返回:
+//   - OneForAllStrategyOption: 一个设置 OneForAllStrategyOptions.MaxRetries 的配置项。
+func WithOneForAllStrategyMaxRetries(maxRetries int) OneForAllStrategyOption {
+	return func(options *OneForAllStrategyOptions) {
+		options.MaxRetries = maxRetries
+	}
+}
+
+// WithOneForAllStrategyWithinDuration 返回一个设置 OneForAllStrategyOptions.WithinDuration 的配置项。
+//
+// within 为重启次数的统计窗口，仅统计最近 within 内发生的重启。
+// 如果 within <= 0，则统计子 Actor 的全部重启。
+//
+// 返回:
+//   - OneForAllStrategyOption: 一个设置 OneForAllStrategyOptions.WithinDuration 的配置项。
+func WithOneForAllStrategyWithinDuration(within time.Duration) OneForAllStrategyOption {
+	return func(options *OneForAllStrategyOptions) {
+		options.WithinDuration = within
+	}
+}
+
+// WithOneForAllStrategyExceededDecision 返回一个设置 OneForAllStrategyOptions.ExceededDecision 的配置项。
+//
+// decision 为超出重启次数后的决策，仅接受停止（含优雅停止）或升级。
+// 如果 decision 为其他值，则使用默认值 SupervisionDecisionStop。
+//
+// 返回:
+//   - OneForAllStrategyOption: 一个设置 OneForAllStrategyOptions.ExceededDecision 的配置项。
+func WithOneForAllStrategyExceededDecision(decision SupervisionDecision) OneForAllStrategyOption {
+	return func(options *OneForAllStrategyOptions) {
+		if decision.IsStop() || decision.IsEscalate() {
+			options.ExceededDecision = decision
+		}
+	}
+}
+
 // OneForAllStrategy 返回一个一对多监督策略，使用指数退避算法。
 // 它将根据决策器返回的决策决定如何处理故障的 Actor。
 //
@@ -335,16 +550,24 @@ func OneForAllStrategy(decisionMaker SupervisionStrategyDecisionMaker, options .
 
 	return &oneForAllStrategy{
 		backoff:       utils.NewExponentialBackoff(opts.InitialDelay, opts.MaxDelay, opts.Factor, opts.Jitter),
+		limiter:       newRestartLimiter(opts.MaxRetries, opts.WithinDuration, opts.ExceededDecision),
 		decisionMaker: decisionMaker,
 	}
 }
 
 type oneForAllStrategy struct {
 	backoff       *utils.ExponentialBackoff
+	limiter       *restartLimiter
 	decisionMaker SupervisionStrategyDecisionMaker
 }
 
 func (strategy *oneForAllStrategy) Supervise(ctx SupervisionContext) (targets ActorRefs, decision SupervisionDecision, reason string) {
 	decision, reason = strategy.decisionMaker.MakeDecision(ctx)
+	decision, reason = strategy.limiter.limit(ctx, decision, reason)
 	return ctx.Children(), decision, reason
 }
+
+// ChildTerminated 实现 childTerminationObserver 接口。
+func (strategy *oneForAllStrategy) ChildTerminated(child ActorRef) {
+	strategy.limiter.forget(child)
+}